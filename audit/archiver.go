@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+// Archiver keeps a copy of finished escalations before they are deleted from the cluster.
+type Archiver interface {
+	Archive(ctx context.Context, esc *kudov1alpha1.Escalation) error
+}
+
+// MultiArchiver archives escalations to all the given archivers, in order.
+// It reports the errors of all the failing archivers at once, the others have archived the escalation anyway.
+func MultiArchiver(archivers ...Archiver) Archiver { return multiArchiver(archivers) }
+
+type multiArchiver []Archiver
+
+func (m multiArchiver) Archive(ctx context.Context, esc *kudov1alpha1.Escalation) error {
+	var errs []error
+
+	for _, archiver := range m {
+		if err := archiver.Archive(ctx, esc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// NewFileArchiver returns an archiver appending escalations to a file, one JSON document per line.
+func NewFileArchiver(path string) Archiver {
+	return &fileArchiver{path: path}
+}
+
+type fileArchiver struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (a *fileArchiver) Archive(_ context.Context, esc *kudov1alpha1.Escalation) error {
+	rawEscalation, err := json.Marshal(esc)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(append(rawEscalation, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// NewHTTPArchiver returns an archiver posting escalations as JSON to an HTTP endpoint.
+func NewHTTPArchiver(url string, client *http.Client) Archiver {
+	return &httpArchiver{url: url, client: client}
+}
+
+type httpArchiver struct {
+	url    string
+	client *http.Client
+}
+
+func (a *httpArchiver) Archive(ctx context.Context, esc *kudov1alpha1.Escalation) error {
+	rawEscalation, err := json.Marshal(esc)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(rawEscalation))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("archive endpoint returned an unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
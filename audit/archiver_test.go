@@ -0,0 +1,264 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jlevesy/kudo/audit"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func archivedEscalation(name string) *kudov1alpha1.Escalation {
+	return &kudov1alpha1.Escalation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kudov1alpha1.EscalationSpec{
+			PolicyName: "some-policy",
+			Requestor:  "jean-testor",
+			Reason:     "I need moar power",
+		},
+		Status: kudov1alpha1.EscalationStatus{
+			State: kudov1alpha1.StateExpired,
+		},
+	}
+}
+
+func TestFileArchiver_Archive(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		escalations []*kudov1alpha1.Escalation
+		setup       func(t *testing.T, path string)
+		wantLines   []string
+		wantErr     bool
+	}{
+		{
+			desc:        "creates the file and writes the escalation as a JSON line",
+			escalations: []*kudov1alpha1.Escalation{archivedEscalation("esc-1")},
+			wantLines:   []string{"esc-1"},
+		},
+		{
+			desc:        "appends escalations to the existing file",
+			escalations: []*kudov1alpha1.Escalation{archivedEscalation("esc-2"), archivedEscalation("esc-3")},
+			setup: func(t *testing.T, path string) {
+				t.Helper()
+
+				rawEscalation, err := json.Marshal(archivedEscalation("esc-1"))
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(path, append(rawEscalation, '\n'), 0o600))
+			},
+			wantLines: []string{"esc-1", "esc-2", "esc-3"},
+		},
+		{
+			desc:        "reports an error if the file can't be opened",
+			escalations: []*kudov1alpha1.Escalation{archivedEscalation("esc-1")},
+			setup: func(t *testing.T, path string) {
+				t.Helper()
+
+				require.NoError(t, os.Mkdir(path, 0o700))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "archive.jsonl")
+
+			if testCase.setup != nil {
+				testCase.setup(t, path)
+			}
+
+			archiver := audit.NewFileArchiver(path)
+
+			for _, esc := range testCase.escalations {
+				err := archiver.Archive(context.Background(), esc)
+				if testCase.wantErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+			}
+
+			rawArchive, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSuffix(string(rawArchive), "\n"), "\n")
+
+			var gotNames []string
+
+			for _, line := range lines {
+				var esc kudov1alpha1.Escalation
+
+				require.NoError(t, json.Unmarshal([]byte(line), &esc))
+				gotNames = append(gotNames, esc.Name)
+			}
+
+			assert.Equal(t, testCase.wantLines, gotNames)
+		})
+	}
+}
+
+func TestHTTPArchiver_Archive(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		statusCode int
+		wantErr    string
+	}{
+		{
+			desc:       "posts the escalation",
+			statusCode: http.StatusOK,
+		},
+		{
+			desc:       "accepts any successful status",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			desc:       "reports client errors",
+			statusCode: http.StatusBadRequest,
+			wantErr:    "archive endpoint returned an unexpected status 400",
+		},
+		{
+			desc:       "reports server errors",
+			statusCode: http.StatusServiceUnavailable,
+			wantErr:    "archive endpoint returned an unexpected status 503",
+		},
+		{
+			desc:       "reports redirections that aren't followed",
+			statusCode: http.StatusNotModified,
+			wantErr:    "archive endpoint returned an unexpected status 304",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				gotMethod      string
+				gotContentType string
+				gotEscalation  kudov1alpha1.Escalation
+			)
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					gotMethod = req.Method
+					gotContentType = req.Header.Get("Content-Type")
+
+					rawEscalation, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					require.NoError(t, json.Unmarshal(rawEscalation, &gotEscalation))
+
+					rw.WriteHeader(testCase.statusCode)
+				}),
+			)
+			defer server.Close()
+
+			esc := archivedEscalation("esc-1")
+
+			err := audit.NewHTTPArchiver(server.URL, server.Client()).Archive(context.Background(), esc)
+			if testCase.wantErr != "" {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, http.MethodPost, gotMethod)
+			assert.Equal(t, "application/json", gotContentType)
+			assert.Equal(t, *esc, gotEscalation)
+		})
+	}
+}
+
+func TestHTTPArchiver_Archive_UnreachableEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	err := audit.NewHTTPArchiver(server.URL, server.Client()).Archive(context.Background(), archivedEscalation("esc-1"))
+	require.Error(t, err)
+}
+
+func TestMultiArchiver_Archive(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		archivers []*mockArchiver
+		wantErr   string
+	}{
+		{
+			desc:      "archives to all the archivers",
+			archivers: []*mockArchiver{{}, {}},
+		},
+		{
+			desc:      "archives to the other archivers if one fails",
+			archivers: []*mockArchiver{{err: errors.New("file is read only")}, {}},
+			wantErr:   "file is read only",
+		},
+		{
+			desc: "reports the errors of all the failing archivers",
+			archivers: []*mockArchiver{
+				{err: errors.New("file is read only")},
+				{},
+				{err: errors.New("endpoint is down")},
+			},
+			wantErr: "[file is read only, endpoint is down]",
+		},
+		{
+			desc: "succeeds without any archiver",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				esc       = archivedEscalation("esc-1")
+				archivers = make([]audit.Archiver, len(testCase.archivers))
+			)
+
+			for i, archiver := range testCase.archivers {
+				archivers[i] = archiver
+			}
+
+			err := audit.MultiArchiver(archivers...).Archive(context.Background(), esc)
+			if testCase.wantErr != "" {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, archiver := range testCase.archivers {
+				assert.Equal(t, 1, archiver.calls)
+
+				if archiver.err == nil {
+					assert.Equal(t, []*kudov1alpha1.Escalation{esc}, archiver.archived)
+				}
+			}
+		})
+	}
+}
+
+type mockArchiver struct {
+	archived []*kudov1alpha1.Escalation
+	calls    int
+	err      error
+}
+
+func (a *mockArchiver) Archive(_ context.Context, esc *kudov1alpha1.Escalation) error {
+	a.calls++
+
+	if a.err != nil {
+		return a.err
+	}
+
+	a.archived = append(a.archived, esc)
+
+	return nil
+}
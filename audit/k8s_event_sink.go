@@ -2,6 +2,9 @@ package audit
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"k8s.io/client-go/tools/record"
@@ -16,22 +19,82 @@ func NewK8sEventSink(recorder record.EventRecorder) Sink {
 }
 
 func (s *k8sEventSink) RecordCreate(ctx context.Context, escalation *kudov1alpha1.Escalation) {
+	message := "Escalation has been created"
+
+	if escalation.Spec.IsDelegated() {
+		message = fmt.Sprintf(
+			"Escalation has been created by %s on behalf of %s",
+			escalation.Spec.Delegate.Username,
+			escalation.Spec.Requestor,
+		)
+	}
+
+	// List everyone that gains access through the escalation, not only its requestor.
+	if len(escalation.Spec.Beneficiaries) > 0 {
+		message += ", granting access to " + strings.Join(escalation.Spec.Grantees(), ", ")
+	}
+
 	s.eventRecorder.Event(
 		escalation,
 		"Normal",
 		"Create",
-		"Escalation has been created",
+		message,
 	)
 }
 
-func (s *k8sEventSink) RecordUpdate(ctx context.Context, _, escalation *kudov1alpha1.Escalation) {
+func (s *k8sEventSink) RecordUpdate(ctx context.Context, oldEscalation, escalation *kudov1alpha1.Escalation) {
+	transitions := NewTransitions(oldEscalation, escalation)
+
+	// Updates that aren't recorded in the history, like grants being applied, are reported with the current state.
+	if len(transitions) == 0 {
+		s.eventRecorder.Eventf(
+			escalation,
+			"Normal",
+			"Update",
+			"New state %s, reason is: %s",
+			escalation.Status.State,
+			escalation.Status.StateDetails,
+		)
+
+		return
+	}
+
+	for _, transition := range transitions {
+		if transition.Actor.Kind == "" || transition.Actor == kudov1alpha1.ControllerActor {
+			s.eventRecorder.Eventf(
+				escalation,
+				"Normal",
+				"Update",
+				"New state %s, reason is: %s",
+				transition.To,
+				transition.Details,
+			)
+
+			continue
+		}
+
+		s.eventRecorder.Eventf(
+			escalation,
+			"Normal",
+			"Update",
+			"New state %s set by %s, reason is: %s",
+			transition.To,
+			transition.Actor,
+			transition.Details,
+		)
+	}
+}
+
+func (s *k8sEventSink) RecordExtend(ctx context.Context, escalation *kudov1alpha1.Escalation, extension kudov1alpha1.EscalationExtension) {
 	s.eventRecorder.Eventf(
 		escalation,
 		"Normal",
-		"Update",
-		"New state %s, reason is: %s",
-		escalation.Status.State,
-		escalation.Status.StateDetails,
+		"Extend",
+		"Escalation has been extended by %s on behalf of %s, now expires at %s, reason is: %s",
+		extension.Duration.Duration,
+		extension.Requestor,
+		escalation.Status.ExpiresAt.UTC().Format(time.RFC3339),
+		extension.Reason,
 	)
 }
 
@@ -0,0 +1,91 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jlevesy/kudo/audit"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestK8sEventSink_RecordUpdate(t *testing.T) {
+	var (
+		createdAt = metav1.Time{Time: time.Date(2022, time.October, 10, 1, 30, 1, 0, time.UTC)}
+		admin     = kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindAdmin, Name: "admin"}
+		created   = kudov1alpha1.EscalationTransition{
+			To:      kudov1alpha1.StatePending,
+			At:      createdAt,
+			Actor:   kudov1alpha1.ControllerActor,
+			Details: "pending",
+		}
+		accepted = kudov1alpha1.EscalationTransition{
+			From:    kudov1alpha1.StatePending,
+			To:      kudov1alpha1.StateAccepted,
+			At:      metav1.Time{Time: createdAt.Add(time.Second)},
+			Actor:   kudov1alpha1.ControllerActor,
+			Details: "accepted",
+		}
+		revoked = kudov1alpha1.EscalationTransition{
+			From:    kudov1alpha1.StateAccepted,
+			To:      kudov1alpha1.StateRevoked,
+			At:      metav1.Time{Time: createdAt.Add(2 * time.Second)},
+			Actor:   admin,
+			Details: "revoked",
+		}
+		escalation = func(state kudov1alpha1.EscalationState, details string, history ...kudov1alpha1.EscalationTransition) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
+				Status: kudov1alpha1.EscalationStatus{
+					State:        state,
+					StateDetails: details,
+					History:      history,
+				},
+			}
+		}
+	)
+
+	testCases := []struct {
+		desc       string
+		oldEsc     *kudov1alpha1.Escalation
+		newEsc     *kudov1alpha1.Escalation
+		wantEvents []string
+	}{
+		{
+			desc:   "records each new transition along with its actor",
+			oldEsc: escalation(kudov1alpha1.StatePending, "pending", created),
+			newEsc: escalation(kudov1alpha1.StateRevoked, "revoked", created, accepted, revoked),
+			wantEvents: []string{
+				"Normal Update New state ACCEPTED, reason is: accepted",
+				"Normal Update New state REVOKED set by Admin admin, reason is: revoked",
+			},
+		},
+		{
+			desc:   "records the current state if no transition has been added",
+			oldEsc: escalation(kudov1alpha1.StateAccepted, "accepted", created, accepted),
+			newEsc: escalation(kudov1alpha1.StateAccepted, "applied", created, accepted),
+			wantEvents: []string{
+				"Normal Update New state ACCEPTED, reason is: applied",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+
+			audit.NewK8sEventSink(recorder).RecordUpdate(context.Background(), testCase.oldEsc, testCase.newEsc)
+			close(recorder.Events)
+
+			var gotEvents []string
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+
+			assert.Equal(t, testCase.wantEvents, gotEvents)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 type Sink interface {
 	RecordCreate(ctx context.Context, esc *kudov1alpha1.Escalation)
 	RecordUpdate(ctx context.Context, oldEsc, newEsc *kudov1alpha1.Escalation)
+	RecordExtend(ctx context.Context, esc *kudov1alpha1.Escalation, extension kudov1alpha1.EscalationExtension)
 	RecordDelete(ctx context.Context, esc *kudov1alpha1.Escalation)
 }
 
@@ -29,6 +30,12 @@ func (m multiAsyncSink) RecordUpdate(ctx context.Context, oldEsc, newEsc *kudov1
 	})
 }
 
+func (m multiAsyncSink) RecordExtend(ctx context.Context, esc *kudov1alpha1.Escalation, extension kudov1alpha1.EscalationExtension) {
+	m.asyncDo(func(s Sink) {
+		s.RecordExtend(ctx, esc, extension)
+	})
+}
+
 func (m multiAsyncSink) RecordDelete(ctx context.Context, esc *kudov1alpha1.Escalation) {
 	m.asyncDo(func(s Sink) {
 		s.RecordDelete(ctx, esc)
@@ -49,3 +56,24 @@ func (m multiAsyncSink) asyncDo(callback func(Sink)) {
 		}()
 	}
 }
+
+// NewTransitions returns the transitions added to the history of an escalation by an update, oldest first.
+// Sinks can rely on it to report who did what to an escalation, without keeping track of its history themselves.
+func NewTransitions(oldEsc, newEsc *kudov1alpha1.Escalation) []kudov1alpha1.EscalationTransition {
+	oldHistory, newHistory := oldEsc.Status.History, newEsc.Status.History
+
+	if len(oldHistory) == 0 {
+		return newHistory
+	}
+
+	// The history is bounded, old transitions might have been dropped: look for the last known one.
+	lastKnown := oldHistory[len(oldHistory)-1]
+
+	for i := len(newHistory) - 1; i >= 0; i-- {
+		if newHistory[i].At.Equal(&lastKnown.At) && newHistory[i].From == lastKnown.From && newHistory[i].To == lastKnown.To {
+			return newHistory[i+1:]
+		}
+	}
+
+	return newHistory
+}
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -31,11 +33,21 @@ import (
 )
 
 var (
-	masterURL      string
-	kubeconfig     string
-	threadiness    int
-	resyncInterval time.Duration
-	retryInterval  time.Duration
+	masterURL          string
+	kubeconfig         string
+	threadiness        int
+	revokedThreadiness int
+	policyThreadiness  int
+	resyncInterval     time.Duration
+	retryInterval      time.Duration
+	retention          time.Duration
+	archiveFile        string
+	archiveURL         string
+	adminUsers         string
+	adminGroups        string
+
+	namespacedPoliciesClusterRoles string
+	verifyPolicyRoles              bool
 
 	webhookConfig webhooksupport.ServerConfig
 )
@@ -49,8 +61,17 @@ func main() {
 	flag.StringVar(&webhookConfig.KeyPath, "webhook_key", "", "Path to webhook TLS key")
 	flag.StringVar(&webhookConfig.Addr, "webhook_addr", ":8080", "Webhook listening address")
 	flag.IntVar(&threadiness, "threadiness", 10, "Amount of events processed in paralled")
+	flag.IntVar(&revokedThreadiness, "revoked_threadiness", 2, "Amount of events about revoked escalations processed in parallel, ahead of the others")
+	flag.IntVar(&policyThreadiness, "policy_threadiness", 2, "Amount of escalation policy events processed in parallel")
 	flag.DurationVar(&resyncInterval, "resync_interval", 30*time.Second, "Maximum period to resync an active escalation")
 	flag.DurationVar(&retryInterval, "retry_interval", 10*time.Second, "Maximum period retry an escalation not fully granted/reclaimed")
+	flag.DurationVar(&retention, "retention", 0, "Period after which finished escalations are deleted, escalations are kept forever if zero")
+	flag.StringVar(&archiveFile, "archive_file", "", "Path to a file finished escalations are appended to before being deleted")
+	flag.StringVar(&archiveURL, "archive_url", "", "HTTP endpoint finished escalations are posted to before being deleted")
+	flag.StringVar(&adminUsers, "admin_users", "", "Comma separated list of users allowed to delete escalations that are not finished")
+	flag.StringVar(&adminGroups, "admin_groups", "", "Comma separated list of groups allowed to delete escalations that are not finished")
+	flag.BoolVar(&verifyPolicyRoles, "verify_policy_roles", false, "Deny escalation policies binding roles or cluster roles that don't exist")
+	flag.StringVar(&namespacedPoliciesClusterRoles, "namespaced_policies_cluster_roles", "", "Comma separated list of cluster roles namespaced escalation policies are allowed to bind")
 	klog.InitFlags(nil)
 
 	flag.Parse()
@@ -85,14 +106,26 @@ func main() {
 		kubeInformerFactory = kubeinformers.NewSharedInformerFactory(kubeClient, defaultInformerResyncInterval)
 		kudoInformerFactory = kudoinformers.NewSharedInformerFactory(kudoClientSet, defaultInformerResyncInterval)
 		escalationsInformer = kudoInformerFactory.K8s().V1alpha1().Escalations().Informer()
+		escalationsLister   = kudoInformerFactory.K8s().V1alpha1().Escalations().Lister()
 		escalationsClient   = kudoClientSet.K8sV1alpha1().Escalations()
+		policiesInformer    = kudoInformerFactory.K8s().V1alpha1().EscalationPolicies().Informer()
 		policiesLister      = kudoInformerFactory.K8s().V1alpha1().EscalationPolicies().Lister()
+		templatesInformer   = kudoInformerFactory.K8s().V1alpha1().EscalationPolicyTemplates().Informer()
+		templatesLister     = kudoInformerFactory.K8s().V1alpha1().EscalationPolicyTemplates().Lister()
+		allowedClusterRoles = splitList(namespacedPoliciesClusterRoles)
+		policyResolver      = escalation.NewPolicyResolver(
+			policiesLister,
+			kudoInformerFactory.K8s().V1alpha1().NamespacedEscalationPolicies().Lister(),
+			templatesLister,
+			allowedClusterRoles,
+		)
 
 		granterFactory = grant.DefaultGranterFactory(kubeInformerFactory, kubeClient)
 
 		escalationController = controllersupport.NewQueuedEventHandler[kudov1alpha1.Escalation](
 			escalation.NewController(
-				policiesLister,
+				policyResolver,
+				escalationsLister,
 				escalationsClient,
 				granterFactory,
 				audit.MutliAsyncSink(
@@ -105,16 +138,41 @@ func main() {
 				),
 				escalation.WithResyncInterval(resyncInterval),
 				escalation.WithRetryInterval(retryInterval),
+				escalation.WithRetention(retention),
+				escalation.WithArchiver(buildArchiver()),
 			),
 			kudov1alpha1.KindEscalation,
 			threadiness,
+			controllersupport.WithPriorityQueue(escalation.IsRevoked, revokedThreadiness),
+		)
+
+		policyController = controllersupport.NewQueuedEventHandler[kudov1alpha1.EscalationPolicy](
+			escalationpolicy.NewController(
+				policiesLister,
+				templatesLister,
+				escalationsLister,
+				kudoClientSet.K8sV1alpha1().EscalationPolicies(),
+				granterFactory,
+			),
+			kudov1alpha1.KindEscalationPolicy,
+			policyThreadiness,
 		)
 	)
 
 	escalationsInformer.AddEventHandler(escalationController)
+	escalationsInformer.AddEventHandler(escalationpolicy.NewEscalationEventHandler(policiesLister, policyController))
+	policiesInformer.AddEventHandler(policyController)
+	templatesInformer.AddEventHandler(escalationpolicy.NewTemplateEventHandler(policiesLister, policyController))
 
-	escalationpolicy.SetupWebhook(serveMux)
-	escalation.SetupWebhook(serveMux, kudoInformerFactory, granterFactory)
+	escalationpolicy.SetupWebhook(serveMux, templatesLister, granterFactory, verifyPolicyRoles, allowedClusterRoles)
+	escalation.SetupWebhook(
+		serveMux,
+		policyResolver,
+		kudoInformerFactory,
+		granterFactory,
+		kubeClient.AuthorizationV1().SubjectAccessReviews(),
+		buildAdminSubjects(),
+	)
 	serveMux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
 		rw.WriteHeader(http.StatusOK)
 		_, _ = rw.Write([]byte("ok"))
@@ -143,6 +201,11 @@ func main() {
 		return nil
 	})
 
+	group.Go(func() error {
+		policyController.Run(ctx)
+		return nil
+	})
+
 	klog.Info("Controller is up and running")
 
 	if err := group.Wait(); err != nil {
@@ -151,3 +214,51 @@ func main() {
 
 	klog.Info("Exited kudo controller")
 }
+
+func buildArchiver() audit.Archiver {
+	var archivers []audit.Archiver
+
+	if archiveFile != "" {
+		archivers = append(archivers, audit.NewFileArchiver(archiveFile))
+	}
+
+	if archiveURL != "" {
+		archivers = append(archivers, audit.NewHTTPArchiver(archiveURL, &http.Client{Timeout: 10 * time.Second}))
+	}
+
+	if len(archivers) == 0 {
+		if retention > 0 {
+			klog.Warning("Finished escalations are going to be deleted without being archived")
+		}
+
+		return nil
+	}
+
+	return audit.MultiArchiver(archivers...)
+}
+
+func buildAdminSubjects() []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+
+	for _, user := range splitList(adminUsers) {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user})
+	}
+
+	for _, group := range splitList(adminGroups) {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group})
+	}
+
+	return subjects
+}
+
+func splitList(raw string) []string {
+	var values []string
+
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+)
+
+func newAcknowledgeCmd() *cobra.Command {
+	config := runAcknowledgeCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "acknowledge",
+		Short:        "Acknowledge a kudo escalation created on your behalf",
+		SilenceUsage: true,
+		Long: `Kudo acknowledge agrees with a kudo escalation one of its policy delegates created on your behalf.
+
+An escalation waits for the acknowledgement of its requestor when its policy requires it.
+
+Examples:
+  To acknowledge the escalation "kudo-escalation-abcde", run:
+    kubectl kudo acknowledge kudo-escalation-abcde
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAcknowledge(cmd, config, args)
+		},
+	}
+
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runAcknowledgeCfg struct {
+	*genericclioptions.ConfigFlags
+}
+
+func runAcknowledge(cmd *cobra.Command, config runAcknowledgeCfg, args []string) error {
+	parsedArgs, err := parseEscalationNameArgs(args)
+	if err != nil {
+		return cmd.Help()
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	var escalation *kudov1alpha1.Escalation
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), parsedArgs.escalationName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if escalation.Spec.Acknowledged {
+			return fmt.Errorf("escalation %s has already been acknowledged", escalation.Name)
+		}
+
+		escalation.Spec.Acknowledged = true
+
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Update(cmd.Context(), escalation, metav1.UpdateOptions{})
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to acknowledge escalation, reason is: %w", err)
+	}
+
+	fmt.Println("Successfuly acknowledged escalation", escalation.Name, "created by", escalation.Spec.Delegate.Username)
+
+	return nil
+}
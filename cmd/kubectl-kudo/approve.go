@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+)
+
+func newApproveCmd() *cobra.Command {
+	config := runApproveCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "approve",
+		Short:        "Approve a pending kudo escalation",
+		SilenceUsage: true,
+		Long: `Kudo approve approves a kudo escalation waiting for the approval of one of its policy reviewers.
+
+An escalation waits for an approval when one of its policy conditions requires a challenge.
+
+Examples:
+  To approve the escalation "kudo-escalation-abcde", run:
+    kubectl kudo approve kudo-escalation-abcde
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApprove(cmd, config, args)
+		},
+	}
+
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runApproveCfg struct {
+	*genericclioptions.ConfigFlags
+}
+
+func runApprove(cmd *cobra.Command, config runApproveCfg, args []string) error {
+	parsedArgs, err := parseEscalationNameArgs(args)
+	if err != nil {
+		return cmd.Help()
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	var escalation *kudov1alpha1.Escalation
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), parsedArgs.escalationName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if escalation.Spec.ApprovedBy != "" {
+			return fmt.Errorf("escalation %s has already been approved by %s", escalation.Name, escalation.Spec.ApprovedBy)
+		}
+
+		escalation.Spec.ApprovedBy = approvedPlaceholder
+
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Update(cmd.Context(), escalation, metav1.UpdateOptions{})
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to approve escalation, reason is: %w", err)
+	}
+
+	fmt.Println("Successfuly approved escalation", escalation.Name)
+
+	return nil
+}
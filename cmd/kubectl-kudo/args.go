@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+type escalationNameArgs struct {
+	escalationName string
+}
+
+func parseEscalationNameArgs(args []string) (escalationNameArgs, error) {
+	if len(args) < 1 {
+		return escalationNameArgs{}, errors.New("you need to provide an escalation name")
+	}
+
+	parsedArgs := escalationNameArgs{
+		escalationName: args[0],
+	}
+
+	if strings.TrimSpace(parsedArgs.escalationName) == "" {
+		return escalationNameArgs{}, errors.New("you need to provide a non blank escalation name")
+	}
+
+	return parsedArgs, nil
+}
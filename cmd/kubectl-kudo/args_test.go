@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEscalationNameArgs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rawArgs  []string
+		wantArgs escalationNameArgs
+		wantErr  error
+	}{
+		{
+			desc:    "raises an error if not enough args",
+			rawArgs: []string{},
+			wantErr: errors.New("you need to provide an escalation name"),
+		},
+		{
+			desc:    "raises an error if escalation name is blank",
+			rawArgs: []string{"    "},
+			wantErr: errors.New("you need to provide a non blank escalation name"),
+		},
+		{
+			desc:    "parse args",
+			rawArgs: []string{"escalation"},
+			wantArgs: escalationNameArgs{
+				escalationName: "escalation",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotArgs, err := parseEscalationNameArgs(testCase.rawArgs)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantArgs, gotArgs)
+		})
+	}
+}
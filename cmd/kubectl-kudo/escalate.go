@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	watch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
@@ -36,6 +37,24 @@ Examples:
   To escalate using the policy "gain-read-configmaps" during 30s on the namespace application-a, run:
     kubectl kudo escalate gain-read-configmaps --namespace=appliation-a --duration=30s --reason="Need access to configmaps"
 
+  To escalate using only the grant named "view" of the policy "gain-read-configmaps", run:
+    kubectl kudo escalate gain-read-configmaps --grant=view --reason="Need access to configmaps"
+
+  To escalate using the policy "restart-pods" only on the pod web-0 of the namespace application-a, run:
+    kubectl kudo escalate restart-pods --namespace=application-a --resource=pods/web-0 --reason="web-0 is stuck"
+
+  To escalate using the policy "gain-read-configmaps" during a maintenance window starting at 2am UTC, run:
+    kubectl kudo escalate gain-read-configmaps --start-at=2022-10-11T02:00:00Z --duration=1h --reason="Planned maintenance"
+
+  To escalate using the policy "gain-read-configmaps" on behalf of the user jane, if the policy allows you to, run:
+    kubectl kudo escalate gain-read-configmaps --on-behalf-of=jane --reason="jane is locked out"
+
+  To escalate using the policy "incident-war-room" for you, jane and john, run:
+    kubectl kudo escalate incident-war-room --beneficiary=jane --beneficiary=john --reason="Database is down"
+
+  To escalate using the namespaced policy "deploy-hotfix" of the namespace application-a, run:
+    kubectl kudo escalate deploy-hotfix --namespace=application-a --namespaced-policy --reason="Deploy a hotfix"
+
 Find more information at:
 	https://github.com/jlevesy/kudo
 `,
@@ -47,6 +66,12 @@ Find more information at:
 	cmd.Flags().BoolVar(&config.noWait, "no-wait", false, "do not wait for escalation to be accepted, or denied")
 	cmd.Flags().DurationVar(&config.duration, "duration", 0, "escalate for the given duration, defaults to the policy default duration")
 	cmd.Flags().StringVar(&config.reason, "reason", "", "reason for the escalation (required)")
+	cmd.Flags().StringSliceVar(&config.grants, "grant", nil, "only escalate using the given policy grants, by name or index, defaults to all the policy grants")
+	cmd.Flags().StringVar(&config.startAt, "start-at", "", "delay the escalation start to the given RFC3339 time, defaults to as soon as it is accepted")
+	cmd.Flags().StringArrayVar(&config.resources, "resource", nil, "restrict the escalation to the given object, formatted as resource/name (for instance pods/web-0 or deployments.apps/api)")
+	cmd.Flags().StringVar(&config.onBehalfOf, "on-behalf-of", "", "escalate on behalf of the given user, if the policy lists you as one of its delegates")
+	cmd.Flags().StringSliceVar(&config.beneficiaries, "beneficiary", nil, "also grant the escalation permissions to the given users, who must be allowed to use the policy")
+	cmd.Flags().BoolVar(&config.namespacedPolicy, "namespaced-policy", false, "use the namespaced policy of the escalation namespace, instead of a cluster wide policy")
 	config.ConfigFlags.AddFlags(cmd.Flags())
 
 	return &cmd
@@ -54,9 +79,16 @@ Find more information at:
 
 type runEscalateCfg struct {
 	*genericclioptions.ConfigFlags
-	noWait   bool
-	duration time.Duration
-	reason   string
+	noWait        bool
+	duration      time.Duration
+	reason        string
+	grants        []string
+	resources     []string
+	startAt       string
+	onBehalfOf    string
+	beneficiaries []string
+
+	namespacedPolicy bool
 }
 
 func runEscalate(cmd *cobra.Command, config runEscalateCfg, args []string) error {
@@ -75,6 +107,26 @@ func runEscalate(cmd *cobra.Command, config runEscalateCfg, args []string) error
 		return err
 	}
 
+	resources, err := resolveEscalationResources(config)
+	if err != nil {
+		return err
+	}
+
+	startAt, err := parseEscalationStartAt(config.startAt)
+	if err != nil {
+		return err
+	}
+
+	var policyNamespace string
+
+	if config.namespacedPolicy {
+		if *config.ConfigFlags.Namespace == "" {
+			return errors.New("a namespace is required to escalate using a namespaced policy")
+		}
+
+		policyNamespace = *config.ConfigFlags.Namespace
+	}
+
 	fmt.Println("Creating a new escalation request using policy", parsedArgs.policyName)
 
 	escalation, err := kudoClient.K8sV1alpha1().Escalations().Create(
@@ -84,10 +136,16 @@ func runEscalate(cmd *cobra.Command, config runEscalateCfg, args []string) error
 				GenerateName: "kudo-escalation-",
 			},
 			Spec: kudov1alpha1.EscalationSpec{
-				PolicyName: parsedArgs.policyName,
-				Reason:     config.reason,
-				Namespace:  *config.ConfigFlags.Namespace,
-				Duration:   metav1.Duration{Duration: config.duration},
+				PolicyName:      parsedArgs.policyName,
+				PolicyNamespace: policyNamespace,
+				Reason:          config.reason,
+				Namespace:       *config.ConfigFlags.Namespace,
+				Duration:        metav1.Duration{Duration: config.duration},
+				Grants:          config.grants,
+				Resources:       resources,
+				StartAt:         startAt,
+				OnBehalfOf:      config.onBehalfOf,
+				Beneficiaries:   config.beneficiaries,
 			},
 		},
 		metav1.CreateOptions{},
@@ -142,7 +200,20 @@ func runEscalate(cmd *cobra.Command, config runEscalateCfg, args []string) error
 			case kudov1alpha1.StatePending, kudov1alpha1.StateUnknown:
 				// We're still pending, wait for another update.
 				continue
+			case kudov1alpha1.StateScheduled:
+				if escalation.Spec.IsDelegated() {
+					fmt.Println("Escalation has been accepted, permissions will be granted to", escalation.Spec.Requestor, "at", escalation.Spec.StartAt.UTC().Format(time.RFC3339))
+					return nil
+				}
+
+				fmt.Println("Escalation has been accepted, you will have augmented permissions at", escalation.Spec.StartAt.UTC().Format(time.RFC3339))
+				return nil
 			case kudov1alpha1.StateAccepted:
+				if escalation.Spec.IsDelegated() {
+					fmt.Println("Escalation has been accepted,", escalation.Spec.Requestor, "now has augmented permissions")
+					return nil
+				}
+
 				// Escalation has been accepeted, success!
 				fmt.Println("You have now augmented permissions, use it with care!")
 				return nil
@@ -150,11 +221,77 @@ func runEscalate(cmd *cobra.Command, config runEscalateCfg, args []string) error
 				return fmt.Errorf("Escalation has been denied, reason is: %s", escalation.Status.StateDetails)
 			case kudov1alpha1.StateExpired:
 				return fmt.Errorf("Escalation has expired, reason is: %s", escalation.Status.StateDetails)
+			case kudov1alpha1.StateReleased:
+				return fmt.Errorf("Escalation has been released, reason is: %s", escalation.Status.StateDetails)
+			case kudov1alpha1.StateRevoked:
+				return fmt.Errorf("Escalation has been revoked, reason is: %s", escalation.Status.StateDetails)
 			}
 		}
 	}
 }
 
+// parseEscalationStartAt parses the start time given by the user, if any.
+func parseEscalationStartAt(rawStartAt string) (*metav1.Time, error) {
+	if rawStartAt == "" {
+		return nil, nil
+	}
+
+	startAt, err := time.Parse(time.RFC3339, rawStartAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time %q, expected an RFC3339 time: %w", rawStartAt, err)
+	}
+
+	return &metav1.Time{Time: startAt}, nil
+}
+
+// resolveEscalationResources turns the resources given by the user into fully qualified resources,
+// using the cluster discovery to resolve short names.
+func resolveEscalationResources(config runEscalateCfg) ([]kudov1alpha1.EscalationResource, error) {
+	if len(config.resources) == 0 {
+		return nil, nil
+	}
+
+	mapper, err := config.ConfigFlags.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]kudov1alpha1.EscalationResource, len(config.resources))
+
+	for i, rawResource := range config.resources {
+		resource, err := parseEscalationResource(rawResource)
+		if err != nil {
+			return nil, err
+		}
+
+		gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Group: resource.APIGroup, Resource: resource.Resource})
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve resource %q, reason is: %w", rawResource, err)
+		}
+
+		resource.APIGroup = gvr.Group
+		resource.Resource = gvr.Resource
+		resources[i] = resource
+	}
+
+	return resources, nil
+}
+
+func parseEscalationResource(raw string) (kudov1alpha1.EscalationResource, error) {
+	rawResource, name, ok := strings.Cut(raw, "/")
+	if !ok || strings.TrimSpace(rawResource) == "" || strings.TrimSpace(name) == "" {
+		return kudov1alpha1.EscalationResource{}, fmt.Errorf("invalid resource %q, expected format is resource/name", raw)
+	}
+
+	groupResource := schema.ParseGroupResource(rawResource)
+
+	return kudov1alpha1.EscalationResource{
+		APIGroup: groupResource.Group,
+		Resource: groupResource.Resource,
+		Name:     name,
+	}, nil
+}
+
 type escalateArgs struct {
 	policyName string
 }
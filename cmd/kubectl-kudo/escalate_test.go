@@ -3,8 +3,12 @@ package main
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 )
 
 func TestParseEscalateArgs(t *testing.T) {
@@ -41,3 +45,85 @@ func TestParseEscalateArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEscalationResource(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		raw          string
+		wantResource kudov1alpha1.EscalationResource
+		wantErr      error
+	}{
+		{
+			desc:    "raises an error if there is no name",
+			raw:     "pods",
+			wantErr: errors.New(`invalid resource "pods", expected format is resource/name`),
+		},
+		{
+			desc:    "raises an error if the name is blank",
+			raw:     "pods/ ",
+			wantErr: errors.New(`invalid resource "pods/ ", expected format is resource/name`),
+		},
+		{
+			desc: "parses a core resource",
+			raw:  "pods/web-0",
+			wantResource: kudov1alpha1.EscalationResource{
+				Resource: "pods",
+				Name:     "web-0",
+			},
+		},
+		{
+			desc: "parses a grouped resource",
+			raw:  "deployments.apps/api",
+			wantResource: kudov1alpha1.EscalationResource{
+				APIGroup: "apps",
+				Resource: "deployments",
+				Name:     "api",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotResource, err := parseEscalationResource(testCase.raw)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantResource, gotResource)
+		})
+	}
+}
+
+func TestParseEscalationStartAt(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		raw         string
+		wantStartAt *metav1.Time
+		wantErr     bool
+	}{
+		{
+			desc: "returns nothing if no start time is given",
+			raw:  "",
+		},
+		{
+			desc:    "raises an error if the start time is not RFC3339",
+			raw:     "tomorrow at 2am",
+			wantErr: true,
+		},
+		{
+			desc:        "parses a start time",
+			raw:         "2022-10-11T02:00:00Z",
+			wantStartAt: &metav1.Time{Time: time.Date(2022, time.October, 11, 2, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotStartAt, err := parseEscalationStartAt(testCase.raw)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantStartAt, gotStartAt)
+		})
+	}
+}
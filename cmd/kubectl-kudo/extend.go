@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+)
+
+// approvedPlaceholder is replaced by the name of the approver by the kudo webhook.
+const approvedPlaceholder = "approved"
+
+func newExtendCmd() *cobra.Command {
+	config := runExtendCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "extend",
+		Short:        "Extend an accepted kudo escalation",
+		SilenceUsage: true,
+		Long: `Kudo extend asks for more time on an accepted kudo escalation.
+
+An escalation can't last longer than the policy max duration, counted from its acceptance.
+
+Examples:
+  To extend the escalation "kudo-escalation-abcde" by 30 minutes, run:
+    kubectl kudo extend kudo-escalation-abcde --duration=30m --reason="Investigation is still running"
+
+  To approve the pending extensions of the escalation "kudo-escalation-abcde", run:
+    kubectl kudo extend kudo-escalation-abcde --approve
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExtend(cmd, config, args)
+		},
+	}
+
+	cmd.Flags().DurationVar(&config.duration, "duration", 0, "extend the escalation by the given duration (required)")
+	cmd.Flags().StringVar(&config.reason, "reason", "", "reason for the extension (required)")
+	cmd.Flags().BoolVar(&config.approve, "approve", false, "approve the pending extensions of the escalation instead of requesting a new one")
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runExtendCfg struct {
+	*genericclioptions.ConfigFlags
+	duration time.Duration
+	reason   string
+	approve  bool
+}
+
+func runExtend(cmd *cobra.Command, config runExtendCfg, args []string) error {
+	parsedArgs, err := parseEscalationNameArgs(args)
+	if err != nil {
+		return cmd.Help()
+	}
+
+	if !config.approve && config.duration <= 0 {
+		return errors.New("you need to provide a positive duration")
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	var escalation *kudov1alpha1.Escalation
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), parsedArgs.escalationName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if config.approve {
+			approved := 0
+
+			for i := range escalation.Spec.Extensions {
+				if escalation.Spec.Extensions[i].ApprovedBy == "" {
+					escalation.Spec.Extensions[i].ApprovedBy = approvedPlaceholder
+					approved++
+				}
+			}
+
+			if approved == 0 {
+				return fmt.Errorf("escalation %s has no extension waiting for an approval", escalation.Name)
+			}
+		} else {
+			escalation.Spec.Extensions = append(
+				escalation.Spec.Extensions,
+				kudov1alpha1.EscalationExtension{
+					Duration: metav1.Duration{Duration: config.duration},
+					Reason:   config.reason,
+				},
+			)
+		}
+
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Update(cmd.Context(), escalation, metav1.UpdateOptions{})
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to extend escalation, reason is: %w", err)
+	}
+
+	if config.approve {
+		fmt.Println("Successfuly approved the pending extensions of escalation", escalation.Name)
+		return nil
+	}
+
+	fmt.Println("Successfuly requested an extension of escalation", escalation.Name)
+
+	return nil
+}
@@ -18,7 +18,13 @@ func main() {
 		},
 	}
 
+	rootCmd.AddCommand(newAcknowledgeCmd())
+	rootCmd.AddCommand(newApproveCmd())
 	rootCmd.AddCommand(newEscalateCmd())
+	rootCmd.AddCommand(newExtendCmd())
+	rootCmd.AddCommand(newReleaseCmd())
+	rootCmd.AddCommand(newRevokeCmd())
+	rootCmd.AddCommand(newTimelineCmd())
 
 	rootCmd.SetUsageTemplate(
 		strings.NewReplacer(
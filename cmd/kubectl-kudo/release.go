@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+)
+
+func newReleaseCmd() *cobra.Command {
+	config := runReleaseCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "release",
+		Short:        "Release a kudo escalation before it expires",
+		SilenceUsage: true,
+		Long: `Kudo release ends a kudo escalation before it expires, all granted permissions are reclaimed.
+
+The escalation is kept in the cluster, in the RELEASED state.
+
+Examples:
+  To release the escalation "kudo-escalation-abcde", run:
+    kubectl kudo release kudo-escalation-abcde
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelease(cmd, config, args)
+		},
+	}
+
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runReleaseCfg struct {
+	*genericclioptions.ConfigFlags
+}
+
+func runRelease(cmd *cobra.Command, config runReleaseCfg, args []string) error {
+	parsedArgs, err := parseEscalationNameArgs(args)
+	if err != nil {
+		return cmd.Help()
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	var escalation *kudov1alpha1.Escalation
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), parsedArgs.escalationName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if escalation.Spec.Released {
+			return nil
+		}
+
+		escalation.Spec.Released = true
+
+		escalation, err = kudoClient.K8sV1alpha1().Escalations().Update(cmd.Context(), escalation, metav1.UpdateOptions{})
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to release escalation, reason is: %w", err)
+	}
+
+	fmt.Println("Successfuly released escalation", escalation.Name, "permissions are going to be reclaimed in a few moments")
+
+	return nil
+}
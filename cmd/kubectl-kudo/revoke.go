@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+func newRevokeCmd() *cobra.Command {
+	config := runRevokeCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "revoke",
+		Short:        "Revoke one or many kudo escalations",
+		SilenceUsage: true,
+		Long: `Kudo revoke ends kudo escalations before they expire, all granted permissions are reclaimed.
+
+Revoking requires to be allowed to "revoke" escalations in the k8s.kudo.dev API group.
+Revoked escalations are kept in the cluster, in the REVOKED state.
+
+Examples:
+  To revoke the escalation "kudo-escalation-abcde", run:
+    kubectl kudo revoke kudo-escalation-abcde --reason="Incident is over"
+
+  To revoke all the escalations granting access to the user "jane@org.com", as requestor or beneficiary, run:
+    kubectl kudo revoke --user=jane@org.com --reason="Account compromised"
+
+  To revoke all the escalations of the policy "production-admin", run:
+    kubectl kudo revoke --policy=production-admin --reason="Policy is being reviewed"
+
+  To revoke all the escalations of the namespaced policy "deploy-hotfix" of the namespace application-a, run:
+    kubectl kudo revoke --policy=deploy-hotfix --policy-namespace=application-a --reason="Policy is being reviewed"
+
+  To revoke all the escalations, run:
+    kubectl kudo revoke --all --reason="Security incident"
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRevoke(cmd, config, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.reason, "reason", "", "reason for the revocation (required)")
+	cmd.Flags().StringVar(&config.user, "user", "", "revoke all the escalations granting access to this user, as requestor or beneficiary")
+	cmd.Flags().StringVar(&config.policy, "policy", "", "revoke all the escalations of this policy")
+	cmd.Flags().StringVar(&config.policyNamespace, "policy-namespace", "", "namespace of the namespaced policy given with --policy, cluster wide policies are selected if empty")
+	cmd.Flags().BoolVar(&config.all, "all", false, "revoke all the escalations")
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runRevokeCfg struct {
+	*genericclioptions.ConfigFlags
+	reason          string
+	user            string
+	policy          string
+	policyNamespace string
+	all             bool
+}
+
+func runRevoke(cmd *cobra.Command, config runRevokeCfg, args []string) error {
+	selector, err := parseRevokeSelector(args, config.user, config.policy, config.policyNamespace, config.all)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(config.reason) == "" {
+		return errors.New("you need to provide a reason")
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	var escalationNames []string
+
+	if selector.escalationName != "" {
+		escalationNames = []string{selector.escalationName}
+	} else {
+		escalations, err := kudoClient.K8sV1alpha1().Escalations().List(cmd.Context(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to list escalations, reason is: %w", err)
+		}
+
+		escalationNames = selector.selectEscalations(escalations.Items)
+	}
+
+	if len(escalationNames) == 0 {
+		fmt.Println("No escalation to revoke")
+		return nil
+	}
+
+	var failed int
+
+	for _, escalationName := range escalationNames {
+		escalationName := escalationName
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			escalation, err := kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), escalationName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			if escalation.Spec.Revocation != nil {
+				return nil
+			}
+
+			escalation.Spec.Revocation = &kudov1alpha1.EscalationRevocation{Reason: config.reason}
+
+			_, err = kudoClient.K8sV1alpha1().Escalations().Update(cmd.Context(), escalation, metav1.UpdateOptions{})
+			return err
+		})
+
+		if err != nil {
+			failed++
+			fmt.Println("Unable to revoke escalation", escalationName, "reason is:", err)
+			continue
+		}
+
+		fmt.Println("Successfuly revoked escalation", escalationName, "permissions are going to be reclaimed in a few moments")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to revoke %d escalation(s) out of %d", failed, len(escalationNames))
+	}
+
+	return nil
+}
+
+// revokeSelector describes which escalations to revoke.
+type revokeSelector struct {
+	escalationName  string
+	user            string
+	policy          string
+	policyNamespace string // empty for a cluster wide policy.
+	all             bool
+}
+
+func parseRevokeSelector(args []string, user, policy, policyNamespace string, all bool) (revokeSelector, error) {
+	selector := revokeSelector{
+		user:            strings.TrimSpace(user),
+		policy:          strings.TrimSpace(policy),
+		policyNamespace: strings.TrimSpace(policyNamespace),
+		all:             all,
+	}
+
+	if len(args) > 0 {
+		parsedArgs, err := parseEscalationNameArgs(args)
+		if err != nil {
+			return revokeSelector{}, err
+		}
+
+		selector.escalationName = parsedArgs.escalationName
+	}
+
+	var selectors int
+
+	for _, set := range []bool{selector.escalationName != "", selector.user != "", selector.policy != "", selector.all} {
+		if set {
+			selectors++
+		}
+	}
+
+	if selectors != 1 {
+		return revokeSelector{}, errors.New("you need to provide either an escalation name, --user, --policy or --all")
+	}
+
+	if selector.policyNamespace != "" && selector.policy == "" {
+		return revokeSelector{}, errors.New("--policy-namespace can only be used along with --policy")
+	}
+
+	return selector, nil
+}
+
+// selectEscalations returns the names of the active escalations matching the selector.
+func (s revokeSelector) selectEscalations(escalations []kudov1alpha1.Escalation) []string {
+	var names []string
+
+	for _, escalation := range escalations {
+		if escalation.Spec.Revocation != nil {
+			continue
+		}
+
+		if !isRevocable(escalation.Status.State) {
+			continue
+		}
+
+		if s.user != "" && !generics.Contains(escalation.Spec.Grantees(), s.user) {
+			continue
+		}
+
+		// A cluster wide policy and a namespaced policy can share the same name.
+		if s.policy != "" && (escalation.Spec.PolicyName != s.policy || escalation.Spec.PolicyNamespace != s.policyNamespace) {
+			continue
+		}
+
+		names = append(names, escalation.Name)
+	}
+
+	return names
+}
+
+// isRevocable returns true if an escalation in the given state can still be revoked.
+func isRevocable(state kudov1alpha1.EscalationState) bool {
+	return state == kudov1alpha1.StatePending ||
+		state == kudov1alpha1.StateScheduled ||
+		state == kudov1alpha1.StateAccepted
+}
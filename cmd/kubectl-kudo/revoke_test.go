@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestParseRevokeSelector(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		rawArgs      []string
+		user         string
+		policy       string
+		policyNS     string
+		all          bool
+		wantSelector revokeSelector
+		wantErr      error
+	}{
+		{
+			desc:    "raises an error if no selector is given",
+			wantErr: errors.New("you need to provide either an escalation name, --user, --policy or --all"),
+		},
+		{
+			desc:    "raises an error if many selectors are given",
+			user:    "user-a",
+			all:     true,
+			wantErr: errors.New("you need to provide either an escalation name, --user, --policy or --all"),
+		},
+		{
+			desc:    "raises an error if escalation name is blank",
+			rawArgs: []string{"    "},
+			wantErr: errors.New("you need to provide a non blank escalation name"),
+		},
+		{
+			desc:         "selects an escalation by name",
+			rawArgs:      []string{"escalation"},
+			wantSelector: revokeSelector{escalationName: "escalation"},
+		},
+		{
+			desc:         "selects escalations by user",
+			user:         "user-a",
+			wantSelector: revokeSelector{user: "user-a"},
+		},
+		{
+			desc:         "selects escalations by policy",
+			policy:       "policy-a",
+			wantSelector: revokeSelector{policy: "policy-a"},
+		},
+		{
+			desc:         "selects escalations by namespaced policy",
+			policy:       "policy-a",
+			policyNS:     "ns-a",
+			wantSelector: revokeSelector{policy: "policy-a", policyNamespace: "ns-a"},
+		},
+		{
+			desc:     "raises an error if a policy namespace is given without a policy",
+			policyNS: "ns-a",
+			all:      true,
+			wantErr:  errors.New("--policy-namespace can only be used along with --policy"),
+		},
+		{
+			desc:         "selects all escalations",
+			all:          true,
+			wantSelector: revokeSelector{all: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotSelector, err := parseRevokeSelector(testCase.rawArgs, testCase.user, testCase.policy, testCase.policyNS, testCase.all)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantSelector, gotSelector)
+		})
+	}
+}
+
+func TestRevokeSelector_SelectEscalations(t *testing.T) {
+	escalation := func(name, requestor, policyName string, state kudov1alpha1.EscalationState) kudov1alpha1.Escalation {
+		return kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kudov1alpha1.EscalationSpec{
+				Requestor:  requestor,
+				PolicyName: policyName,
+			},
+			Status: kudov1alpha1.EscalationStatus{State: state},
+		}
+	}
+
+	teamEscalation := escalation("esc-team", "user-b", "policy-b", kudov1alpha1.StateAccepted)
+	teamEscalation.Spec.Beneficiaries = []string{"user-c", "user-a"}
+
+	namespacedEscalation := escalation("esc-namespaced", "user-b", "policy-a", kudov1alpha1.StateAccepted)
+	namespacedEscalation.Spec.PolicyNamespace = "ns-a"
+
+	revokedEscalation := escalation("esc-revoked", "user-a", "policy-a", kudov1alpha1.StateAccepted)
+	revokedEscalation.Spec.Revocation = &kudov1alpha1.EscalationRevocation{Reason: "Revoked"}
+
+	escalations := []kudov1alpha1.Escalation{
+		escalation("esc-1", "user-a", "policy-a", kudov1alpha1.StateAccepted),
+		escalation("esc-2", "user-a", "policy-b", kudov1alpha1.StatePending),
+		escalation("esc-3", "user-b", "policy-a", kudov1alpha1.StateAccepted),
+		escalation("esc-expired", "user-a", "policy-a", kudov1alpha1.StateExpired),
+		teamEscalation,
+		namespacedEscalation,
+		revokedEscalation,
+	}
+
+	testCases := []struct {
+		desc      string
+		selector  revokeSelector
+		wantNames []string
+	}{
+		{
+			desc:      "selects active escalations granting access to a user",
+			selector:  revokeSelector{user: "user-a"},
+			wantNames: []string{"esc-1", "esc-2", "esc-team"},
+		},
+		{
+			desc:      "selects active escalations granting access to a beneficiary",
+			selector:  revokeSelector{user: "user-c"},
+			wantNames: []string{"esc-team"},
+		},
+		{
+			desc:      "selects active escalations of a cluster wide policy",
+			selector:  revokeSelector{policy: "policy-a"},
+			wantNames: []string{"esc-1", "esc-3"},
+		},
+		{
+			desc:      "selects active escalations of a namespaced policy sharing its name with a cluster wide policy",
+			selector:  revokeSelector{policy: "policy-a", policyNamespace: "ns-a"},
+			wantNames: []string{"esc-namespaced"},
+		},
+		{
+			desc:     "selects nothing if the namespaced policy doesn't exist",
+			selector: revokeSelector{policy: "policy-a", policyNamespace: "ns-b"},
+		},
+		{
+			desc:      "selects all active escalations",
+			selector:  revokeSelector{all: true},
+			wantNames: []string{"esc-1", "esc-2", "esc-3", "esc-team", "esc-namespaced"},
+		},
+		{
+			desc:     "selects nothing if no escalation matches",
+			selector: revokeSelector{user: "user-d"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.wantNames, testCase.selector.selectEscalations(escalations))
+		})
+	}
+}
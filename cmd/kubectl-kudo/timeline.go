@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+)
+
+func newTimelineCmd() *cobra.Command {
+	config := runTimelineCfg{
+		ConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := cobra.Command{
+		Use:          "timeline",
+		Short:        "Show the history of a kudo escalation",
+		SilenceUsage: true,
+		Long: `Kudo timeline shows the transitions of a kudo escalation, oldest first, along with who made them.
+
+Only the last transitions are kept in the escalation.
+
+Examples:
+  To show the history of the escalation "kudo-escalation-abcde", run:
+    kubectl kudo timeline kudo-escalation-abcde
+
+Find more information at:
+	https://github.com/jlevesy/kudo
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTimeline(cmd, config, args)
+		},
+	}
+
+	config.ConfigFlags.AddFlags(cmd.Flags())
+
+	return &cmd
+}
+
+type runTimelineCfg struct {
+	*genericclioptions.ConfigFlags
+}
+
+func runTimeline(cmd *cobra.Command, config runTimelineCfg, args []string) error {
+	parsedArgs, err := parseEscalationNameArgs(args)
+	if err != nil {
+		return cmd.Help()
+	}
+
+	k8sConfig, err := config.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	kudoClient, err := kudoclientset.NewForConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	escalation, err := kudoClient.K8sV1alpha1().Escalations().Get(cmd.Context(), parsedArgs.escalationName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to get escalation, reason is: %w", err)
+	}
+
+	return printTimeline(os.Stdout, escalation)
+}
+
+func printTimeline(out io.Writer, escalation *kudov1alpha1.Escalation) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "TIME\tFROM\tTO\tACTOR\tDETAILS")
+
+	for _, transition := range escalation.Status.History {
+		from := string(transition.From)
+		if from == "" {
+			from = "-"
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\n",
+			transition.At.UTC().Format(time.RFC3339),
+			from,
+			transition.To,
+			transition.Actor,
+			transition.Details,
+		)
+	}
+
+	return w.Flush()
+}
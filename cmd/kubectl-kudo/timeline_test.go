@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestPrintTimeline(t *testing.T) {
+	var (
+		createdAt  = time.Date(2022, time.October, 10, 1, 30, 1, 0, time.UTC)
+		escalation = kudov1alpha1.Escalation{
+			Status: kudov1alpha1.EscalationStatus{
+				History: []kudov1alpha1.EscalationTransition{
+					{
+						To:      kudov1alpha1.StatePending,
+						At:      metav1.NewTime(createdAt),
+						Actor:   kudov1alpha1.ControllerActor,
+						Details: "This escalation is being processed",
+					},
+					{
+						From:    kudov1alpha1.StatePending,
+						To:      kudov1alpha1.StateAccepted,
+						At:      metav1.NewTime(createdAt.Add(time.Second)),
+						Actor:   kudov1alpha1.ControllerActor,
+						Details: "This escalation has been accepted",
+					},
+					{
+						From:    kudov1alpha1.StateAccepted,
+						To:      kudov1alpha1.StateRevoked,
+						At:      metav1.NewTime(createdAt.Add(time.Hour)),
+						Actor:   kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindAdmin, Name: "admin"},
+						Details: "This escalation has been revoked",
+					},
+				},
+			},
+		}
+		out bytes.Buffer
+	)
+
+	require.NoError(t, printTimeline(&out, &escalation))
+
+	assert.Equal(
+		t,
+		`TIME                   FROM       TO         ACTOR         DETAILS
+2022-10-10T01:30:01Z   -          PENDING    Controller    This escalation is being processed
+2022-10-10T01:30:02Z   PENDING    ACCEPTED   Controller    This escalation has been accepted
+2022-10-10T02:30:01Z   ACCEPTED   REVOKED    Admin admin   This escalation has been revoked
+`,
+		out.String(),
+	)
+}
@@ -362,20 +362,19 @@ func TestEscalation_Controller_DenyEscalationIfPolicyChanges(t *testing.T) {
 		},
 	)
 
-	// Now admin mutates the policy.
-	gotPolicy.Spec.Target.Grants = append(
-		policy.Spec.Target.Grants,
+	// Now admin changes the granted role.
+	gotPolicy.Spec.Target.Grants = []kudov1alpha1.ValueWithKind{
 		kudov1alpha1.MustEncodeValueWithKind(
 			kudov1alpha1.GrantKindK8sRoleBinding,
 			kudov1alpha1.K8sRoleBindingGrant{
-				DefaultNamespace: "foo",
+				AllowedNamespaces: []string{namespace.Name},
 				RoleRef: rbacv1.RoleRef{
 					Kind: "ClusterRole",
 					Name: "some-other-role",
 				},
 			},
 		),
-	)
+	}
 
 	_, err = admin.kudo.K8sV1alpha1().EscalationPolicies().Update(ctx, gotPolicy, metav1.UpdateOptions{})
 	require.NoError(t, err)
@@ -460,8 +459,8 @@ func TestEscalation_Controller_DropsPermissionsIfEscalationDeleted(t *testing.T)
 		},
 	)
 
-	// Now admin deletes the escalation.
-	err = admin.kudo.K8sV1alpha1().EscalationPolicies().Delete(ctx, gotEsc.Name, metav1.DeleteOptions{})
+	// Now admin deletes the escalation, it is allowed to do so as a member of the admin groups.
+	err = admin.kudo.K8sV1alpha1().Escalations().Delete(ctx, gotEsc.Name, metav1.DeleteOptions{})
 	require.NoError(t, err)
 
 	// We can't properly sync on the escalation state as it is deleted now. Let's wait a bit.
@@ -470,3 +469,99 @@ func TestEscalation_Controller_DropsPermissionsIfEscalationDeleted(t *testing.T)
 	// Bindings are reclaimed.
 	assertGrantedK8sResourcesDeleted(t, *gotEsc, "rolebindings")
 }
+
+// This test makes sure that a delegate can create an escalation on behalf of another user and that the controller
+// records the delegate in the escalation history.
+func TestEscalation_Controller_AcceptsDelegatedEscalations(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		beneficiary = "user-" + k8sFixtureName(t)
+		namespace   = generateNamespace(t, 0)
+		role        = generateRole(t, 0, namespace.Name, rbacv1.PolicyRule{
+			Verbs:     []string{"list"},
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+		})
+		policy = generateEscalationPolicy(
+			t,
+			withSubjects(
+				kudov1alpha1.PolicySubject{
+					Subject: rbacv1.Subject{
+						Kind: rbacv1.UserKind,
+						Name: beneficiary,
+					},
+				},
+			),
+			withDelegates(
+				kudov1alpha1.PolicySubject{
+					Subject: rbacv1.Subject{
+						Kind: rbacv1.UserKind,
+						Name: userA.userName,
+					},
+				},
+			),
+			withGrants(
+				kudov1alpha1.MustEncodeValueWithKind(
+					kudov1alpha1.GrantKindK8sRoleBinding,
+					kudov1alpha1.K8sRoleBindingGrant{
+						AllowedNamespaces: []string{namespace.Name},
+						RoleRef: rbacv1.RoleRef{
+							Kind: "Role",
+							Name: role.Name,
+						},
+					},
+				),
+			),
+		)
+
+		escalation = generateEscalation(
+			t,
+			policy.Name,
+			withNamespace(namespace.Name),
+			withOnBehalfOf(beneficiary),
+		)
+
+		err error
+	)
+
+	_, err = admin.k8s.CoreV1().Namespaces().Create(ctx, &namespace, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = admin.k8s.RbacV1().Roles(namespace.Name).Create(
+		ctx,
+		&role,
+		metav1.CreateOptions{},
+	)
+	require.NoError(t, err)
+
+	_, err = admin.kudo.K8sV1alpha1().EscalationPolicies().Create(ctx, &policy, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assertPolicyCreated(t, policy.Name)
+
+	// userA creates the escalation on behalf of the beneficiary.
+	_, err = userA.kudo.K8sV1alpha1().Escalations().Create(ctx, &escalation, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// admin waits for escalation to reach state ACCEPTED, and grants are created
+	gotEsc := assertEscalationInState(
+		t,
+		escalation.Name,
+		escalationWaitCondSpec{
+			state: kudov1alpha1.StateAccepted,
+			grantStatuses: []kudov1alpha1.GrantStatus{
+				kudov1alpha1.GrantStatusCreated,
+			},
+		},
+	)
+
+	assert.Equal(t, beneficiary, gotEsc.Spec.Requestor)
+	require.NotEmpty(t, gotEsc.Status.History)
+	assert.Equal(
+		t,
+		kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindDelegate, Name: userA.userName},
+		gotEsc.Status.History[0].Actor,
+	)
+}
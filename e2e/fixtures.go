@@ -67,6 +67,18 @@ func withMaxDuration(duration time.Duration) escalationPolicyOption {
 	}
 }
 
+func withSubjects(subjects ...kudov1alpha1.PolicySubject) escalationPolicyOption {
+	return func(p *kudov1alpha1.EscalationPolicy) {
+		p.Spec.Subjects = subjects
+	}
+}
+
+func withDelegates(delegates ...kudov1alpha1.PolicySubject) escalationPolicyOption {
+	return func(p *kudov1alpha1.EscalationPolicy) {
+		p.Spec.Delegation = &kudov1alpha1.EscalationDelegation{Delegates: delegates}
+	}
+}
+
 func generateEscalationPolicy(t *testing.T, opts ...escalationPolicyOption) kudov1alpha1.EscalationPolicy {
 	t.Helper()
 
@@ -75,10 +87,12 @@ func generateEscalationPolicy(t *testing.T, opts ...escalationPolicyOption) kudo
 			Name: k8sFixtureName(t),
 		},
 		Spec: kudov1alpha1.EscalationPolicySpec{
-			Subjects: []rbacv1.Subject{
+			Subjects: []kudov1alpha1.PolicySubject{
 				{
-					Kind: rbacv1.UserKind,
-					Name: userA.userName,
+					Subject: rbacv1.Subject{
+						Kind: rbacv1.UserKind,
+						Name: userA.userName,
+					},
 				},
 			},
 			Target: kudov1alpha1.EscalationTarget{
@@ -109,6 +123,12 @@ func withDuration(duration time.Duration) escalationOption {
 	}
 }
 
+func withOnBehalfOf(userName string) escalationOption {
+	return func(e *kudov1alpha1.Escalation) {
+		e.Spec.OnBehalfOf = userName
+	}
+}
+
 func generateEscalation(t *testing.T, policyName string, opts ...escalationOption) kudov1alpha1.Escalation {
 	t.Helper()
 
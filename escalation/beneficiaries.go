@@ -0,0 +1,49 @@
+package escalation
+
+import (
+	"fmt"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+// beneficiariesDenialReason returns a non empty reason if one of the escalation beneficiaries can't be granted its permissions.
+// Only beneficiaries usernames are known, they're reviewed against the policy subjects by username. As a username isn't enough to
+// tell whether a beneficiary is excluded by a group, a service account or some extra attributes, policies excluding subjects
+// that aren't plain users don't allow beneficiaries.
+func beneficiariesDenialReason(policy *kudov1alpha1.EscalationPolicy, esc *kudov1alpha1.Escalation) string {
+	if len(esc.Spec.Beneficiaries) > 0 && !exclusionsMatchUsernames(policy) {
+		return fmt.Sprintf("the escalation policy %q excludes subjects that are not users, it can't grant permissions to beneficiaries", policy.Name)
+	}
+
+	for i, beneficiary := range esc.Spec.Beneficiaries {
+		if strings.TrimSpace(beneficiary) == "" {
+			return "beneficiaries must be identified by their username"
+		}
+
+		if beneficiary == esc.Spec.Requestor || generics.Contains(esc.Spec.Beneficiaries[:i], beneficiary) {
+			return fmt.Sprintf("beneficiary %q is listed more than once", beneficiary)
+		}
+
+		if !userAllowed(*policy, authenticationv1.UserInfo{Username: beneficiary}) {
+			return fmt.Sprintf("beneficiary %q is not allowed to use the escalation policy %q", beneficiary, policy.Name)
+		}
+	}
+
+	return ""
+}
+
+// exclusionsMatchUsernames returns true if all the subjects excluded by the policy are users without extra attributes.
+func exclusionsMatchUsernames(policy *kudov1alpha1.EscalationPolicy) bool {
+	for _, subject := range policy.Spec.ExcludedSubjects {
+		if subject.Kind != rbacv1.UserKind || len(subject.Extra) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
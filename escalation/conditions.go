@@ -0,0 +1,88 @@
+package escalation
+
+import (
+	"context"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/jlevesy/kudo/escalationpolicy"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+type EscalationsLister interface {
+	List(selector labels.Selector) ([]*kudov1alpha1.Escalation, error)
+}
+
+// evaluateConditions evaluates the policy conditions over an escalation requested by the given user.
+func evaluateConditions(
+	ctx context.Context,
+	lister EscalationsLister,
+	policy *kudov1alpha1.EscalationPolicy,
+	esc *kudov1alpha1.Escalation,
+	user authenticationv1.UserInfo,
+	now time.Time,
+) (escalationpolicy.ConditionsResult, error) {
+	if len(policy.Spec.Conditions) == 0 {
+		return escalationpolicy.ConditionsResult{}, nil
+	}
+
+	activeEscalations, err := activeEscalations(lister, esc, user.Username)
+	if err != nil {
+		return escalationpolicy.ConditionsResult{}, err
+	}
+
+	extra := make(map[string][]string, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = value
+	}
+
+	return escalationpolicy.EvaluateConditions(
+		ctx,
+		policy.Spec.Conditions,
+		escalationpolicy.ConditionInput{
+			Requestor:         user.Username,
+			Beneficiaries:     esc.Spec.Beneficiaries,
+			Groups:            user.Groups,
+			Extra:             extra,
+			Namespace:         esc.Spec.Namespace,
+			Duration:          escalationDuration(esc, policy),
+			Reason:            esc.Spec.Reason,
+			Now:               now,
+			ActiveEscalations: activeEscalations,
+		},
+	)
+}
+
+// activeEscalations returns the accepted escalations granting permissions to a requestor, except the given one.
+func activeEscalations(lister EscalationsLister, esc *kudov1alpha1.Escalation, requestor string) ([]*kudov1alpha1.Escalation, error) {
+	escalations, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*kudov1alpha1.Escalation
+
+	for _, candidate := range escalations {
+		if candidate.Name == esc.Name ||
+			!generics.Contains(candidate.Spec.Grantees(), requestor) ||
+			candidate.Status.State != kudov1alpha1.StateAccepted {
+			continue
+		}
+
+		active = append(active, candidate)
+	}
+
+	return active, nil
+}
+
+// requestorInfo returns the identity of the escalation requestor, only its username is known if it hasn't been recorded.
+func requestorInfo(esc *kudov1alpha1.Escalation) authenticationv1.UserInfo {
+	if esc.Spec.RequestorInfo != nil {
+		return *esc.Spec.RequestorInfo
+	}
+
+	return authenticationv1.UserInfo{Username: esc.Spec.Requestor}
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/jlevesy/kudo/audit"
+	"github.com/jlevesy/kudo/escalationpolicy"
 	"github.com/jlevesy/kudo/grant"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"github.com/jlevesy/kudo/pkg/controllersupport"
@@ -19,13 +20,20 @@ import (
 
 const (
 	PendingStateDetails              = "This escalation is being processed"
+	PendingApprovalStateDetails      = "This escalation is waiting for the approval of one of the policy reviewers"
+	PendingAcknowledgementDetails    = "This escalation has been created on behalf of its requestor, it is waiting for their acknowledgement"
+	PendingAcknowledgedDetails       = "This escalation has been acknowledged by its requestor, it is being processed"
 	AcceptedInProgressStateDetails   = "This escalation has been accepted, permissions are going to be granted in a few moments"
 	AcceptedAppliedStateDetails      = "This escalation has been accepted, permissions are granted"
 	ExpiredStateDetails              = "This escalation has expired, all granted permissions are reclaimed"
 	ExpiredStateWillReclaimDetails   = "This escalation has expired, all granted permissions are going to be reclaimed"
+	ReleasedStateDetails             = "This escalation has been released by its requestor, all granted permissions are reclaimed"
 	DeniedBadEscalationSpecDetails   = "This escalation does not have necessary information, it is denied"
 	DeniedPolicyNotFoundStateDetails = "This escalation references a policy that do not exist anymore, all granted permissions are reclaimed"
 	DeniedPolicyChangedStateDetails  = "This escalation references a policy that has changed, all granted permissions are reclaimed"
+	DeniedRequestorNotAllowedDetails = "This escalation requestor is not allowed to use its policy anymore, all granted permissions are reclaimed"
+
+	PolicyChangedGrantsRecreatedStateDetails = "This escalation references a policy that has changed, changed grants are going to be granted again in a few moments"
 )
 
 var statusZero = kudov1alpha1.EscalationStatus{}
@@ -34,17 +42,29 @@ type EscalationStatusUpdater interface {
 	UpdateStatus(ctx context.Context, escalation *kudov1alpha1.Escalation, opts metav1.UpdateOptions) (*kudov1alpha1.Escalation, error)
 }
 
+type EscalationDeleter interface {
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+type EscalationsClient interface {
+	EscalationStatusUpdater
+	EscalationDeleter
+}
+
 type EventInsight = controllersupport.EventInsight[kudov1alpha1.Escalation]
 
 type Controller struct {
-	policiesGetter          EscalationPoliciesGetter
-	escalationStatusUpdater EscalationStatusUpdater
-	granterFactory          grant.Factory
-	auditSink               audit.Sink
+	policyResolver    PolicyResolver
+	escalationsLister EscalationsLister
+	escalationsClient EscalationsClient
+	granterFactory    grant.Factory
+	auditSink         audit.Sink
+	archiver          audit.Archiver
 
 	nowFunc        func() time.Time
 	resyncInterval time.Duration
 	retryInterval  time.Duration
+	retention      time.Duration
 }
 
 type ControllerOpt func(c *Controller)
@@ -67,21 +87,38 @@ func WithRetryInterval(d time.Duration) ControllerOpt {
 	}
 }
 
+// WithRetention deletes finished escalations once all their grants are reclaimed and the given retention has elapsed.
+// Escalations are kept forever if the retention is zero.
+func WithRetention(d time.Duration) ControllerOpt {
+	return func(c *Controller) {
+		c.retention = d
+	}
+}
+
+// WithArchiver hands finished escalations to the given archiver before deleting them.
+func WithArchiver(archiver audit.Archiver) ControllerOpt {
+	return func(c *Controller) {
+		c.archiver = archiver
+	}
+}
+
 func NewController(
-	policiesGetter EscalationPoliciesGetter,
-	escalationStatusUpdater EscalationStatusUpdater,
+	policyResolver PolicyResolver,
+	escalationsLister EscalationsLister,
+	escalationsClient EscalationsClient,
 	granterFactory grant.Factory,
 	auditSink audit.Sink,
 	opts ...ControllerOpt,
 ) *Controller {
 	c := Controller{
-		policiesGetter:          policiesGetter,
-		escalationStatusUpdater: escalationStatusUpdater,
-		granterFactory:          granterFactory,
-		auditSink:               auditSink,
-		nowFunc:                 time.Now,
-		resyncInterval:          30 * time.Second,
-		retryInterval:           5 * time.Second,
+		policyResolver:    policyResolver,
+		escalationsLister: escalationsLister,
+		escalationsClient: escalationsClient,
+		granterFactory:    granterFactory,
+		auditSink:         auditSink,
+		nowFunc:           time.Now,
+		resyncInterval:    30 * time.Second,
+		retryInterval:     5 * time.Second,
 	}
 
 	for _, opt := range opts {
@@ -116,20 +153,35 @@ func (c *Controller) OnAdd(ctx context.Context, escalation *kudov1alpha1.Escalat
 		return EventInsight{}, err
 	}
 
+	mutations := []kudov1alpha1.TransitionMutation{
+		kudov1alpha1.WithDetails(PendingStateDetails),
+		kudov1alpha1.WithPolicyInfo(policy.UID, policyVersion(policy)),
+		kudov1alpha1.WithPolicySnapshot(policySnapshot(escalation, policy)),
+	}
+
+	if escalation.Spec.IsDelegated() {
+		mutations = append(
+			mutations,
+			kudov1alpha1.WithActor(
+				kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindDelegate, Name: escalation.Spec.Delegate.Username},
+			),
+		)
+	}
+
 	_, err = c.updateStatus(
 		ctx,
 		escalation,
-		escalation.Status.TransitionTo(
-			kudov1alpha1.StatePending,
-			kudov1alpha1.WithDetails(PendingStateDetails),
-			kudov1alpha1.WithPolicyInfo(policy.UID, policy.ResourceVersion),
-		),
+		escalation.Status.TransitionTo(kudov1alpha1.StatePending, mutations...),
 	)
 
 	return EventInsight{}, err
 }
 
 func (c *Controller) OnUpdate(ctx context.Context, _, esc *kudov1alpha1.Escalation) (EventInsight, error) {
+	if c.isRetentionOver(esc) {
+		return c.cleanUp(ctx, esc), nil
+	}
+
 	status, err := c.reconcileState(ctx, esc)
 	if err != nil {
 		return EventInsight{}, err
@@ -156,6 +208,12 @@ func (c *Controller) OnUpdate(ctx context.Context, _, esc *kudov1alpha1.Escalati
 }
 
 func (c *Controller) OnDelete(ctx context.Context, esc *kudov1alpha1.Escalation) (EventInsight, error) {
+	// The escalation has been archived and deleted once its retention is over, there's nothing left to reclaim.
+	if isArchived(esc) {
+		klog.InfoS("Escalation cleaned up", "escalation", esc.Name)
+		return EventInsight{}, nil
+	}
+
 	klog.InfoS("Escalation deleted, reclaiming permissions", "escalation", esc.Name)
 
 	c.auditSink.RecordDelete(ctx, esc)
@@ -165,6 +223,34 @@ func (c *Controller) OnDelete(ctx context.Context, esc *kudov1alpha1.Escalation)
 }
 
 func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Escalation) (kudov1alpha1.EscalationStatus, error) {
+	// An administrator has revoked the escalation, whatever its progress is.
+	if IsRevoked(newEsc) && isReleasable(newEsc.Status.State) {
+		return newEsc.Status.TransitionTo(
+			kudov1alpha1.StateRevoked,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation has been revoked by %s, all granted permissions are reclaimed. Reason is: %s",
+					newEsc.Spec.Revocation.RevokedBy,
+					newEsc.Spec.Revocation.Reason,
+				),
+			),
+			kudov1alpha1.WithActor(
+				kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindAdmin, Name: newEsc.Spec.Revocation.RevokedBy},
+			),
+		), nil
+	}
+
+	// The requestor has released the escalation, whatever its progress is.
+	if newEsc.Spec.Released && isReleasable(newEsc.Status.State) {
+		return newEsc.Status.TransitionTo(
+			kudov1alpha1.StateReleased,
+			kudov1alpha1.WithDetails(ReleasedStateDetails),
+			kudov1alpha1.WithActor(
+				kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindRequestor, Name: newEsc.Spec.Requestor},
+			),
+		), nil
+	}
+
 	switch newEsc.Status.State {
 	case kudov1alpha1.StatePending:
 		policy, newStatus, updated, err := c.readPolicy(ctx, newEsc)
@@ -175,26 +261,100 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 			return newStatus, nil
 		}
 
-		// Has policy changed since the escalation was created? If so, deny the escalation.
-		if hasPolicyChanged(newEsc, policy) {
+		// The escalation has been created on behalf of its requestor, who has to agree with it first.
+		if newStatus, updated := reviewAcknowledgement(newEsc, policy); updated {
+			return newStatus, nil
+		}
+
+		if newStatus, denied := reviewRequestor(newEsc, policy); denied {
+			return newStatus, nil
+		}
+
+		// Has policy changed since the escalation was created? If so, make sure it still allows the escalation.
+		if newStatus, updated := c.reviewPolicyChange(ctx, newEsc, policy); updated {
+			return newStatus, nil
+		}
+
+		conditions, newStatus, denied := c.reviewConditions(ctx, newEsc, policy)
+		if denied {
+			return newStatus, nil
+		}
+
+		// Some conditions require the escalation to be approved by one of the policy reviewers first.
+		if conditions.ChallengeRequired && newEsc.Spec.ApprovedBy == "" {
 			return newEsc.Status.TransitionTo(
-				kudov1alpha1.StateDenied,
-				kudov1alpha1.WithDetails(DeniedPolicyChangedStateDetails),
+				kudov1alpha1.StatePending,
+				kudov1alpha1.WithDetails(PendingApprovalStateDetails),
 			), nil
 		}
 
-		// Policies challenges will be evaluated here.
+		var (
+			now      = c.nowFunc()
+			startAt  = now
+			duration = escalationDuration(newEsc, policy)
+		)
+
+		if newEsc.Spec.StartAt != nil {
+			startAt = newEsc.Spec.StartAt.Time
+		}
 
-		// Compute expires at, now that the escalation is accepted.
-		duration := newEsc.Spec.Duration
-		if duration.Duration == 0 {
-			duration = policy.Spec.Target.DefaultDuration
+		// The escalation is approved ahead of time, wait for its start before granting anything.
+		if startAt.After(now) {
+			return newEsc.Status.TransitionTo(
+				kudov1alpha1.StateScheduled,
+				kudov1alpha1.WithExpiresAt(startAt.Add(duration)),
+				kudov1alpha1.WithDetails(
+					fmt.Sprintf(
+						"This escalation has been accepted, permissions are going to be granted at %s",
+						startAt.UTC().Format(time.RFC3339),
+					),
+				),
+				kudov1alpha1.WithActor(approver(newEsc)),
+			), nil
 		}
 
 		// if ok, transition to accepted.
 		return newEsc.Status.TransitionTo(
 			kudov1alpha1.StateAccepted,
-			kudov1alpha1.WithExpiresAt(c.nowFunc().Add(duration.Duration)),
+			kudov1alpha1.WithAcceptedAt(startAt),
+			kudov1alpha1.WithExpiresAt(startAt.Add(duration)),
+			kudov1alpha1.WithDetails(AcceptedInProgressStateDetails),
+			kudov1alpha1.WithActor(approver(newEsc)),
+		), nil
+
+	case kudov1alpha1.StateScheduled:
+		policy, newStatus, updated, err := c.readPolicy(ctx, newEsc)
+		if err != nil {
+			return statusZero, err
+		}
+		if updated {
+			return newStatus, nil
+		}
+
+		if newStatus, denied := reviewRequestor(newEsc, policy); denied {
+			return newStatus, nil
+		}
+
+		if newStatus, updated := c.reviewPolicyChange(ctx, newEsc, policy); updated {
+			return newStatus, nil
+		}
+
+		if _, newStatus, denied := c.reviewConditions(ctx, newEsc, policy); denied {
+			return newStatus, nil
+		}
+
+		startAt := newEsc.Spec.StartAt.Time
+
+		// Not started yet, keep waiting.
+		if startAt.After(c.nowFunc()) {
+			return newEsc.Status.TransitionTo(kudov1alpha1.StateScheduled), nil
+		}
+
+		// The escalation window is computed from its start, not from the moment it is processed.
+		return newEsc.Status.TransitionTo(
+			kudov1alpha1.StateAccepted,
+			kudov1alpha1.WithAcceptedAt(startAt),
+			kudov1alpha1.WithExpiresAt(startAt.Add(escalationDuration(newEsc, policy))),
 			kudov1alpha1.WithDetails(AcceptedInProgressStateDetails),
 		), nil
 
@@ -215,11 +375,20 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 			return newStatus, nil
 		}
 
-		if hasPolicyChanged(newEsc, policy) {
-			return newEsc.Status.TransitionTo(
-				kudov1alpha1.StateDenied,
-				kudov1alpha1.WithDetails(DeniedPolicyChangedStateDetails),
-			), nil
+		if newStatus, denied := reviewRequestor(newEsc, policy); denied {
+			return newStatus, nil
+		}
+
+		if newStatus, updated := c.reviewPolicyChange(ctx, newEsc, policy); updated {
+			return newStatus, nil
+		}
+
+		if _, newStatus, denied := c.reviewConditions(ctx, newEsc, policy); denied {
+			return newStatus, nil
+		}
+
+		if newStatus, extended := applyExtensions(newEsc, policy); extended {
+			return newStatus, nil
 		}
 
 		return c.createGrants(ctx, newEsc, policy)
@@ -236,6 +405,7 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 					),
 				),
 				kudov1alpha1.WithNewGrantRefs(grantRefs),
+				kudov1alpha1.WithReclaimFailure(err),
 			), nil
 		}
 
@@ -244,6 +414,48 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 			kudov1alpha1.WithNewGrantRefs(grantRefs),
 		), nil
 
+	case kudov1alpha1.StateReleased:
+		grantRefs, err := c.reclaimGrants(ctx, newEsc)
+		if err != nil {
+			return newEsc.Status.TransitionTo(
+				kudov1alpha1.StateReleased,
+				kudov1alpha1.WithDetails(
+					fmt.Sprintf(
+						"This escalation has been released, but grants have been partially reclaimed. Reason is: %s",
+						err.Error(),
+					),
+				),
+				kudov1alpha1.WithNewGrantRefs(grantRefs),
+				kudov1alpha1.WithReclaimFailure(err),
+			), nil
+		}
+
+		return newEsc.Status.TransitionTo(
+			kudov1alpha1.StateReleased,
+			kudov1alpha1.WithNewGrantRefs(grantRefs),
+		), nil
+
+	case kudov1alpha1.StateRevoked:
+		grantRefs, err := c.reclaimGrants(ctx, newEsc)
+		if err != nil {
+			return newEsc.Status.TransitionTo(
+				kudov1alpha1.StateRevoked,
+				kudov1alpha1.WithDetails(
+					fmt.Sprintf(
+						"This escalation has been revoked, but grants have been partially reclaimed. Reason is: %s",
+						err.Error(),
+					),
+				),
+				kudov1alpha1.WithNewGrantRefs(grantRefs),
+				kudov1alpha1.WithReclaimFailure(err),
+			), nil
+		}
+
+		return newEsc.Status.TransitionTo(
+			kudov1alpha1.StateRevoked,
+			kudov1alpha1.WithNewGrantRefs(grantRefs),
+		), nil
+
 	case kudov1alpha1.StateDenied:
 		grantRefs, err := c.reclaimGrants(ctx, newEsc)
 		if err != nil {
@@ -256,6 +468,7 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 					),
 				),
 				kudov1alpha1.WithNewGrantRefs(grantRefs),
+				kudov1alpha1.WithReclaimFailure(err),
 			), nil
 		}
 
@@ -269,28 +482,76 @@ func (c *Controller) reconcileState(ctx context.Context, newEsc *kudov1alpha1.Es
 	}
 }
 
-func (c *Controller) createGrants(ctx context.Context, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, error) {
-	grantRefs := make([]kudov1alpha1.EscalationGrantRef, len(policy.Spec.Target.Grants))
-	group, ctx := errgroup.WithContext(ctx)
+// applyExtensions pushes back the expiry of an accepted escalation for all the extensions ready to be applied, in order.
+// An extension that waits for an approval blocks the ones requested after it.
+func applyExtensions(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, bool) {
+	var (
+		applied      = esc.Status.AppliedExtensions
+		expiresAt    = esc.Status.ExpiresAt.Time
+		maxExpiresAt = esc.MaxExpiresAt(policy)
+		actor        kudov1alpha1.EscalationActor
+	)
 
-	for i, grant := range policy.Spec.Target.Grants {
-		i := i
-		grant := grant
+	for _, extension := range esc.PendingExtensions() {
+		if policy.Spec.Target.ExtensionsRequireApproval && extension.ApprovedBy == "" {
+			break
+		}
 
-		group.Go(func() error {
-			granter, err := c.granterFactory.Get(grant.Kind)
-			if err != nil {
-				return err
-			}
+		expiresAt = expiresAt.Add(extension.Duration.Duration)
+		if expiresAt.After(maxExpiresAt) {
+			expiresAt = maxExpiresAt
+		}
 
-			grantRefs[i], err = granter.Create(ctx, esc, grant)
+		actor = kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindRequestor, Name: extension.Requestor}
+		if extension.ApprovedBy != "" {
+			actor = kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindReviewer, Name: extension.ApprovedBy}
+		}
 
-			return err
-		})
+		applied++
+	}
+
+	if applied == esc.Status.AppliedExtensions {
+		return statusZero, false
+	}
+
+	return esc.Status.TransitionTo(
+		kudov1alpha1.StateAccepted,
+		kudov1alpha1.WithDetails(
+			fmt.Sprintf("This escalation has been extended, it now expires at %s", expiresAt.UTC().Format(time.RFC3339)),
+		),
+		kudov1alpha1.WithExpiresAt(expiresAt),
+		kudov1alpha1.WithAppliedExtensions(applied),
+		kudov1alpha1.WithActor(actor),
+	), true
+}
+
+func (c *Controller) createGrants(ctx context.Context, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, error) {
+	target, grants, err := grantTarget(esc, policy)
+	if err != nil {
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf("Escalation has been denied, reason is: %s", err.Error()),
+			),
+		), nil
+	}
+
+	var grantRefs []kudov1alpha1.EscalationGrantRef
+
+	if target.OrderedGrants {
+		grantRefs, err = c.createGrantsInOrder(ctx, esc, grants)
+	} else {
+		grantRefs, err = c.createGrantsConcurrently(ctx, esc, grants)
+	}
+
+	for i := range grantRefs {
+		if grantRefs[i].Ref.Kind == "" {
+			grantRefs[i] = uncreatedGrantRef(esc, i, grants[i])
+		}
 	}
 
 	// If we fail to apply one target, it'll be retried in the next resync.
-	if err := group.Wait(); err != nil {
+	if err != nil {
 		klog.ErrorS(
 			err,
 			"Granter reports an issue while creating",
@@ -306,9 +567,18 @@ func (c *Controller) createGrants(ctx context.Context, esc *kudov1alpha1.Escalat
 				kudov1alpha1.WithDetails(
 					fmt.Sprintf("Escalation has been denied, reason is: %s", err.Error()),
 				),
+				kudov1alpha1.WithTampering(err),
 			), nil
 		}
 
+		if target.AtomicGrants {
+			if target.GrantFailurePolicy == kudov1alpha1.GrantFailurePolicyDeny {
+				return c.compensateGrants(ctx, esc, grantRefs, err), nil
+			}
+
+			return c.resetGrants(ctx, esc, grantRefs, err), nil
+		}
+
 		return esc.Status.TransitionTo(
 			kudov1alpha1.StateAccepted,
 			kudov1alpha1.WithDetails(
@@ -325,16 +595,230 @@ func (c *Controller) createGrants(ctx context.Context, esc *kudov1alpha1.Escalat
 	), nil
 }
 
-func (h *Controller) reclaimGrants(ctx context.Context, esc *kudov1alpha1.Escalation) ([]kudov1alpha1.EscalationGrantRef, error) {
-	grantRefs := make([]kudov1alpha1.EscalationGrantRef, len(esc.Status.GrantRefs))
+// createGrantsConcurrently creates all grants, even if some of them fail, and returns the refs of all the created ones.
+func (c *Controller) createGrantsConcurrently(ctx context.Context, esc *kudov1alpha1.Escalation, grants []kudov1alpha1.ValueWithKind) ([]kudov1alpha1.EscalationGrantRef, error) {
+	var (
+		grantRefs = make([]kudov1alpha1.EscalationGrantRef, len(grants))
+		group     errgroup.Group
+	)
+
+	for i, grant := range grants {
+		i := i
+		grant := grant
+
+		group.Go(func() error {
+			var err error
+
+			grantRefs[i], err = c.createGrant(ctx, esc, i, grant)
+
+			return err
+		})
+	}
+
+	return grantRefs, group.Wait()
+}
+
+// createGrantsInOrder creates grants one after the other and stops at the first failure.
+func (c *Controller) createGrantsInOrder(ctx context.Context, esc *kudov1alpha1.Escalation, grants []kudov1alpha1.ValueWithKind) ([]kudov1alpha1.EscalationGrantRef, error) {
+	grantRefs := make([]kudov1alpha1.EscalationGrantRef, len(grants))
+
+	for i, grant := range grants {
+		var err error
+
+		grantRefs[i], err = c.createGrant(ctx, esc, i, grant)
+		if err != nil {
+			return grantRefs, err
+		}
+	}
+
+	return grantRefs, nil
+}
+
+func (c *Controller) createGrant(ctx context.Context, esc *kudov1alpha1.Escalation, i int, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+	expiresAt, err := grantExpiresAt(esc, grant)
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	granter, err := c.granterFactory.Get(grant.Kind)
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	if !expiresAt.IsZero() && !c.nowFunc().Before(expiresAt) {
+		return c.expireGrant(ctx, esc, granter, i, grant, expiresAt)
+	}
+
+	ref, err := granter.Create(ctx, esc, grant)
+	if err != nil {
+		return ref, err
+	}
+
+	ref.ExpiresAt = metav1.Time{Time: expiresAt}
+
+	return ref, nil
+}
+
+// uncreatedGrantRef returns the ref to record for a grant that could not be created.
+// It keeps the ref of a previous attempt, so that a grant created back then is still reclaimed eventually.
+func uncreatedGrantRef(esc *kudov1alpha1.Escalation, i int, grant kudov1alpha1.ValueWithKind) kudov1alpha1.EscalationGrantRef {
+	if i < len(esc.Status.GrantRefs) && esc.Status.GrantRefs[i].Ref.Kind == grant.Kind {
+		return esc.Status.GrantRefs[i]
+	}
+
+	return kudov1alpha1.EscalationGrantRef{
+		Status: kudov1alpha1.GrantStatusUnknown,
+		Ref:    kudov1alpha1.MustEncodeValueWithKind(grant.Kind, struct{}{}),
+	}
+}
+
+// expireGrant reclaims a grant that has reached its own expiry time, while the escalation is still active.
+func (c *Controller) expireGrant(ctx context.Context, esc *kudov1alpha1.Escalation, granter grant.Granter, i int, grant kudov1alpha1.ValueWithKind, expiresAt time.Time) (kudov1alpha1.EscalationGrantRef, error) {
+	var previousRef kudov1alpha1.EscalationGrantRef
+
+	if i < len(esc.Status.GrantRefs) && esc.Status.GrantRefs[i].Ref.Kind == grant.Kind {
+		previousRef = esc.Status.GrantRefs[i]
+	}
+
+	switch previousRef.Status {
+	case kudov1alpha1.GrantStatusCreated:
+		ref, err := granter.Reclaim(ctx, previousRef)
+		if err != nil {
+			return previousRef, err
+		}
+
+		klog.InfoS("Reclaimed an expired grant", "escalation", esc.Name, "grantKind", grant.Kind, "expiresAt", expiresAt)
+
+		ref.ExpiresAt = metav1.Time{Time: expiresAt}
+
+		return ref, nil
+	case kudov1alpha1.GrantStatusReclaimed:
+		return previousRef, nil
+	default:
+		// The grant has expired before kudo had a chance to create it, record it as reclaimed.
+		ref, err := kudov1alpha1.EncodeValueWithKind(grant.Kind, struct{}{})
+
+		return kudov1alpha1.EscalationGrantRef{
+			Status:    kudov1alpha1.GrantStatusReclaimed,
+			Ref:       ref,
+			ExpiresAt: metav1.Time{Time: expiresAt},
+		}, err
+	}
+}
+
+// compensateGrants reclaims all the grants created so far for an escalation that could not be fully granted,
+// then denies the escalation.
+func (c *Controller) compensateGrants(ctx context.Context, esc *kudov1alpha1.Escalation, grantRefs []kudov1alpha1.EscalationGrantRef, createErr error) kudov1alpha1.EscalationStatus {
+	var createdRefs []kudov1alpha1.EscalationGrantRef
+
+	for _, ref := range grantRefs {
+		if ref.Status == kudov1alpha1.GrantStatusCreated {
+			createdRefs = append(createdRefs, ref)
+		}
+	}
+
+	reclaimedRefs, err := c.reclaimGrantRefs(ctx, esc.Name, createdRefs)
+	if err != nil {
+		// Some grants are still there, keep track of them. They will be reclaimed once the escalation is denied.
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"Escalation could not be fully granted, and some grants could not be reclaimed. Reason is: %s, reclaim error is: %s",
+					createErr.Error(),
+					err.Error(),
+				),
+			),
+			kudov1alpha1.WithNewGrantRefs(reclaimedRefs),
+			kudov1alpha1.WithReclaimFailure(err),
+		)
+	}
+
+	return esc.Status.TransitionTo(
+		kudov1alpha1.StateDenied,
+		kudov1alpha1.WithDetails(
+			fmt.Sprintf(
+				"Escalation has been denied because it could not be fully granted, all grants are reclaimed. Reason is: %s",
+				createErr.Error(),
+			),
+		),
+		kudov1alpha1.WithNewGrantRefs(reclaimedRefs),
+	)
+}
+
+// resetGrants reclaims all the grants created so far for an escalation that could not be fully granted,
+// then keeps the escalation accepted so that all its grants are created again on the next attempt.
+func (c *Controller) resetGrants(ctx context.Context, esc *kudov1alpha1.Escalation, grantRefs []kudov1alpha1.EscalationGrantRef, createErr error) kudov1alpha1.EscalationStatus {
+	var (
+		createdRefs    []kudov1alpha1.EscalationGrantRef
+		createdIndexes []int
+	)
+
+	for i, ref := range grantRefs {
+		if ref.Status == kudov1alpha1.GrantStatusCreated {
+			createdRefs = append(createdRefs, ref)
+			createdIndexes = append(createdIndexes, i)
+		}
+	}
+
+	reclaimedRefs, err := c.reclaimGrantRefs(ctx, esc.Name, createdRefs)
+
+	resetRefs := make([]kudov1alpha1.EscalationGrantRef, len(grantRefs))
+	copy(resetRefs, grantRefs)
+
+	for i, ref := range reclaimedRefs {
+		resetRefs[createdIndexes[i]] = ref
+	}
+
+	if err != nil {
+		// Some grants are still there, keep track of them. They will be reclaimed on the next attempt.
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateAccepted,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"Escalation could not be fully granted, and some grants could not be reclaimed, retrying. Reason is: %s, reclaim error is: %s",
+					createErr.Error(),
+					err.Error(),
+				),
+			),
+			kudov1alpha1.WithNewGrantRefs(resetRefs),
+			kudov1alpha1.WithReclaimFailure(err),
+		)
+	}
+
+	return esc.Status.TransitionTo(
+		kudov1alpha1.StateAccepted,
+		kudov1alpha1.WithDetails(
+			fmt.Sprintf(
+				"Escalation could not be fully granted, all grants are reclaimed and will be created again. Reason is: %s",
+				createErr.Error(),
+			),
+		),
+		kudov1alpha1.WithNewGrantRefs(resetRefs),
+	)
+}
+
+func (c *Controller) reclaimGrants(ctx context.Context, esc *kudov1alpha1.Escalation) ([]kudov1alpha1.EscalationGrantRef, error) {
+	return c.reclaimGrantRefs(ctx, esc.Name, esc.Status.GrantRefs)
+}
+
+func (c *Controller) reclaimGrantRefs(ctx context.Context, escalationName string, refs []kudov1alpha1.EscalationGrantRef) ([]kudov1alpha1.EscalationGrantRef, error) {
+	grantRefs := make([]kudov1alpha1.EscalationGrantRef, len(refs))
 	group, ctx := errgroup.WithContext(ctx)
 
-	for i, grantRef := range esc.Status.GrantRefs {
+	for i, grantRef := range refs {
 		i := i
 		grantRef := grantRef
 
+		// This grant has never been created, there's nothing to reclaim.
+		if grantRef.Status == kudov1alpha1.GrantStatusUnknown {
+			grantRef.Status = kudov1alpha1.GrantStatusReclaimed
+			grantRefs[i] = grantRef
+			continue
+		}
+
 		group.Go(func() error {
-			granter, err := h.granterFactory.Get(grantRef.Ref.Kind)
+			granter, err := c.granterFactory.Get(grantRef.Ref.Kind)
 			if err != nil {
 				return err
 			}
@@ -351,18 +835,115 @@ func (h *Controller) reclaimGrants(ctx context.Context, esc *kudov1alpha1.Escala
 			err,
 			"One or more reclaims have failed",
 			"escalation",
-			esc.Name,
+			escalationName,
 		)
 
-		return esc.Status.GrantRefs, err
+		return refs, err
 	}
 
 	return grantRefs, nil
 }
 
+// reviewRequestor denies an escalation whose requestor, or one of its beneficiaries, is not part of the policy subjects anymore.
+// Escalations created before the requestor identity was recorded are only reviewed when their policy changes.
+func reviewRequestor(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, bool) {
+	if reason := beneficiariesDenialReason(policy, esc); reason != "" {
+		klog.InfoS("Beneficiaries are not allowed to use the escalation policy anymore, denying the escalation", "escalation", esc.Name, "policy", policy.Name, "reason", reason)
+
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation beneficiaries are not allowed to use its policy anymore, all granted permissions are reclaimed. Reason is: %s",
+					reason,
+				),
+			),
+		), true
+	}
+
+	if esc.Spec.RequestorInfo == nil || userAllowed(*policy, *esc.Spec.RequestorInfo) {
+		return statusZero, false
+	}
+
+	klog.InfoS("Requestor is not allowed to use the escalation policy anymore, denying the escalation", "escalation", esc.Name, "policy", policy.Name)
+
+	return esc.Status.TransitionTo(
+		kudov1alpha1.StateDenied,
+		kudov1alpha1.WithDetails(DeniedRequestorNotAllowedDetails),
+	), true
+}
+
+// reviewAcknowledgement keeps a delegated escalation pending until its requestor acknowledges it,
+// then records the acknowledgement in the escalation history.
+// It returns true if the escalation status has to be updated before going any further.
+func reviewAcknowledgement(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, bool) {
+	if awaitsAcknowledgement(esc, policy) {
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StatePending,
+			kudov1alpha1.WithDetails(PendingAcknowledgementDetails),
+		), true
+	}
+
+	if esc.Spec.Acknowledged && esc.Status.StateDetails == PendingAcknowledgementDetails {
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StatePending,
+			kudov1alpha1.WithDetails(PendingAcknowledgedDetails),
+			kudov1alpha1.WithActor(
+				kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindRequestor, Name: esc.Spec.Requestor},
+			),
+		), true
+	}
+
+	return statusZero, false
+}
+
+// approver returns the reviewer that approved the escalation, or the controller if no approval was required.
+func approver(esc *kudov1alpha1.Escalation) kudov1alpha1.EscalationActor {
+	if esc.Spec.ApprovedBy == "" {
+		return kudov1alpha1.ControllerActor
+	}
+
+	return kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindReviewer, Name: esc.Spec.ApprovedBy}
+}
+
+// reviewConditions evaluates the policy conditions, and denies an escalation they don't allow anymore.
+// Conditions that can't be evaluated deny the escalation as well.
+func (c *Controller) reviewConditions(ctx context.Context, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (escalationpolicy.ConditionsResult, kudov1alpha1.EscalationStatus, bool) {
+	result, err := evaluateConditions(ctx, c.escalationsLister, policy, esc, requestorInfo(esc), c.nowFunc())
+	if err != nil {
+		klog.ErrorS(err, "Unable to evaluate the policy conditions, denying the escalation", "escalation", esc.Name, "policy", policy.Name)
+
+		return result, esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation policy conditions can't be evaluated, all granted permissions are reclaimed. Reason is: %s",
+					err,
+				),
+			),
+		), true
+	}
+
+	if result.Denied {
+		klog.InfoS("Policy conditions do not allow the escalation, denying it", "escalation", esc.Name, "policy", policy.Name, "reason", result.Reason)
+
+		return result, esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation is not allowed by its policy conditions, all granted permissions are reclaimed. Reason is: %s",
+					result.Reason,
+				),
+			),
+		), true
+	}
+
+	return result, statusZero, false
+}
+
 func (c *Controller) readPolicy(ctx context.Context, esc *kudov1alpha1.Escalation) (*kudov1alpha1.EscalationPolicy, kudov1alpha1.EscalationStatus, bool, error) {
 	// Does the referenced policy exists?
-	policy, err := c.policiesGetter.Get(esc.Spec.PolicyName)
+	policy, err := c.policyResolver.Resolve(&esc.Spec)
 	switch {
 	case errors.IsNotFound(err):
 		return nil,
@@ -372,6 +953,19 @@ func (c *Controller) readPolicy(ctx context.Context, esc *kudov1alpha1.Escalatio
 			),
 			true,
 			nil
+	case isUnusablePolicy(err):
+		return nil,
+			esc.Status.TransitionTo(
+				kudov1alpha1.StateDenied,
+				kudov1alpha1.WithDetails(
+					fmt.Sprintf(
+						"This escalation references a policy that can't be used anymore, all granted permissions are reclaimed. Reason is: %s",
+						err,
+					),
+				),
+			),
+			true,
+			nil
 	case err != nil:
 		return nil, statusZero, false, err
 	default:
@@ -383,7 +977,13 @@ func (c *Controller) updateStatus(ctx context.Context, escalation *kudov1alpha1.
 	clonedEscalation := escalation.DeepCopy()
 	clonedEscalation.Status = status
 
-	newEsc, err := c.escalationStatusUpdater.UpdateStatus(ctx, clonedEscalation, metav1.UpdateOptions{})
+	if status.IsTerminal() && status.FinishedAt.IsZero() {
+		clonedEscalation.Status.FinishedAt = metav1.Time{Time: c.nowFunc()}
+	}
+
+	clonedEscalation.Status.MarkObserved(escalation.Generation, c.nowFunc())
+
+	newEsc, err := c.escalationsClient.UpdateStatus(ctx, clonedEscalation, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -391,6 +991,10 @@ func (c *Controller) updateStatus(ctx context.Context, escalation *kudov1alpha1.
 	// When there's a state update, record it.
 	if newEsc.ResourceVersion != escalation.ResourceVersion {
 		c.auditSink.RecordUpdate(ctx, escalation, newEsc)
+
+		for i := escalation.Status.AppliedExtensions; i < newEsc.Status.AppliedExtensions && i < len(newEsc.Spec.Extensions); i++ {
+			c.auditSink.RecordExtend(ctx, newEsc, newEsc.Spec.Extensions[i])
+		}
 	}
 
 	return newEsc, nil
@@ -399,7 +1003,9 @@ func (c *Controller) updateStatus(ctx context.Context, escalation *kudov1alpha1.
 func (c *Controller) nextEventInsight(esc *kudov1alpha1.Escalation) EventInsight {
 	switch esc.Status.State {
 	case kudov1alpha1.StateAccepted:
-		if !esc.Status.AllGrantsInStatus(kudov1alpha1.GrantStatusCreated) {
+		now := c.nowFunc()
+
+		if !allGrantsApplied(esc, now) {
 			return EventInsight{
 				ResyncAfter: c.retryInterval,
 				Object:      esc,
@@ -408,26 +1014,63 @@ func (c *Controller) nextEventInsight(esc *kudov1alpha1.Escalation) EventInsight
 
 		var (
 			resyncDelay       = c.resyncInterval
-			delayToExpiration = esc.Status.ExpiresAt.Sub(c.nowFunc())
+			delayToExpiration = esc.Status.ExpiresAt.Sub(now)
 		)
 
 		if delayToExpiration < resyncDelay {
 			resyncDelay = delayToExpiration
 		}
 
+		// Some grants might expire before the escalation, make sure to be there when they do.
+		for _, ref := range esc.Status.GrantRefs {
+			if ref.Status != kudov1alpha1.GrantStatusCreated || ref.ExpiresAt.IsZero() {
+				continue
+			}
+
+			if delayToGrantExpiration := ref.ExpiresAt.Sub(now); delayToGrantExpiration < resyncDelay {
+				resyncDelay = delayToGrantExpiration
+			}
+		}
+
 		return EventInsight{
 			ResyncAfter: resyncDelay,
 			Object:      esc,
 		}
-	case kudov1alpha1.StateDenied, kudov1alpha1.StateExpired:
-		if !esc.Status.AllGrantsInStatus(kudov1alpha1.GrantStatusReclaimed) {
+	case kudov1alpha1.StateScheduled:
+		var (
+			resyncDelay  = c.resyncInterval
+			delayToStart = esc.Spec.StartAt.Sub(c.nowFunc())
+		)
+
+		if delayToStart < resyncDelay {
+			resyncDelay = delayToStart
+		}
+
+		// The start might have been reached while processing, make sure the escalation is picked up right away.
+		if resyncDelay <= 0 {
+			resyncDelay = time.Millisecond
+		}
+
+		return EventInsight{
+			ResyncAfter: resyncDelay,
+			Object:      esc,
+		}
+	case kudov1alpha1.StateDenied, kudov1alpha1.StateExpired, kudov1alpha1.StateReleased, kudov1alpha1.StateRevoked:
+		if !allGrantsReclaimed(esc) {
 			return EventInsight{
 				ResyncAfter: c.retryInterval,
 				Object:      esc,
 			}
 		}
 
-		klog.InfoS("Not resyncing because denied / expired and all reclaimed", "escalation", esc.Name)
+		if c.retention > 0 {
+			return EventInsight{
+				ResyncAfter: c.retentionEnd(esc).Sub(c.nowFunc()),
+				Object:      esc,
+			}
+		}
+
+		klog.InfoS("Not resyncing because denied / expired / released / revoked and all reclaimed", "escalation", esc.Name)
 		return EventInsight{}
 	default:
 		klog.InfoS("Not resyncing because in unreschedulable state", "escalation", esc.Name, "state", esc.Status.State)
@@ -435,7 +1078,158 @@ func (c *Controller) nextEventInsight(esc *kudov1alpha1.Escalation) EventInsight
 	}
 }
 
-func hasPolicyChanged(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) bool {
-	return policy.UID != esc.Status.PolicyUID ||
-		policy.ResourceVersion != esc.Status.PolicyVersion
+// isRetentionOver returns true if the escalation is finished for longer than the retention, and can be deleted.
+func (c *Controller) isRetentionOver(esc *kudov1alpha1.Escalation) bool {
+	if c.retention == 0 || !esc.Status.IsTerminal() || esc.Status.FinishedAt.IsZero() || !allGrantsReclaimed(esc) {
+		return false
+	}
+
+	return !c.nowFunc().Before(c.retentionEnd(esc))
+}
+
+func (c *Controller) retentionEnd(esc *kudov1alpha1.Escalation) time.Time {
+	return esc.Status.FinishedAt.Add(c.retention)
+}
+
+// cleanUp archives a finished escalation, marks it as archived, then deletes it. It is retried later if any of those fails.
+// An escalation already marked as archived isn't handed again to the archiver.
+func (c *Controller) cleanUp(ctx context.Context, esc *kudov1alpha1.Escalation) EventInsight {
+	retry := EventInsight{
+		ResyncAfter: c.retryInterval,
+		Object:      esc,
+	}
+
+	if !isArchived(esc) {
+		if c.archiver != nil {
+			if err := c.archiver.Archive(ctx, esc); err != nil {
+				klog.ErrorS(err, "Unable to archive escalation, will retry", "escalation", esc.Name)
+				return retry
+			}
+		}
+
+		archivedEsc := esc.DeepCopy()
+		archivedEsc.Status.ArchivedAt = metav1.Time{Time: c.nowFunc()}
+
+		var err error
+
+		esc, err = c.escalationsClient.UpdateStatus(ctx, archivedEsc, metav1.UpdateOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			return EventInsight{}
+		case err != nil:
+			klog.ErrorS(err, "Unable to mark escalation as archived, will retry", "escalation", archivedEsc.Name)
+			return retry
+		}
+	}
+
+	err := c.escalationsClient.Delete(
+		ctx,
+		esc.Name,
+		metav1.DeleteOptions{
+			// The escalation is deleted as it has been marked, so its deletion is recognized as a clean up.
+			Preconditions: &metav1.Preconditions{UID: &esc.UID, ResourceVersion: &esc.ResourceVersion},
+		},
+	)
+	switch {
+	case errors.IsNotFound(err):
+		return EventInsight{}
+	case err != nil:
+		klog.ErrorS(err, "Unable to delete escalation, will retry", "escalation", esc.Name)
+		return retry
+	}
+
+	klog.InfoS("Escalation retention is over, deleted it", "escalation", esc.Name)
+
+	return EventInsight{}
+}
+
+// isArchived returns true if the escalation has been archived by the controller, and has nothing left to reclaim.
+func isArchived(esc *kudov1alpha1.Escalation) bool {
+	return !esc.Status.ArchivedAt.IsZero() && allGrantsReclaimed(esc)
+}
+
+// allGrantsReclaimed returns true if none of the escalation grants is active anymore.
+func allGrantsReclaimed(esc *kudov1alpha1.Escalation) bool {
+	for _, ref := range esc.Status.GrantRefs {
+		if ref.Status != kudov1alpha1.GrantStatusReclaimed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allGrantsApplied returns true if all the grants of an accepted escalation are created,
+// except the ones that have reached their own expiry time, which must be reclaimed.
+func allGrantsApplied(esc *kudov1alpha1.Escalation, now time.Time) bool {
+	if len(esc.Status.GrantRefs) == 0 {
+		return false
+	}
+
+	for _, ref := range esc.Status.GrantRefs {
+		wantStatus := kudov1alpha1.GrantStatusCreated
+		if ref.IsExpired(now) {
+			wantStatus = kudov1alpha1.GrantStatusReclaimed
+		}
+
+		if ref.Status != wantStatus {
+			return false
+		}
+	}
+
+	return true
+}
+
+// grantExpiresAt returns the time at which a grant expires, if it expires before the escalation.
+// It returns a zero time otherwise.
+func grantExpiresAt(esc *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (time.Time, error) {
+	commonSpec, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.GrantCommonSpec](grant)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if commonSpec.Duration.Duration == 0 || esc.Status.AcceptedAt.IsZero() {
+		return time.Time{}, nil
+	}
+
+	expiresAt := esc.Status.AcceptedAt.Add(commonSpec.Duration.Duration)
+	if !expiresAt.Before(esc.Status.ExpiresAt.Time) {
+		return time.Time{}, nil
+	}
+
+	return expiresAt, nil
+}
+
+// IsRevoked returns true if an administrator has revoked the escalation.
+// Events about revoked escalations are processed before the others, to reclaim their grants as soon as possible.
+func IsRevoked(esc *kudov1alpha1.Escalation) bool {
+	return esc.Spec.Revocation != nil
+}
+
+// isReleasable returns true if an escalation in the given state can be released.
+func isReleasable(state kudov1alpha1.EscalationState) bool {
+	return state == kudov1alpha1.StatePending ||
+		state == kudov1alpha1.StateScheduled ||
+		state == kudov1alpha1.StateAccepted
+}
+
+// grantTarget returns the target the escalation grants are created from, along with the selected grants.
+// Escalations created before policy snapshots were recorded fall back on the current policy.
+func grantTarget(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationTarget, []kudov1alpha1.ValueWithKind, error) {
+	if snapshot := esc.Status.PolicySnapshot; snapshot != nil {
+		return snapshot.Target, escalationGrants(esc), nil
+	}
+
+	grants, err := policy.Spec.Target.SelectGrants(esc.Spec.Grants)
+
+	return policy.Spec.Target, grants, err
+}
+
+// escalationDuration returns how long the escalation lasts once started.
+func escalationDuration(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) time.Duration {
+	if esc.Spec.Duration.Duration == 0 {
+		return policy.Spec.Target.DefaultDuration.Duration
+	}
+
+	return esc.Spec.Duration.Duration
 }
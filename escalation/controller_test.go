@@ -2,6 +2,7 @@ package escalation_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sort"
 	"testing"
@@ -9,9 +10,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/jlevesy/kudo/audit"
@@ -19,7 +23,6 @@ import (
 	"github.com/jlevesy/kudo/grant"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"github.com/jlevesy/kudo/pkg/controllersupport"
-	kudoclientset "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
 	kudofake "github.com/jlevesy/kudo/pkg/generated/clientset/versioned/fake"
 	kudoinformers "github.com/jlevesy/kudo/pkg/generated/informers/externalversions"
 )
@@ -27,21 +30,28 @@ import (
 const testGrantKind = "TestGrantKind"
 
 var (
+	testAllowedClusterRoles = []string{"view"}
+
+	// testPolicyVersion is the version escalations record, the policy generation.
+	testPolicyVersion = "43333"
+
 	expiredCreationTimestamp = metav1.Time{
 		Time: time.Date(2020, time.October, 3, 10, 20, 30, 0, time.UTC),
 	}
 
 	testPolicy = kudov1alpha1.EscalationPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            "test-policy",
-			UID:             "eeee-eeee-eee",
-			ResourceVersion: "43333",
+			Name:       "test-policy",
+			UID:        "eeee-eeee-eee",
+			Generation: 43333,
 		},
 		Spec: kudov1alpha1.EscalationPolicySpec{
-			Subjects: []rbacv1.Subject{
+			Subjects: []kudov1alpha1.PolicySubject{
 				{
-					Kind: rbacv1.UserKind,
-					Name: "jean-testeur",
+					Subject: rbacv1.Subject{
+						Kind: rbacv1.UserKind,
+						Name: "jean-testeur",
+					},
 				},
 			},
 			Challenges: []kudov1alpha1.EscalationChallenge{},
@@ -104,6 +114,7 @@ func TestEscalationController_OnCreate(t *testing.T) {
 			},
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
 				StateDetails: escalation.DeniedPolicyNotFoundStateDetails,
 			},
 		},
@@ -121,6 +132,7 @@ func TestEscalationController_OnCreate(t *testing.T) {
 			},
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
 				StateDetails: escalation.DeniedBadEscalationSpecDetails,
 			},
 		},
@@ -141,10 +153,11 @@ func TestEscalationController_OnCreate(t *testing.T) {
 				},
 			},
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
-				State:         kudov1alpha1.StatePending,
-				StateDetails:  escalation.PendingStateDetails,
-				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				State:          kudov1alpha1.StatePending,
+				StateDetails:   escalation.PendingStateDetails,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion,
+				PolicySnapshot: &testPolicy.Spec,
 			},
 		},
 	}
@@ -172,7 +185,7 @@ func TestEscalationController_OnCreate(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			assert.Equal(t, testCase.wantEscalationStatus, gotEscalation.Status)
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
 		})
 	}
 }
@@ -217,12 +230,13 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
 				StateDetails: escalation.DeniedPolicyNotFoundStateDetails,
 				GrantRefs:    []kudov1alpha1.EscalationGrantRef{{}},
 			},
 		},
 		{
-			desc:     "on pending state, transitions to denied if policy has changed since creation",
+			desc:     "on pending state, transitions to denied if policy has changed since creation and might not allow the requestor anymore",
 			kudoSeed: []runtime.Object{&testPolicy},
 			updatedEscalation: kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
@@ -244,7 +258,8 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:         kudov1alpha1.StateDenied,
-				StateDetails:  escalation.DeniedPolicyChangedStateDetails,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the policy subjects have changed, and the requestor might not be allowed to use it anymore",
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				PolicyUID:     testPolicy.UID,
 				PolicyVersion: "3030303",
@@ -267,16 +282,17 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StatePending,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     "aaa-aaa-aaa",
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 				},
 			},
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
 				StateDetails:  escalation.DeniedPolicyChangedStateDetails,
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				PolicyUID:     "aaa-aaa-aaa",
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 			},
 		},
 		{
@@ -296,7 +312,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StatePending,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 				},
 			},
 			wantNextResync: retryDelay,
@@ -305,7 +321,8 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				StateDetails:  escalation.AcceptedInProgressStateDetails,
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    metav1.Time{Time: now},
 				ExpiresAt: metav1.Time{
 					Time: now.Add(
 						testPolicy.Spec.Target.DefaultDuration.Duration,
@@ -331,7 +348,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StatePending,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 				},
 			},
 			wantNextResync: retryDelay,
@@ -340,12 +357,152 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				StateDetails:  escalation.AcceptedInProgressStateDetails,
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    metav1.Time{Time: now},
 				ExpiresAt: metav1.Time{
 					Time: now.Add(2 * time.Second),
 				},
 			},
 		},
+		{
+			desc:     "on pending state, transitions to scheduled if the escalation starts later",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					StartAt:    &metav1.Time{Time: now.Add(5 * time.Second)},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StatePending,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+				},
+			},
+			wantNextResync: 5 * time.Second,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateScheduled,
+				StateDetails:  "This escalation has been accepted, permissions are going to be granted at 2022-10-10T01:30:06Z",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     metav1.Time{Time: now.Add(time.Hour + 5*time.Second)},
+			},
+		},
+		{
+			desc:     "on pending state, computes the expiry from the start if already started",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					StartAt:    &metav1.Time{Time: now.Add(-time.Minute)},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StatePending,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+				},
+			},
+			wantNextResync: retryDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedInProgressStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    metav1.Time{Time: now.Add(-time.Minute)},
+				ExpiresAt:     metav1.Time{Time: now.Add(59 * time.Minute)},
+			},
+		},
+		{
+			desc:     "on scheduled state, waits for the start of the escalation",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					StartAt:    &metav1.Time{Time: now.Add(2 * time.Hour)},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateScheduled,
+					StateDetails:  "scheduled",
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt:     metav1.Time{Time: now.Add(3 * time.Hour)},
+				},
+			},
+			wantNextResync: resyncDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateScheduled,
+				StateDetails:  "scheduled",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     metav1.Time{Time: now.Add(3 * time.Hour)},
+			},
+		},
+		{
+			desc:     "on scheduled state, transitions to accepted once started",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Duration:   metav1.Duration{Duration: 2 * time.Hour},
+					StartAt:    &metav1.Time{Time: now.Add(-time.Second)},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateScheduled,
+					StateDetails:  "scheduled",
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt:     metav1.Time{Time: now.Add(2*time.Hour - time.Second)},
+				},
+			},
+			wantNextResync: retryDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedInProgressStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    metav1.Time{Time: now.Add(-time.Second)},
+				ExpiresAt:     metav1.Time{Time: now.Add(2*time.Hour - time.Second)},
+			},
+		},
+		{
+			desc:     "on scheduled state, transitions to denied if policy has changed and might not allow the requestor anymore",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					StartAt:    &metav1.Time{Time: now.Add(time.Hour)},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateScheduled,
+					StateDetails:  "scheduled",
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: "3030303",
+					ExpiresAt:     metav1.Time{Time: now.Add(2 * time.Hour)},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the policy subjects have changed, and the requestor might not be allowed to use it anymore",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: "3030303",
+				ExpiresAt:     metav1.Time{Time: now.Add(2 * time.Hour)},
+			},
+		},
 		{
 			desc:     "on accepted state, transitions to denied if referenced policy doesn't exists",
 			kudoSeed: []runtime.Object{&testPolicy},
@@ -370,6 +527,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
 				StateDetails: escalation.DeniedPolicyNotFoundStateDetails,
 				GrantRefs:    []kudov1alpha1.EscalationGrantRef{{}},
 				ExpiresAt: metav1.Time{
@@ -399,6 +557,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:        kudov1alpha1.StateExpired,
+				FinishedAt:   metav1.Time{Time: now},
 				StateDetails: escalation.ExpiredStateDetails,
 				GrantRefs:    []kudov1alpha1.EscalationGrantRef{{}},
 				ExpiresAt: metav1.Time{
@@ -407,7 +566,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			},
 		},
 		{
-			desc:     "on accepted state, transition to denied if policy has changed",
+			desc:     "on accepted state, transition to denied if policy has changed and might not allow the requestor anymore",
 			kudoSeed: []runtime.Object{&testPolicy},
 			updatedEscalation: kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
@@ -423,7 +582,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StateAccepted,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion + "4444",
+					PolicyVersion: testPolicyVersion + "4444",
 					ExpiresAt: metav1.Time{
 						Time: now.Add(50 * time.Second),
 					},
@@ -432,9 +591,10 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:         kudov1alpha1.StateDenied,
-				StateDetails:  escalation.DeniedPolicyChangedStateDetails,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the policy subjects have changed, and the requestor might not be allowed to use it anymore",
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion + "4444",
+				PolicyVersion: testPolicyVersion + "4444",
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				ExpiresAt: metav1.Time{
 					Time: now.Add(50 * time.Second),
@@ -458,7 +618,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StateAccepted,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID + "33333",
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 					ExpiresAt: metav1.Time{
 						Time: now.Add(50 * time.Second),
 					},
@@ -467,9 +627,10 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
 				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
 				StateDetails:  escalation.DeniedPolicyChangedStateDetails,
 				PolicyUID:     testPolicy.UID + "33333",
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 				GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 				ExpiresAt: metav1.Time{
 					Time: now.Add(50 * time.Second),
@@ -493,7 +654,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StateAccepted,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 					ExpiresAt: metav1.Time{
 						Time: now.Add(50 * time.Second),
 					},
@@ -504,7 +665,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				State:         kudov1alpha1.StateAccepted,
 				StateDetails:  escalation.AcceptedAppliedStateDetails,
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 				ExpiresAt: metav1.Time{
 					Time: now.Add(50 * time.Second),
 				},
@@ -530,6 +691,85 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "on accepted state, provisions only the selected grants",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Grants:     []string{"1"},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateAccepted,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt: metav1.Time{
+						Time: now.Add(50 * time.Second),
+					},
+				},
+			},
+			wantNextResync: resyncDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedAppliedStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt: metav1.Time{
+					Time: now.Add(50 * time.Second),
+				},
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-2",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:     "on accepted state, denies the escalation if the selected grants are not part of the policy",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Grants:     []string{"admin"},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateAccepted,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt: metav1.Time{
+						Time: now.Add(50 * time.Second),
+					},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  `Escalation has been denied, reason is: unknown grant "admin"`,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt: metav1.Time{
+					Time: now.Add(50 * time.Second),
+				},
+			},
+		},
 		{
 			desc:     "on accepted state, schedules next retry to expration date",
 			kudoSeed: []runtime.Object{&testPolicy},
@@ -547,7 +787,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StateAccepted,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 					ExpiresAt: metav1.Time{
 						Time: now.Add(10 * time.Second),
 					},
@@ -558,7 +798,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				State:         kudov1alpha1.StateAccepted,
 				StateDetails:  escalation.AcceptedAppliedStateDetails,
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 				ExpiresAt: metav1.Time{
 					Time: now.Add(10 * time.Second),
 				},
@@ -602,7 +842,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					State:         kudov1alpha1.StateAccepted,
 					GrantRefs:     []kudov1alpha1.EscalationGrantRef{{}},
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 					ExpiresAt: metav1.Time{
 						Time: now.Add(50 * time.Second),
 					},
@@ -613,7 +853,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				State:         kudov1alpha1.StateAccepted,
 				StateDetails:  "Escalation is partially active, reason is: not today!",
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 				ExpiresAt: metav1.Time{
 					Time: now.Add(50 * time.Second),
 				},
@@ -659,7 +899,7 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 					},
 					State:         kudov1alpha1.StateAccepted,
 					PolicyUID:     testPolicy.UID,
-					PolicyVersion: testPolicy.ResourceVersion,
+					PolicyVersion: testPolicyVersion,
 					GrantRefs: []kudov1alpha1.EscalationGrantRef{
 						{
 							Status: kudov1alpha1.GrantStatusCreated,
@@ -680,8 +920,9 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 				},
 				State:         kudov1alpha1.StateDenied,
 				StateDetails:  "Escalation has been denied, reason is: kudo managed resource has been tampered with",
+				FinishedAt:    metav1.Time{Time: now},
 				PolicyUID:     testPolicy.UID,
-				PolicyVersion: testPolicy.ResourceVersion,
+				PolicyVersion: testPolicyVersion,
 				GrantRefs: []kudov1alpha1.EscalationGrantRef{
 					{
 						Status: kudov1alpha1.GrantStatusCreated,
@@ -696,23 +937,24 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 			},
 		},
 		{
-			desc:     "on expired state, reclaims all the known grants",
+			desc:     "on accepted state, transitions to released if the requestor has released the escalation",
 			kudoSeed: []runtime.Object{&testPolicy},
 			updatedEscalation: kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-escalation",
-					CreationTimestamp: metav1.Time{
-						Time: now,
-					},
 				},
 				Spec: kudov1alpha1.EscalationSpec{
 					PolicyName: testPolicy.Name,
+					Released:   true,
 				},
 				Status: kudov1alpha1.EscalationStatus{
-					State:        kudov1alpha1.StateExpired,
-					StateDetails: "expiration has expired",
+					State:         kudov1alpha1.StateAccepted,
+					StateDetails:  escalation.AcceptedAppliedStateDetails,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt:     metav1.Time{Time: now.Add(time.Hour)},
 					GrantRefs: []kudov1alpha1.EscalationGrantRef{
-						{
+						kudov1alpha1.EscalationGrantRef{
 							Status: kudov1alpha1.GrantStatusCreated,
 							Ref: kudov1alpha1.MustEncodeValueWithKind(
 								testGrantKind,
@@ -721,24 +963,20 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 								},
 							),
 						},
-						{
-							Status: kudov1alpha1.GrantStatusCreated,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								testGrantKind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: "grant-test-ns-2",
-								},
-							),
-						},
 					},
 				},
 			},
+			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
-				State:        kudov1alpha1.StateExpired,
-				StateDetails: "expiration has expired",
+				State:         kudov1alpha1.StateReleased,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  escalation.ReleasedStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     metav1.Time{Time: now.Add(time.Hour)},
 				GrantRefs: []kudov1alpha1.EscalationGrantRef{
-					{
-						Status: kudov1alpha1.GrantStatusReclaimed,
+					kudov1alpha1.EscalationGrantRef{
+						Status: kudov1alpha1.GrantStatusCreated,
 						Ref: kudov1alpha1.MustEncodeValueWithKind(
 							testGrantKind,
 							kudov1alpha1.K8sRoleBindingGrantRef{
@@ -746,36 +984,25 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 							},
 						),
 					},
-					{
-						Status: kudov1alpha1.GrantStatusReclaimed,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-2",
-							},
-						),
-					},
 				},
 			},
 		},
 		{
-			desc:            "on expired state, marks permission as partially reclaimed if one of the granter fails",
-			kudoSeed:        []runtime.Object{&testPolicy},
-			reclaimGrantErr: errors.New("nonono"),
+			desc:     "on released state, reclaims all the known grants",
+			kudoSeed: []runtime.Object{&testPolicy},
 			updatedEscalation: kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-escalation",
-					CreationTimestamp: metav1.Time{
-						Time: now,
-					},
 				},
 				Spec: kudov1alpha1.EscalationSpec{
 					PolicyName: testPolicy.Name,
+					Released:   true,
 				},
 				Status: kudov1alpha1.EscalationStatus{
-					State: kudov1alpha1.StateExpired,
+					State:        kudov1alpha1.StateReleased,
+					StateDetails: escalation.ReleasedStateDetails,
 					GrantRefs: []kudov1alpha1.EscalationGrantRef{
-						{
+						kudov1alpha1.EscalationGrantRef{
 							Status: kudov1alpha1.GrantStatusCreated,
 							Ref: kudov1alpha1.MustEncodeValueWithKind(
 								testGrantKind,
@@ -784,25 +1011,16 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 								},
 							),
 						},
-						{
-							Status: kudov1alpha1.GrantStatusCreated,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								testGrantKind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: "grant-test-ns-2",
-								},
-							),
-						},
 					},
 				},
 			},
-			wantNextResync: retryDelay,
 			wantEscalationStatus: kudov1alpha1.EscalationStatus{
-				State:        kudov1alpha1.StateExpired,
-				StateDetails: "This escalation has expired, but grants have been partially reclaimed. Reason is: nonono",
+				State:        kudov1alpha1.StateReleased,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: escalation.ReleasedStateDetails,
 				GrantRefs: []kudov1alpha1.EscalationGrantRef{
-					{
-						Status: kudov1alpha1.GrantStatusCreated,
+					kudov1alpha1.EscalationGrantRef{
+						Status: kudov1alpha1.GrantStatusReclaimed,
 						Ref: kudov1alpha1.MustEncodeValueWithKind(
 							testGrantKind,
 							kudov1alpha1.K8sRoleBindingGrantRef{
@@ -810,36 +1028,32 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 							},
 						),
 					},
-					{
-						Status: kudov1alpha1.GrantStatusCreated,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-2",
-							},
-						),
-					},
 				},
 			},
 		},
 		{
-			desc:     "on denied state, reclaims all the known grants",
+			desc:     "on accepted state, transitions to revoked if an administrator has revoked the escalation, even if released",
 			kudoSeed: []runtime.Object{&testPolicy},
 			updatedEscalation: kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-escalation",
-					CreationTimestamp: metav1.Time{
-						Time: now,
-					},
 				},
 				Spec: kudov1alpha1.EscalationSpec{
 					PolicyName: testPolicy.Name,
+					Released:   true,
+					Revocation: &kudov1alpha1.EscalationRevocation{
+						Reason:    "Incident is over",
+						RevokedBy: "admin",
+					},
 				},
 				Status: kudov1alpha1.EscalationStatus{
-					State:        kudov1alpha1.StateDenied,
-					StateDetails: "denied for some reason",
+					State:         kudov1alpha1.StateAccepted,
+					StateDetails:  escalation.AcceptedAppliedStateDetails,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					ExpiresAt:     metav1.Time{Time: now.Add(time.Hour)},
 					GrantRefs: []kudov1alpha1.EscalationGrantRef{
-						{
+						kudov1alpha1.EscalationGrantRef{
 							Status: kudov1alpha1.GrantStatusCreated,
 							Ref: kudov1alpha1.MustEncodeValueWithKind(
 								testGrantKind,
@@ -848,62 +1062,2451 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 								},
 							),
 						},
-						{
-							Status: kudov1alpha1.GrantStatusCreated,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								testGrantKind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: "grant-test-ns-2",
-								},
+					},
+				},
+			},
+			wantNextResync: retryDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateRevoked,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "This escalation has been revoked by admin, all granted permissions are reclaimed. Reason is: Incident is over",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     metav1.Time{Time: now.Add(time.Hour)},
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					kudov1alpha1.EscalationGrantRef{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:     "on revoked state, reclaims all the known grants",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Released:   true,
+					Revocation: &kudov1alpha1.EscalationRevocation{
+						Reason:    "Incident is over",
+						RevokedBy: "admin",
+					},
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:        kudov1alpha1.StateRevoked,
+					StateDetails: "This escalation has been revoked by admin, all granted permissions are reclaimed. Reason is: Incident is over",
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						kudov1alpha1.EscalationGrantRef{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+					},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateRevoked,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "This escalation has been revoked by admin, all granted permissions are reclaimed. Reason is: Incident is over",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					kudov1alpha1.EscalationGrantRef{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:     "on expired state, reclaims all the known grants",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:        kudov1alpha1.StateExpired,
+					StateDetails: "expiration has expired",
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-2",
+								},
+							),
+						},
+					},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateExpired,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "expiration has expired",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-2",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:     "on expired state, marks the grants that were never created as reclaimed",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:        kudov1alpha1.StateExpired,
+					StateDetails: "expiration has expired",
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+						{
+							Ref: kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+						},
+					},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateExpired,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "expiration has expired",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref:    kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:            "on expired state, marks permission as partially reclaimed if one of the granter fails",
+			kudoSeed:        []runtime.Object{&testPolicy},
+			reclaimGrantErr: errors.New("nonono"),
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State: kudov1alpha1.StateExpired,
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-2",
+								},
 							),
 						},
 					},
 				},
 			},
-			wantEscalationStatus: kudov1alpha1.EscalationStatus{
-				State:        kudov1alpha1.StateDenied,
-				StateDetails: "denied for some reason",
-				GrantRefs: []kudov1alpha1.EscalationGrantRef{
-					{
-						Status: kudov1alpha1.GrantStatusReclaimed,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-1",
-							},
-						),
-					},
-					{
-						Status: kudov1alpha1.GrantStatusReclaimed,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-2",
+			wantNextResync: retryDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateExpired,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "This escalation has expired, but grants have been partially reclaimed. Reason is: nonono",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+					{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-2",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:     "on denied state, reclaims all the known grants",
+			kudoSeed: []runtime.Object{&testPolicy},
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:        kudov1alpha1.StateDenied,
+					StateDetails: "denied for some reason",
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-2",
+								},
+							),
+						},
+					},
+				},
+			},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "denied for some reason",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-2",
+							},
+						),
+					},
+				},
+			},
+		},
+		{
+			desc:            "on denied state, marks permission as partially reclaimed if one of the granter fails",
+			kudoSeed:        []runtime.Object{&testPolicy},
+			reclaimGrantErr: errors.New("nonono"),
+			updatedEscalation: kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+					CreationTimestamp: metav1.Time{
+						Time: now,
+					},
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State: kudov1alpha1.StateDenied,
+					GrantRefs: []kudov1alpha1.EscalationGrantRef{
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-1",
+								},
+							),
+						},
+						{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-test-ns-2",
+								},
+							),
+						},
+					},
+				},
+			},
+			wantNextResync: retryDelay,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:        kudov1alpha1.StateDenied,
+				FinishedAt:   metav1.Time{Time: now},
+				StateDetails: "This escalation is denied, but grants have been partially reclaimed. Reason is: nonono",
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-1",
+							},
+						),
+					},
+					{
+						Status: kudov1alpha1.GrantStatusCreated,
+						Ref: kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrantRef{
+								Name: "grant-test-ns-2",
+							},
+						),
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+						require.NoError(t, err)
+
+						return kudov1alpha1.EscalationGrantRef{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								testGrantKind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: "grant-" + k8sGrant.DefaultNamespace,
+								},
+							),
+						}, testCase.upsertGrantErr
+					},
+					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrantRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](ref.Ref)
+						require.NoError(t, err)
+
+						return kudov1alpha1.EscalationGrantRef{
+							Status: kudov1alpha1.GrantStatusReclaimed,
+							Ref: kudov1alpha1.MustEncodeValueWithKind(
+								ref.Ref.Kind,
+								kudov1alpha1.K8sRoleBindingGrantRef{
+									Name: k8sGrantRef.Name,
+								},
+							),
+						}, testCase.reclaimGrantErr
+					},
+				}
+
+				controller, k8s, done = buildController(
+					t,
+					grant.StaticFactory{
+						testGrantKind: injectMockGranter(&dummyGranter),
+					},
+					append(
+						testCase.kudoSeed,
+						&testCase.updatedEscalation,
+					),
+				)
+			)
+
+			defer done()
+
+			gotInsight, err := controller.OnUpdate(ctx, nil, &testCase.updatedEscalation)
+			if testCase.wantError != nil {
+				require.Equal(t, testCase.wantError, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantNextResync, gotInsight.ResyncAfter)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				testCase.updatedEscalation.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			sort.Slice(gotEscalation.Status.GrantRefs, func(i, j int) bool {
+				vi, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](gotEscalation.Status.GrantRefs[i].Ref)
+				require.NoError(t, err)
+				vj, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](gotEscalation.Status.GrantRefs[j].Ref)
+				require.NoError(t, err)
+
+				return vi.Name < vj.Name
+			})
+
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_GrantApplication(t *testing.T) {
+	var (
+		errFailingGrant = errors.New("boom")
+		errReclaim      = errors.New("nope")
+
+		acceptedEscalation = kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt: metav1.Time{
+					Time: now.Add(50 * time.Second),
+				},
+			},
+		}
+
+		grantRef = func(name string, status kudov1alpha1.GrantStatus) kudov1alpha1.EscalationGrantRef {
+			return kudov1alpha1.EscalationGrantRef{
+				Status: status,
+				Ref: kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrantRef{
+						Name: name,
+					},
+				),
+			}
+		}
+
+		uncreatedGrantRef = kudov1alpha1.EscalationGrantRef{
+			Ref: kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+		}
+	)
+
+	testCases := []struct {
+		desc string
+
+		target          kudov1alpha1.EscalationTarget
+		grantRefs       []kudov1alpha1.EscalationGrantRef
+		failingGrantNs  string
+		reclaimGrantErr error
+
+		wantCreatedOrder     []string
+		wantEscalationStatus kudov1alpha1.EscalationStatus
+	}{
+		{
+			desc:           "keeps created grants in place and retries when not atomic",
+			failingGrantNs: "test-ns-2",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation is partially active, reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+					uncreatedGrantRef,
+				},
+			},
+		},
+		{
+			desc: "keeps the ref of a grant created by a previous attempt if it fails to be created again",
+			grantRefs: []kudov1alpha1.EscalationGrantRef{
+				grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated),
+			},
+			failingGrantNs: "test-ns-2",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation is partially active, reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+					grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated),
+				},
+			},
+		},
+		{
+			desc: "records the grants not created after the failing one when ordered",
+			target: kudov1alpha1.EscalationTarget{
+				OrderedGrants: true,
+			},
+			failingGrantNs:   "test-ns-1",
+			wantCreatedOrder: []string{"test-ns-1"},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation is partially active, reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					uncreatedGrantRef,
+					uncreatedGrantRef,
+				},
+			},
+		},
+		{
+			desc: "reclaims created grants and retries when atomic and failure policy is retry",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyRetry,
+			},
+			failingGrantNs: "test-ns-2",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation could not be fully granted, all grants are reclaimed and will be created again. Reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusReclaimed),
+					uncreatedGrantRef,
+				},
+			},
+		},
+		{
+			desc: "reclaims created grants and retries when atomic and failure policy is not set",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants: true,
+			},
+			failingGrantNs: "test-ns-1",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation could not be fully granted, all grants are reclaimed and will be created again. Reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					uncreatedGrantRef,
+					grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusReclaimed),
+				},
+			},
+		},
+		{
+			desc: "keeps track of created grants and retries if reclaiming them fails when atomic and failure policy is retry",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyRetry,
+			},
+			failingGrantNs:  "test-ns-2",
+			reclaimGrantErr: errReclaim,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  "Escalation could not be fully granted, and some grants could not be reclaimed, retrying. Reason is: boom, reclaim error is: nope",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+					uncreatedGrantRef,
+				},
+			},
+		},
+		{
+			desc: "reclaims created grants and denies when atomic and failure policy is deny",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyDeny,
+			},
+			failingGrantNs: "test-ns-2",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "Escalation has been denied because it could not be fully granted, all grants are reclaimed. Reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusReclaimed),
+				},
+			},
+		},
+		{
+			desc: "reclaims the grants created after the failing one when atomic and failure policy is deny",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyDeny,
+			},
+			failingGrantNs: "test-ns-1",
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "Escalation has been denied because it could not be fully granted, all grants are reclaimed. Reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusReclaimed),
+				},
+			},
+		},
+		{
+			desc: "keeps track of created grants if compensation fails",
+			target: kudov1alpha1.EscalationTarget{
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyDeny,
+			},
+			failingGrantNs:  "test-ns-2",
+			reclaimGrantErr: errReclaim,
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "Escalation could not be fully granted, and some grants could not be reclaimed. Reason is: boom, reclaim error is: nope",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+				},
+			},
+		},
+		{
+			desc: "creates grants in order",
+			target: kudov1alpha1.EscalationTarget{
+				OrderedGrants: true,
+			},
+			wantCreatedOrder: []string{"test-ns-1", "test-ns-2"},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedAppliedStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated),
+					grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated),
+				},
+			},
+		},
+		{
+			desc: "stops at the first failure when ordered",
+			target: kudov1alpha1.EscalationTarget{
+				OrderedGrants:      true,
+				AtomicGrants:       true,
+				GrantFailurePolicy: kudov1alpha1.GrantFailurePolicyDeny,
+			},
+			failingGrantNs:   "test-ns-1",
+			wantCreatedOrder: []string{"test-ns-1"},
+			wantEscalationStatus: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateDenied,
+				FinishedAt:    metav1.Time{Time: now},
+				StateDetails:  "Escalation has been denied because it could not be fully granted, all grants are reclaimed. Reason is: boom",
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				ExpiresAt:     acceptedEscalation.Status.ExpiresAt,
+				GrantRefs:     []kudov1alpha1.EscalationGrantRef{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				policy       = testPolicy.DeepCopy()
+				esc          = acceptedEscalation.DeepCopy()
+				createdOrder []string
+				dummyGranter = mockGranter{
+					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+						require.NoError(t, err)
+
+						createdOrder = append(createdOrder, k8sGrant.DefaultNamespace)
+
+						if k8sGrant.DefaultNamespace == testCase.failingGrantNs {
+							return kudov1alpha1.EscalationGrantRef{}, errFailingGrant
+						}
+
+						return grantRef("grant-"+k8sGrant.DefaultNamespace, kudov1alpha1.GrantStatusCreated), nil
+					},
+					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrantRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](ref.Ref)
+						require.NoError(t, err)
+
+						return grantRef(k8sGrantRef.Name, kudov1alpha1.GrantStatusReclaimed), testCase.reclaimGrantErr
+					},
+				}
+			)
+
+			esc.Status.GrantRefs = testCase.grantRefs
+			testCase.target.DefaultDuration = policy.Spec.Target.DefaultDuration
+			testCase.target.Grants = policy.Spec.Target.Grants
+			policy.Spec.Target = testCase.target
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{policy, esc},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				esc.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			if testCase.wantCreatedOrder != nil {
+				assert.Equal(t, testCase.wantCreatedOrder, createdOrder)
+			}
+
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
+
+			// Refs without a payload can't be stored by the API server.
+			for _, ref := range gotEscalation.Status.GrantRefs {
+				assert.NotEmpty(t, ref.Ref.Kind)
+
+				_, err := json.Marshal(ref)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_GrantDurations(t *testing.T) {
+	var (
+		grantRef = func(name string, status kudov1alpha1.GrantStatus, expiresAt time.Time) kudov1alpha1.EscalationGrantRef {
+			return kudov1alpha1.EscalationGrantRef{
+				Status: status,
+				Ref: kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrantRef{
+						Name: name,
+					},
+				),
+				ExpiresAt: metav1.Time{Time: expiresAt},
+			}
+		}
+
+		policyWithGrantDuration = func(d time.Duration) *kudov1alpha1.EscalationPolicy {
+			policy := testPolicy.DeepCopy()
+			policy.Spec.Target.Grants = []kudov1alpha1.ValueWithKind{
+				kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrant{
+						GrantCommonSpec: kudov1alpha1.GrantCommonSpec{
+							Duration: metav1.Duration{Duration: d},
+						},
+						DefaultNamespace: "test-ns-1",
+					},
+				),
+				kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: "test-ns-2",
+					},
+				),
+			}
+
+			return policy
+		}
+
+		acceptedEscalation = func(acceptedAt time.Time, grantRefs ...kudov1alpha1.EscalationGrantRef) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StateAccepted,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+					AcceptedAt:    metav1.Time{Time: acceptedAt},
+					ExpiresAt:     metav1.Time{Time: acceptedAt.Add(time.Hour)},
+					GrantRefs:     grantRefs,
+				},
+			}
+		}
+	)
+
+	testCases := []struct {
+		desc string
+
+		policy     *kudov1alpha1.EscalationPolicy
+		escalation *kudov1alpha1.Escalation
+
+		wantNextResync time.Duration
+		wantGrantRefs  []kudov1alpha1.EscalationGrantRef
+	}{
+		{
+			desc:           "tracks grant expiry and schedules the next resync at the earliest grant expiry",
+			policy:         policyWithGrantDuration(5*time.Minute + 10*time.Second),
+			escalation:     acceptedEscalation(now.Add(-5 * time.Minute)),
+			wantNextResync: 10 * time.Second,
+			wantGrantRefs: []kudov1alpha1.EscalationGrantRef{
+				grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated, now.Add(10*time.Second)),
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated, time.Time{}),
+			},
+		},
+		{
+			desc:           "does not track grant expiry if grant lasts longer than the escalation",
+			policy:         policyWithGrantDuration(2 * time.Hour),
+			escalation:     acceptedEscalation(now.Add(-5 * time.Minute)),
+			wantNextResync: resyncDelay,
+			wantGrantRefs: []kudov1alpha1.EscalationGrantRef{
+				grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated, time.Time{}),
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated, time.Time{}),
+			},
+		},
+		{
+			desc:   "reclaims a grant that has expired",
+			policy: policyWithGrantDuration(10 * time.Minute),
+			escalation: acceptedEscalation(
+				now.Add(-20*time.Minute),
+				grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusCreated, now.Add(-10*time.Minute)),
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated, time.Time{}),
+			),
+			wantNextResync: resyncDelay,
+			wantGrantRefs: []kudov1alpha1.EscalationGrantRef{
+				grantRef("grant-test-ns-1", kudov1alpha1.GrantStatusReclaimed, now.Add(-10*time.Minute)),
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated, time.Time{}),
+			},
+		},
+		{
+			desc:           "does not create a grant that has expired before being created",
+			policy:         policyWithGrantDuration(10 * time.Minute),
+			escalation:     acceptedEscalation(now.Add(-20 * time.Minute)),
+			wantNextResync: resyncDelay,
+			wantGrantRefs: []kudov1alpha1.EscalationGrantRef{
+				{
+					Status:    kudov1alpha1.GrantStatusReclaimed,
+					Ref:       kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					ExpiresAt: metav1.Time{Time: now.Add(-10 * time.Minute)},
+				},
+				grantRef("grant-test-ns-2", kudov1alpha1.GrantStatusCreated, time.Time{}),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+						require.NoError(t, err)
+
+						return grantRef("grant-"+k8sGrant.DefaultNamespace, kudov1alpha1.GrantStatusCreated, time.Time{}), nil
+					},
+					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrantRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](ref.Ref)
+						require.NoError(t, err)
+
+						return grantRef(k8sGrantRef.Name, kudov1alpha1.GrantStatusReclaimed, time.Time{}), nil
+					},
+				}
+			)
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{testCase.policy, testCase.escalation},
+			)
+
+			defer done()
+
+			gotInsight, err := controller.OnUpdate(ctx, nil, testCase.escalation)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantNextResync, gotInsight.ResyncAfter)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				testCase.escalation.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, kudov1alpha1.StateAccepted, gotEscalation.Status.State)
+			assert.Equal(t, testCase.wantGrantRefs, gotEscalation.Status.GrantRefs)
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_Extensions(t *testing.T) {
+	var (
+		acceptedAt = now.Add(-30 * time.Minute)
+
+		extensionPolicy = func(requireApproval bool) *kudov1alpha1.EscalationPolicy {
+			policy := testPolicy.DeepCopy()
+			policy.Spec.Target.MaxDuration = metav1.Duration{Duration: 2 * time.Hour}
+			policy.Spec.Target.ExtensionsRequireApproval = requireApproval
+
+			return policy
+		}
+
+		extension = func(d time.Duration, approvedBy string) kudov1alpha1.EscalationExtension {
+			return kudov1alpha1.EscalationExtension{
+				Duration:   metav1.Duration{Duration: d},
+				Reason:     "Still investigating",
+				Requestor:  "jean-testeur",
+				ApprovedBy: approvedBy,
+			}
+		}
+
+		acceptedEscalation = func(applied int, extensions ...kudov1alpha1.EscalationExtension) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Extensions: extensions,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:             kudov1alpha1.StateAccepted,
+					StateDetails:      escalation.AcceptedAppliedStateDetails,
+					PolicyUID:         testPolicy.UID,
+					PolicyVersion:     testPolicyVersion,
+					AcceptedAt:        metav1.Time{Time: acceptedAt},
+					ExpiresAt:         metav1.Time{Time: acceptedAt.Add(time.Hour)},
+					AppliedExtensions: applied,
+				},
+			}
+		}
+	)
+
+	testCases := []struct {
+		desc string
+
+		policy     *kudov1alpha1.EscalationPolicy
+		escalation *kudov1alpha1.Escalation
+
+		wantExpiresAt         time.Time
+		wantAppliedExtensions int
+		wantStateDetails      string
+	}{
+		{
+			desc:                  "applies pending extensions",
+			policy:                extensionPolicy(false),
+			escalation:            acceptedEscalation(0, extension(10*time.Minute, ""), extension(5*time.Minute, "")),
+			wantExpiresAt:         acceptedAt.Add(time.Hour + 15*time.Minute),
+			wantAppliedExtensions: 2,
+			wantStateDetails:      "This escalation has been extended, it now expires at 2022-10-10T02:15:01Z",
+		},
+		{
+			desc:                  "caps extensions to the policy max duration counted from the acceptance",
+			policy:                extensionPolicy(false),
+			escalation:            acceptedEscalation(0, extension(3*time.Hour, "")),
+			wantExpiresAt:         acceptedAt.Add(2 * time.Hour),
+			wantAppliedExtensions: 1,
+			wantStateDetails:      "This escalation has been extended, it now expires at 2022-10-10T03:00:01Z",
+		},
+		{
+			desc:                  "does not apply extensions twice",
+			policy:                extensionPolicy(false),
+			escalation:            acceptedEscalation(1, extension(10*time.Minute, "")),
+			wantExpiresAt:         acceptedAt.Add(time.Hour),
+			wantAppliedExtensions: 1,
+			wantStateDetails:      escalation.AcceptedAppliedStateDetails,
+		},
+		{
+			desc:                  "waits for extensions to be approved if the policy requires it",
+			policy:                extensionPolicy(true),
+			escalation:            acceptedEscalation(0, extension(10*time.Minute, "reviewer"), extension(5*time.Minute, ""), extension(5*time.Minute, "reviewer")),
+			wantExpiresAt:         acceptedAt.Add(time.Hour + 10*time.Minute),
+			wantAppliedExtensions: 1,
+			wantStateDetails:      "This escalation has been extended, it now expires at 2022-10-10T02:10:01Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						return kudov1alpha1.EscalationGrantRef{
+							Status: kudov1alpha1.GrantStatusCreated,
+							Ref:    grant,
+						}, nil
+					},
+				}
+			)
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{testCase.policy, testCase.escalation},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, testCase.escalation)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				testCase.escalation.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, kudov1alpha1.StateAccepted, gotEscalation.Status.State)
+			assert.Equal(t, testCase.wantStateDetails, gotEscalation.Status.StateDetails)
+			assert.Equal(t, testCase.wantExpiresAt, gotEscalation.Status.ExpiresAt.Time)
+			assert.Equal(t, testCase.wantAppliedExtensions, gotEscalation.Status.AppliedExtensions)
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_PolicyChange(t *testing.T) {
+	const (
+		narrowedNamespace = "narrowed-ns"
+		widenedRoleName   = "woopy-admin"
+	)
+
+	var (
+		changedPolicy = func(mutate func(policy *kudov1alpha1.EscalationPolicy)) *kudov1alpha1.EscalationPolicy {
+			policy := testPolicy.DeepCopy()
+			policy.Generation = 43334
+			mutate(policy)
+			return policy
+		}
+
+		createdRef = func(name string) kudov1alpha1.EscalationGrantRef {
+			return kudov1alpha1.EscalationGrantRef{
+				Status: kudov1alpha1.GrantStatusCreated,
+				Ref: kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrantRef{Name: name},
+				),
+			}
+		}
+
+		redefinedGrant = kudov1alpha1.MustEncodeValueWithKind(
+			testGrantKind,
+			kudov1alpha1.K8sRoleBindingGrant{
+				DefaultNamespace: "test-ns-2",
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRoleBinding",
+					Name:     "woopy-woop",
+				},
+			},
+		)
+
+		acceptedEscalation = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "john-claude",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:          kudov1alpha1.StateAccepted,
+				StateDetails:   escalation.AcceptedAppliedStateDetails,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion,
+				PolicySnapshot: testPolicy.Spec.DeepCopy(),
+				AcceptedAt:     metav1.Time{Time: now.Add(-30 * time.Minute)},
+				ExpiresAt:      metav1.Time{Time: now.Add(30 * time.Minute)},
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					createdRef("woopy-woop"),
+					createdRef("woopy-wap"),
+				},
+			},
+		}
+	)
+
+	testCases := []struct {
+		desc   string
+		policy *kudov1alpha1.EscalationPolicy
+		// recordedPolicyVersion overrides the policy version recorded by the escalation, if set.
+		recordedPolicyVersion string
+		// currentGrants are the escalation grants redefined by a previous policy change, if set.
+		currentGrants []kudov1alpha1.ValueWithKind
+
+		wantEscalationStatus kudov1alpha1.EscalationStatus
+	}{
+		{
+			desc: "follows the current policy version if the escalation references the current policy resource version",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.ResourceVersion = "987654"
+				policy.Spec.Subjects = []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}}}
+			}),
+			recordedPolicyVersion: "987654",
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+		{
+			desc: "follows the current policy version if the escalation references a resource version of a policy never updated",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Generation = 1
+				policy.ResourceVersion = "987700"
+				policy.Spec.Subjects = []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}}}
+			}),
+			recordedPolicyVersion: "987654",
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "1"
+				return status
+			}(),
+		},
+		{
+			desc: "reviews the policy change if the escalation references an older policy resource version",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.ResourceVersion = "987700"
+				policy.Spec.Subjects = []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}}}
+			}),
+			recordedPolicyVersion: "987654",
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the policy subjects have changed, and the requestor might not be allowed to use it anymore"
+				status.PolicyVersion = "987654"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "follows the new policy version if only metadata has changed",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Labels = map[string]string{"team": "sre"}
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+		{
+			desc: "keeps the escalation as is if only the policy status has changed",
+			policy: func() *kudov1alpha1.EscalationPolicy {
+				policy := testPolicy.DeepCopy()
+				policy.ResourceVersion = "43335"
+				policy.Status = kudov1alpha1.EscalationPolicyStatus{Valid: true, ActiveEscalations: 1}
+				return policy
+			}(),
+			wantEscalationStatus: acceptedEscalation.Status,
+		},
+		{
+			desc: "follows the new policy version if the requestor is still part of the subjects",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Subjects = append(
+					policy.Spec.Subjects,
+					kudov1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "john-claude"}},
+				)
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if the requestor might not be allowed anymore",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Subjects = []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}}}
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the policy subjects have changed, and the requestor might not be allowed to use it anymore"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if it lasts longer than the new maximum duration",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.MaxDuration = metav1.Duration{Duration: 30 * time.Minute}
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the escalation lasts longer than the policy maximum duration [30m0s]"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if one of its grants has been removed",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants = policy.Spec.Target.Grants[:1]
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: some of the escalation grants have been removed from the policy"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "reclaims the grants that have changed, to create them again",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants[1] = redefinedGrant
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := *acceptedEscalation.Status.DeepCopy()
+				status.StateDetails = escalation.PolicyChangedGrantsRecreatedStateDetails
+				status.PolicyVersion = "43334"
+				status.CurrentGrants = []kudov1alpha1.ValueWithKind{
+					testPolicy.Spec.Target.Grants[0],
+					redefinedGrant,
+				}
+				status.GrantRefs = []kudov1alpha1.EscalationGrantRef{
+					createdRef("woopy-woop"),
+					{
+						Status: kudov1alpha1.GrantStatusReclaimed,
+						Ref:    createdRef("woopy-wap").Ref,
+					},
+				}
+				return status
+			}(),
+		},
+		{
+			desc: "compares the grants with their definition recorded by a previous policy change",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants[1] = redefinedGrant
+			}),
+			currentGrants: []kudov1alpha1.ValueWithKind{
+				testPolicy.Spec.Target.Grants[0],
+				redefinedGrant,
+			},
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := *acceptedEscalation.Status.DeepCopy()
+				status.PolicyVersion = "43334"
+				status.CurrentGrants = []kudov1alpha1.ValueWithKind{
+					testPolicy.Spec.Target.Grants[0],
+					redefinedGrant,
+				}
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if one of its grants has been widened",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants[1] = kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: "test-ns-2",
+						RoleRef: rbacv1.RoleRef{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "ClusterRoleBinding",
+							Name:     widenedRoleName,
+						},
+					},
+				)
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: grant 1 gives more permissions than the escalation was allowed"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if one of its grants has been narrowed",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants[1] = kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: narrowedNamespace,
+						RoleRef: rbacv1.RoleRef{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "ClusterRoleBinding",
+							Name:     "woopy-wap",
+						},
+					},
+				)
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: grant 1 is not valid anymore, resource not allowed"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "follows the new policy version if a grant has been added",
+			policy: changedPolicy(func(policy *kudov1alpha1.EscalationPolicy) {
+				policy.Spec.Target.Grants = append(
+					[]kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(
+							testGrantKind,
+							kudov1alpha1.K8sRoleBindingGrant{
+								DefaultNamespace: "test-ns-3",
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: rbacv1.GroupName,
+									Kind:     "ClusterRoleBinding",
+									Name:     "woopy-wup",
+								},
+							},
+						),
+					},
+					policy.Spec.Target.Grants...,
+				)
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+						if err != nil {
+							return kudov1alpha1.EscalationGrantRef{}, err
+						}
+
+						return createdRef(k8sGrant.RoleRef.Name), nil
+					},
+					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+						ref.Status = kudov1alpha1.GrantStatusReclaimed
+						return ref, nil
+					},
+					ValidateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) error {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+						if err != nil {
+							return err
+						}
+
+						if k8sGrant.DefaultNamespace == narrowedNamespace {
+							return errors.New("resource not allowed")
+						}
+
+						return nil
+					},
+					WidensFn: func(_, current kudov1alpha1.ValueWithKind) (bool, error) {
+						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](current)
+						if err != nil {
+							return false, err
+						}
+
+						return k8sGrant.RoleRef.Name == widenedRoleName, nil
+					},
+				}
+				esc = acceptedEscalation.DeepCopy()
+			)
+
+			if testCase.recordedPolicyVersion != "" {
+				esc.Status.PolicyVersion = testCase.recordedPolicyVersion
+			}
+
+			esc.Status.CurrentGrants = testCase.currentGrants
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{testCase.policy, esc},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				esc.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_PolicyTemplateChange(t *testing.T) {
+	var (
+		templatedPolicy = func() *kudov1alpha1.EscalationPolicy {
+			policy := testPolicy.DeepCopy()
+			policy.Spec = kudov1alpha1.EscalationPolicySpec{
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "test-template"},
+			}
+			return policy
+		}()
+
+		template = func(generation int64, mutate func(spec *kudov1alpha1.EscalationPolicySpec)) *kudov1alpha1.EscalationPolicyTemplate {
+			template := &kudov1alpha1.EscalationPolicyTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-template",
+					Generation: generation,
+				},
+				Spec: *testPolicy.Spec.DeepCopy(),
+			}
+			mutate(&template.Spec)
+			return template
+		}
+
+		createdRef = func(name string) kudov1alpha1.EscalationGrantRef {
+			return kudov1alpha1.EscalationGrantRef{
+				Status: kudov1alpha1.GrantStatusCreated,
+				Ref: kudov1alpha1.MustEncodeValueWithKind(
+					testGrantKind,
+					kudov1alpha1.K8sRoleBindingGrantRef{Name: name},
+				),
+			}
+		}
+
+		effectiveSpec = func() *kudov1alpha1.EscalationPolicySpec {
+			spec := testPolicy.Spec.DeepCopy()
+			spec.TemplateRef = templatedPolicy.Spec.TemplateRef.DeepCopy()
+			return spec
+		}()
+
+		acceptedEscalation = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "john-claude",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:          kudov1alpha1.StateAccepted,
+				StateDetails:   escalation.AcceptedAppliedStateDetails,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion + "/10",
+				PolicySnapshot: effectiveSpec,
+				AcceptedAt:     metav1.Time{Time: now.Add(-30 * time.Minute)},
+				ExpiresAt:      metav1.Time{Time: now.Add(30 * time.Minute)},
+				GrantRefs: []kudov1alpha1.EscalationGrantRef{
+					createdRef("grant-0"),
+					createdRef("grant-1"),
+				},
+			},
+		}
+	)
+
+	testCases := []struct {
+		desc     string
+		template *kudov1alpha1.EscalationPolicyTemplate
+
+		wantEscalationStatus kudov1alpha1.EscalationStatus
+	}{
+		{
+			desc: "follows the new template version if the escalation is still allowed",
+			template: template(11, func(spec *kudov1alpha1.EscalationPolicySpec) {
+				spec.Target.MaxDuration = metav1.Duration{Duration: 2 * time.Hour}
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = testPolicyVersion + "/11"
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if the template change narrows it",
+			template: template(11, func(spec *kudov1alpha1.EscalationPolicySpec) {
+				spec.Target.MaxDuration = metav1.Duration{Duration: 30 * time.Minute}
+			}),
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: the escalation lasts longer than the policy maximum duration [30m0s]"
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "denies the escalation if the template doesn't exist anymore",
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation references a policy that can't be used anymore, all granted permissions are reclaimed. " +
+					`Reason is: policy template can't be applied, template "test-template" doesn't exist`
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+						ref.Status = kudov1alpha1.GrantStatusReclaimed
+						return ref, nil
+					},
+					ValidateFn: func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) error {
+						return nil
+					},
+				}
+				esc  = acceptedEscalation.DeepCopy()
+				seed = []runtime.Object{templatedPolicy, esc}
+			)
+
+			if testCase.template != nil {
+				seed = append(seed, testCase.template)
+			}
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				seed,
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				esc.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_CreatesGrantsFromPolicySnapshot(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		createdGrants []kudov1alpha1.ValueWithKind
+		dummyGranter  = mockGranter{
+			CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+				createdGrants = append(createdGrants, grant)
+
+				return kudov1alpha1.EscalationGrantRef{
+					Status: kudov1alpha1.GrantStatusCreated,
+					Ref:    kudov1alpha1.MustEncodeValueWithKind(testGrantKind, kudov1alpha1.K8sRoleBindingGrantRef{Name: "grant"}),
+				}, nil
+			},
+		}
+		snapshot = testPolicy.Spec.DeepCopy()
+		policy   = testPolicy.DeepCopy()
+		esc      = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:          kudov1alpha1.StateAccepted,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion,
+				PolicySnapshot: snapshot,
+				AcceptedAt:     metav1.Time{Time: now.Add(-30 * time.Minute)},
+				ExpiresAt:      metav1.Time{Time: now.Add(30 * time.Minute)},
+			},
+		}
+	)
+
+	snapshot.Target.Grants = snapshot.Target.Grants[1:]
+	// Pretend the policy has been changed without bumping its version, only the snapshot must be used.
+	policy.Spec.Target.Grants = policy.Spec.Target.Grants[:1]
+
+	controller, _, done := buildController(
+		t,
+		grant.StaticFactory{
+			testGrantKind: injectMockGranter(&dummyGranter),
+		},
+		[]runtime.Object{policy, esc},
+	)
+
+	defer done()
+
+	_, err := controller.OnUpdate(ctx, nil, esc)
+	require.NoError(t, err)
+
+	assert.Equal(t, snapshot.Target.Grants, createdGrants)
+}
+
+func TestEscalationController_OnUpdate_CreatesGrantsFromCurrentGrants(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		createdGrants []kudov1alpha1.ValueWithKind
+		dummyGranter  = mockGranter{
+			CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+				createdGrants = append(createdGrants, grant)
+
+				return kudov1alpha1.EscalationGrantRef{
+					Status: kudov1alpha1.GrantStatusCreated,
+					Ref:    kudov1alpha1.MustEncodeValueWithKind(testGrantKind, kudov1alpha1.K8sRoleBindingGrantRef{Name: "grant"}),
+				}, nil
+			},
+		}
+		currentGrants = testPolicy.Spec.Target.Grants[1:]
+		esc           = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:          kudov1alpha1.StateAccepted,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion,
+				PolicySnapshot: testPolicy.Spec.DeepCopy(),
+				CurrentGrants:  currentGrants,
+				AcceptedAt:     metav1.Time{Time: now.Add(-30 * time.Minute)},
+				ExpiresAt:      metav1.Time{Time: now.Add(30 * time.Minute)},
+			},
+		}
+	)
+
+	controller, _, done := buildController(
+		t,
+		grant.StaticFactory{
+			testGrantKind: injectMockGranter(&dummyGranter),
+		},
+		[]runtime.Object{testPolicy.DeepCopy(), esc},
+	)
+
+	defer done()
+
+	_, err := controller.OnUpdate(ctx, nil, esc)
+	require.NoError(t, err)
+
+	assert.Equal(t, currentGrants, createdGrants)
+}
+
+func TestEscalationController_OnUpdate_RequestorReview(t *testing.T) {
+	acceptedEscalation := &kudov1alpha1.Escalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-escalation",
+		},
+		Spec: kudov1alpha1.EscalationSpec{
+			PolicyName: testPolicy.Name,
+			Requestor:  "john-claude",
+			RequestorInfo: &authenticationv1.UserInfo{
+				Username: "john-claude",
+				Groups:   []string{"sre"},
+			},
+		},
+		Status: kudov1alpha1.EscalationStatus{
+			State:          kudov1alpha1.StateAccepted,
+			StateDetails:   escalation.AcceptedAppliedStateDetails,
+			PolicyUID:      testPolicy.UID,
+			PolicyVersion:  testPolicyVersion,
+			PolicySnapshot: testPolicy.Spec.DeepCopy(),
+			AcceptedAt:     metav1.Time{Time: now.Add(-30 * time.Minute)},
+			ExpiresAt:      metav1.Time{Time: now.Add(30 * time.Minute)},
+			GrantRefs: []kudov1alpha1.EscalationGrantRef{
+				{
+					Status: kudov1alpha1.GrantStatusCreated,
+					Ref: kudov1alpha1.MustEncodeValueWithKind(
+						testGrantKind,
+						kudov1alpha1.K8sRoleBindingGrantRef{Name: "grant-0"},
+					),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc          string
+		subjects      []kudov1alpha1.PolicySubject
+		beneficiaries []string
+
+		wantEscalationStatus kudov1alpha1.EscalationStatus
+	}{
+		{
+			desc:     "follows the new policy version if the requestor is still allowed through its groups",
+			subjects: []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "sre"}}},
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+		{
+			desc:     "denies the escalation if the requestor is not allowed anymore",
+			subjects: []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "admins"}}},
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = escalation.DeniedRequestorNotAllowedDetails
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+		{
+			desc: "follows the new policy version if the beneficiaries are still allowed",
+			subjects: []kudov1alpha1.PolicySubject{
+				{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "sre"}},
+				{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "jeanne-claude"}},
+			},
+			beneficiaries: []string{"jeanne-claude"},
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.PolicyVersion = "43334"
+				return status
+			}(),
+		},
+		{
+			desc:          "denies the escalation if one of the beneficiaries is not allowed anymore",
+			subjects:      []kudov1alpha1.PolicySubject{{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "sre"}}},
+			beneficiaries: []string{"jeanne-claude"},
+			wantEscalationStatus: func() kudov1alpha1.EscalationStatus {
+				status := acceptedEscalation.Status
+				status.State = kudov1alpha1.StateDenied
+				status.StateDetails = "This escalation beneficiaries are not allowed to use its policy anymore, all granted permissions are reclaimed. " +
+					"Reason is: beneficiary \"jeanne-claude\" is not allowed to use the escalation policy \"test-policy\""
+				status.FinishedAt = metav1.Time{Time: now}
+				return status
+			}(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					ValidateFn: func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) error {
+						return nil
+					},
+				}
+				esc    = acceptedEscalation.DeepCopy()
+				policy = testPolicy.DeepCopy()
+			)
+
+			policy.Generation = 43334
+			policy.Spec.Subjects = testCase.subjects
+			esc.Spec.Beneficiaries = testCase.beneficiaries
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{policy, esc},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+				ctx,
+				esc.Name,
+				metav1.GetOptions{},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantEscalationStatus, withoutDerivedFields(gotEscalation.Status))
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_PolicyConditions(t *testing.T) {
+	var (
+		pendingEscalation = func(approvedBy string) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: testPolicy.Name,
+					Requestor:  "jean-testeur",
+					Reason:     "INC-1234",
+					ApprovedBy: approvedBy,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StatePending,
+					StateDetails:  escalation.PendingStateDetails,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+				},
+			}
+		}
+
+		acceptedEscalation = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+				Reason:     "INC-1234",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedAppliedStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    metav1.Time{Time: now.Add(-30 * time.Minute)},
+				ExpiresAt:     metav1.Time{Time: now.Add(30 * time.Minute)},
+			},
+		}
+
+		otherActiveEscalation = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State: kudov1alpha1.StateAccepted,
+			},
+		}
+	)
+
+	testCases := []struct {
+		desc       string
+		conditions []kudov1alpha1.PolicyCondition
+		escalation *kudov1alpha1.Escalation
+		seed       []runtime.Object
+
+		wantState        kudov1alpha1.EscalationState
+		wantStateDetails string
+		wantActor        kudov1alpha1.EscalationActor
+	}{
+		{
+			desc: "keeps the escalation pending until it is approved",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `reason.startsWith("INC-")`, Action: kudov1alpha1.ConditionActionRequireChallenge},
+			},
+			escalation:       pendingEscalation(""),
+			wantState:        kudov1alpha1.StatePending,
+			wantStateDetails: escalation.PendingApprovalStateDetails,
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+		{
+			desc: "accepts the escalation once approved",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `reason.startsWith("INC-")`, Action: kudov1alpha1.ConditionActionRequireChallenge},
+			},
+			escalation:       pendingEscalation("reviewer"),
+			wantState:        kudov1alpha1.StateAccepted,
+			wantStateDetails: escalation.AcceptedInProgressStateDetails,
+			wantActor:        kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindReviewer, Name: "reviewer"},
+		},
+		{
+			desc: "denies a pending escalation the conditions do not allow",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"sre" in groups`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			escalation:       pendingEscalation(""),
+			wantState:        kudov1alpha1.StateDenied,
+			wantStateDetails: "This escalation is not allowed by its policy conditions, all granted permissions are reclaimed. Reason is: none of the policy allow conditions matched",
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+		{
+			desc: "denies an accepted escalation the conditions do not allow anymore",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{
+					Expression: "size(activeEscalations) > 0",
+					Action:     kudov1alpha1.ConditionActionDeny,
+					Message:    "only one active escalation is allowed",
+				},
+			},
+			escalation:       acceptedEscalation,
+			seed:             []runtime.Object{otherActiveEscalation},
+			wantState:        kudov1alpha1.StateDenied,
+			wantStateDetails: "This escalation is not allowed by its policy conditions, all granted permissions are reclaimed. Reason is: only one active escalation is allowed",
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+		{
+			desc: "denies an escalation if the conditions can't be evaluated",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"high" in extra["mfa-level"]`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			escalation:       acceptedEscalation,
+			wantState:        kudov1alpha1.StateDenied,
+			wantStateDetails: "This escalation policy conditions can't be evaluated, all granted permissions are reclaimed. Reason is: unable to evaluate condition 0, no such key: mfa-level",
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				dummyGranter = mockGranter{
+					CreateFn: func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+						return kudov1alpha1.EscalationGrantRef{Status: kudov1alpha1.GrantStatusCreated}, nil
+					},
+				}
+				policy = testPolicy.DeepCopy()
+				esc    = testCase.escalation.DeepCopy()
+			)
+
+			policy.Spec.Conditions = testCase.conditions
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				append([]runtime.Object{policy, esc}, testCase.seed...),
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(ctx, esc.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantState, gotEscalation.Status.State)
+			assert.Equal(t, testCase.wantStateDetails, gotEscalation.Status.StateDetails)
+
+			if history := gotEscalation.Status.History; len(history) > 0 {
+				assert.Equal(t, testCase.wantActor, history[len(history)-1].Actor)
+			}
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_Delegation(t *testing.T) {
+	var (
+		delegatedEscalation = func(acknowledged bool, stateDetails string) *kudov1alpha1.Escalation {
+			esc := &kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-escalation",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName:   testPolicy.Name,
+					Requestor:    "jean-testeur",
+					Reason:       "locked out",
+					OnBehalfOf:   "jean-testeur",
+					Delegate:     &authenticationv1.UserInfo{Username: "jean-lead"},
+					Acknowledged: acknowledged,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:         kudov1alpha1.StatePending,
+					StateDetails:  stateDetails,
+					PolicyUID:     testPolicy.UID,
+					PolicyVersion: testPolicyVersion,
+				},
+			}
+
+			if acknowledged {
+				esc.Spec.RequestorInfo = &authenticationv1.UserInfo{Username: "jean-testeur"}
+			}
+
+			return esc
+		}
+	)
+
+	testCases := []struct {
+		desc                   string
+		requireAcknowledgement bool
+		excludedSubjects       []kudov1alpha1.PolicySubject
+		conditions             []kudov1alpha1.PolicyCondition
+		escalation             *kudov1alpha1.Escalation
+
+		wantState        kudov1alpha1.EscalationState
+		wantStateDetails string
+		wantActor        kudov1alpha1.EscalationActor
+	}{
+		{
+			desc:                   "keeps the escalation pending until its requestor acknowledges it",
+			requireAcknowledgement: true,
+			escalation:             delegatedEscalation(false, escalation.PendingStateDetails),
+			wantState:              kudov1alpha1.StatePending,
+			wantStateDetails:       escalation.PendingAcknowledgementDetails,
+			wantActor:              kudov1alpha1.ControllerActor,
+		},
+		{
+			desc:                   "records the acknowledgement of the requestor",
+			requireAcknowledgement: true,
+			escalation:             delegatedEscalation(true, escalation.PendingAcknowledgementDetails),
+			wantState:              kudov1alpha1.StatePending,
+			wantStateDetails:       escalation.PendingAcknowledgedDetails,
+			wantActor:              kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindRequestor, Name: "jean-testeur"},
+		},
+		{
+			desc:                   "accepts the escalation once acknowledged",
+			requireAcknowledgement: true,
+			escalation:             delegatedEscalation(true, escalation.PendingAcknowledgedDetails),
+			wantState:              kudov1alpha1.StateAccepted,
+			wantStateDetails:       escalation.AcceptedInProgressStateDetails,
+			wantActor:              kudov1alpha1.ControllerActor,
+		},
+		{
+			desc: "keeps the escalation pending until its requestor acknowledges it if the policy excludes some subjects",
+			excludedSubjects: []kudov1alpha1.PolicySubject{
+				{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "contractors"}},
+			},
+			escalation:       delegatedEscalation(false, escalation.PendingStateDetails),
+			wantState:        kudov1alpha1.StatePending,
+			wantStateDetails: escalation.PendingAcknowledgementDetails,
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+		{
+			desc: "keeps the escalation pending until its requestor acknowledges it if the policy has conditions",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"contractors" in groups`, Action: kudov1alpha1.ConditionActionDeny},
+			},
+			escalation:       delegatedEscalation(false, escalation.PendingStateDetails),
+			wantState:        kudov1alpha1.StatePending,
+			wantStateDetails: escalation.PendingAcknowledgementDetails,
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+		{
+			desc:             "accepts the escalation if the policy does not require an acknowledgement",
+			escalation:       delegatedEscalation(false, escalation.PendingStateDetails),
+			wantState:        kudov1alpha1.StateAccepted,
+			wantStateDetails: escalation.AcceptedInProgressStateDetails,
+			wantActor:        kudov1alpha1.ControllerActor,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx    = context.Background()
+				policy = testPolicy.DeepCopy()
+				esc    = testCase.escalation.DeepCopy()
+			)
+
+			policy.Spec.Delegation = &kudov1alpha1.EscalationDelegation{
+				Delegates: []kudov1alpha1.PolicySubject{
+					{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "jean-lead"}},
+				},
+				RequireAcknowledgement: testCase.requireAcknowledgement,
+			}
+			policy.Spec.ExcludedSubjects = testCase.excludedSubjects
+			policy.Spec.Conditions = testCase.conditions
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&mockGranter{}),
+				},
+				[]runtime.Object{policy, esc},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(ctx, esc.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantState, gotEscalation.Status.State)
+			assert.Equal(t, testCase.wantStateDetails, gotEscalation.Status.StateDetails)
+
+			if history := gotEscalation.Status.History; len(history) > 0 {
+				assert.Equal(t, testCase.wantActor, history[len(history)-1].Actor)
+			}
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_NamespacedPolicy(t *testing.T) {
+	namespacedPolicy := func(roleKind, roleName string) *kudov1alpha1.NamespacedEscalationPolicy {
+		return &kudov1alpha1.NamespacedEscalationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "team-policy",
+				Namespace:  "team-a",
+				UID:        "ffff-ffff-fff",
+				Generation: 1234,
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: testPolicy.Spec.Subjects,
+				Target: kudov1alpha1.EscalationTarget{
+					DefaultDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(
+							kudov1alpha1.GrantKindK8sRoleBinding,
+							kudov1alpha1.K8sRoleBindingGrant{
+								DefaultNamespace: "team-a",
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: rbacv1.GroupName,
+									Kind:     roleKind,
+									Name:     roleName,
+								},
 							},
 						),
 					},
 				},
 			},
+		}
+	}
+
+	testCases := []struct {
+		desc   string
+		policy *kudov1alpha1.NamespacedEscalationPolicy
+
+		wantState        kudov1alpha1.EscalationState
+		wantStateDetails string
+	}{
+		{
+			desc:             "accepts escalations refering to a namespaced policy binding a role of its namespace",
+			policy:           namespacedPolicy("Role", "deployer"),
+			wantState:        kudov1alpha1.StateAccepted,
+			wantStateDetails: escalation.AcceptedInProgressStateDetails,
 		},
 		{
-			desc:            "on denied state, marks permission as partially reclaimed if one of the granter fails",
-			kudoSeed:        []runtime.Object{&testPolicy},
-			reclaimGrantErr: errors.New("nonono"),
-			updatedEscalation: kudov1alpha1.Escalation{
+			desc:             "accepts escalations refering to a namespaced policy binding an allowed cluster role",
+			policy:           namespacedPolicy("ClusterRole", "view"),
+			wantState:        kudov1alpha1.StateAccepted,
+			wantStateDetails: escalation.AcceptedInProgressStateDetails,
+		},
+		{
+			desc:      "denies escalations refering to a namespaced policy binding a cluster role that is not allowed",
+			policy:    namespacedPolicy("ClusterRole", "admin"),
+			wantState: kudov1alpha1.StateDenied,
+			wantStateDetails: "This escalation references a policy that can't be used anymore, all granted permissions are reclaimed. " +
+				`Reason is: namespaced policy target is not confined to its namespace, grant 0 binds the cluster role "admin", allowed cluster roles are [view]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				esc = &kudov1alpha1.Escalation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-escalation",
+					},
+					Spec: kudov1alpha1.EscalationSpec{
+						PolicyName:      testCase.policy.Name,
+						PolicyNamespace: testCase.policy.Namespace,
+						Requestor:       "jean-testeur",
+						Reason:          "hotfix",
+					},
+					Status: kudov1alpha1.EscalationStatus{
+						State:         kudov1alpha1.StatePending,
+						StateDetails:  escalation.PendingStateDetails,
+						PolicyUID:     testCase.policy.UID,
+						PolicyVersion: "1234",
+					},
+				}
+			)
+
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					kudov1alpha1.GrantKindK8sRoleBinding: injectMockGranter(&mockGranter{}),
+				},
+				[]runtime.Object{testCase.policy, esc},
+			)
+
+			defer done()
+
+			_, err := controller.OnUpdate(ctx, nil, esc)
+			require.NoError(t, err)
+
+			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(ctx, esc.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantState, gotEscalation.Status.State)
+			assert.Equal(t, testCase.wantStateDetails, gotEscalation.Status.StateDetails)
+		})
+	}
+}
+
+func TestEscalationController_OnUpdate_Conditions(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		dummyGranter = mockGranter{
+			CreateFn: func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+				return kudov1alpha1.EscalationGrantRef{
+					Status: kudov1alpha1.GrantStatusCreated,
+					Ref:    kudov1alpha1.MustEncodeValueWithKind(testGrantKind, kudov1alpha1.K8sRoleBindingGrantRef{Name: "grant"}),
+				}, nil
+			},
+		}
+		approvedAt = metav1.NewTime(now.Add(-time.Minute))
+		esc        = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-escalation",
+				Generation: 3,
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:          kudov1alpha1.StateAccepted,
+				StateDetails:   escalation.AcceptedInProgressStateDetails,
+				PolicyUID:      testPolicy.UID,
+				PolicyVersion:  testPolicyVersion,
+				PolicySnapshot: testPolicy.Spec.DeepCopy(),
+				AcceptedAt:     approvedAt,
+				ExpiresAt:      metav1.NewTime(now.Add(time.Hour)),
+				Conditions: []metav1.Condition{
+					{Type: kudov1alpha1.ConditionApproved, Status: metav1.ConditionTrue, Reason: "Accepted", LastTransitionTime: approvedAt, ObservedGeneration: 2},
+					{Type: kudov1alpha1.ConditionGranted, Status: metav1.ConditionFalse, Reason: "GrantsPending", LastTransitionTime: approvedAt, ObservedGeneration: 2},
+				},
+			},
+		}
+	)
+
+	controller, k8s, done := buildController(
+		t,
+		grant.StaticFactory{
+			testGrantKind: injectMockGranter(&dummyGranter),
+		},
+		[]runtime.Object{&testPolicy, esc},
+	)
+
+	defer done()
+
+	_, err := controller.OnUpdate(ctx, nil, esc)
+	require.NoError(t, err)
+
+	gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(ctx, esc.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), gotEscalation.Status.ObservedGeneration)
+	assert.Equal(
+		t,
+		[]metav1.Condition{
+			{
+				Type:               kudov1alpha1.ConditionApproved,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Accepted",
+				Message:            escalation.AcceptedAppliedStateDetails,
+				LastTransitionTime: approvedAt,
+				ObservedGeneration: 3,
+			},
+			{
+				Type:               kudov1alpha1.ConditionGranted,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Granted",
+				Message:            escalation.AcceptedAppliedStateDetails,
+				LastTransitionTime: metav1.NewTime(now),
+				ObservedGeneration: 3,
+			},
+			{
+				Type:               kudov1alpha1.ConditionExpired,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Accepted",
+				LastTransitionTime: metav1.NewTime(now),
+				ObservedGeneration: 3,
+			},
+			{
+				Type:               kudov1alpha1.ConditionReclaimFailed,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoFailure",
+				LastTransitionTime: metav1.NewTime(now),
+				ObservedGeneration: 3,
+			},
+			{
+				Type:               kudov1alpha1.ConditionTampered,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NotTampered",
+				LastTransitionTime: metav1.NewTime(now),
+				ObservedGeneration: 3,
+			},
+		},
+		gotEscalation.Status.Conditions,
+	)
+}
+
+func TestEscalationController_OnUpdate_History(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		acceptedAt   = metav1.NewTime(now.Add(-time.Minute))
+		dummyGranter = mockGranter{
+			ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+				ref.Status = kudov1alpha1.GrantStatusReclaimed
+				return ref, nil
+			},
+		}
+		accepted = kudov1alpha1.EscalationTransition{
+			From:    kudov1alpha1.StatePending,
+			To:      kudov1alpha1.StateAccepted,
+			At:      acceptedAt,
+			Actor:   kudov1alpha1.ControllerActor,
+			Details: escalation.AcceptedInProgressStateDetails,
+		}
+		esc = &kudov1alpha1.Escalation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: testPolicy.Name,
+				Requestor:  "jean-testeur",
+				Revocation: &kudov1alpha1.EscalationRevocation{
+					Reason:    "Incident is over",
+					RevokedBy: "admin",
+				},
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State:         kudov1alpha1.StateAccepted,
+				StateDetails:  escalation.AcceptedAppliedStateDetails,
+				PolicyUID:     testPolicy.UID,
+				PolicyVersion: testPolicyVersion,
+				AcceptedAt:    acceptedAt,
+				ExpiresAt:     metav1.NewTime(now.Add(time.Hour)),
+				History:       []kudov1alpha1.EscalationTransition{accepted},
+			},
+		}
+	)
+
+	controller, k8s, done := buildController(
+		t,
+		grant.StaticFactory{
+			testGrantKind: injectMockGranter(&dummyGranter),
+		},
+		[]runtime.Object{&testPolicy, esc},
+	)
+
+	defer done()
+
+	_, err := controller.OnUpdate(ctx, nil, esc)
+	require.NoError(t, err)
+
+	gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(ctx, esc.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]kudov1alpha1.EscalationTransition{
+			accepted,
+			{
+				From:    kudov1alpha1.StateAccepted,
+				To:      kudov1alpha1.StateRevoked,
+				At:      metav1.NewTime(now),
+				Actor:   kudov1alpha1.EscalationActor{Kind: kudov1alpha1.ActorKindAdmin, Name: "admin"},
+				Details: "This escalation has been revoked by admin, all granted permissions are reclaimed. Reason is: Incident is over",
+			},
+		},
+		gotEscalation.Status.History,
+	)
+}
+
+func TestEscalationController_OnUpdate_Retention(t *testing.T) {
+	const retention = 24 * time.Hour
+
+	var (
+		finishedEscalation = func(finishedAt time.Time, grantStatus kudov1alpha1.GrantStatus) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-escalation",
-					CreationTimestamp: metav1.Time{
-						Time: now,
-					},
+					UID:  "test-escalation-uid",
 				},
 				Spec: kudov1alpha1.EscalationSpec{
 					PolicyName: testPolicy.Name,
 				},
 				Status: kudov1alpha1.EscalationStatus{
-					State: kudov1alpha1.StateDenied,
+					State:        kudov1alpha1.StateExpired,
+					StateDetails: escalation.ExpiredStateDetails,
+					FinishedAt:   metav1.Time{Time: finishedAt},
 					GrantRefs: []kudov1alpha1.EscalationGrantRef{
 						{
-							Status: kudov1alpha1.GrantStatusCreated,
+							Status: grantStatus,
 							Ref: kudov1alpha1.MustEncodeValueWithKind(
 								testGrantKind,
 								kudov1alpha1.K8sRoleBindingGrantRef{
@@ -911,43 +3514,51 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 								},
 							),
 						},
-						{
-							Status: kudov1alpha1.GrantStatusCreated,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								testGrantKind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: "grant-test-ns-2",
-								},
-							),
-						},
 					},
 				},
-			},
+			}
+		}
+	)
+
+	testCases := []struct {
+		desc       string
+		escalation *kudov1alpha1.Escalation
+		archiveErr error
+
+		wantArchived   bool
+		wantDeleted    bool
+		wantNextResync time.Duration
+	}{
+		{
+			desc:         "archives and deletes the escalation once the retention is over",
+			escalation:   finishedEscalation(now.Add(-retention), kudov1alpha1.GrantStatusReclaimed),
+			wantArchived: true,
+			wantDeleted:  true,
+		},
+		{
+			desc: "deletes an escalation already archived without archiving it again",
+			escalation: func() *kudov1alpha1.Escalation {
+				esc := finishedEscalation(now.Add(-retention), kudov1alpha1.GrantStatusReclaimed)
+				esc.Status.ArchivedAt = metav1.Time{Time: now.Add(-time.Minute)}
+				return esc
+			}(),
+			wantDeleted: true,
+		},
+		{
+			desc:           "keeps the escalation until the end of the retention",
+			escalation:     finishedEscalation(now.Add(-time.Hour), kudov1alpha1.GrantStatusReclaimed),
+			wantNextResync: retention - time.Hour,
+		},
+		{
+			desc:           "keeps the escalation if all grants are not reclaimed",
+			escalation:     finishedEscalation(now.Add(-retention), kudov1alpha1.GrantStatusCreated),
+			wantNextResync: retryDelay,
+		},
+		{
+			desc:           "keeps the escalation if it can't be archived",
+			escalation:     finishedEscalation(now.Add(-retention), kudov1alpha1.GrantStatusReclaimed),
+			archiveErr:     errors.New("archive is down"),
 			wantNextResync: retryDelay,
-			wantEscalationStatus: kudov1alpha1.EscalationStatus{
-				State:        kudov1alpha1.StateDenied,
-				StateDetails: "This escalation is denied, but grants have been partially reclaimed. Reason is: nonono",
-				GrantRefs: []kudov1alpha1.EscalationGrantRef{
-					{
-						Status: kudov1alpha1.GrantStatusCreated,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-1",
-							},
-						),
-					},
-					{
-						Status: kudov1alpha1.GrantStatusCreated,
-						Ref: kudov1alpha1.MustEncodeValueWithKind(
-							testGrantKind,
-							kudov1alpha1.K8sRoleBindingGrantRef{
-								Name: "grant-test-ns-2",
-							},
-						),
-					},
-				},
-			},
 		},
 	}
 
@@ -955,89 +3566,108 @@ func TestEscalationController_OnUpdate(t *testing.T) {
 		t.Run(testCase.desc, func(t *testing.T) {
 			var (
 				ctx          = context.Background()
+				archiver     = mockArchiver{err: testCase.archiveErr}
+				reclaimed    int
 				dummyGranter = mockGranter{
-					CreateFn: func(_ *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
-						k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
-						require.NoError(t, err)
-
-						return kudov1alpha1.EscalationGrantRef{
-							Status: kudov1alpha1.GrantStatusCreated,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								testGrantKind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: "grant-" + k8sGrant.DefaultNamespace,
-								},
-							),
-						}, testCase.upsertGrantErr
-					},
 					ReclaimFn: func(ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
-						k8sGrantRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](ref.Ref)
-						require.NoError(t, err)
-
-						return kudov1alpha1.EscalationGrantRef{
-							Status: kudov1alpha1.GrantStatusReclaimed,
-							Ref: kudov1alpha1.MustEncodeValueWithKind(
-								ref.Ref.Kind,
-								kudov1alpha1.K8sRoleBindingGrantRef{
-									Name: k8sGrantRef.Name,
-								},
-							),
-						}, testCase.reclaimGrantErr
+						reclaimed++
+						return ref, nil
 					},
 				}
+			)
 
-				controller, k8s, done = buildController(
-					t,
-					grant.StaticFactory{
-						testGrantKind: injectMockGranter(&dummyGranter),
-					},
-					append(
-						testCase.kudoSeed,
-						&testCase.updatedEscalation,
-					),
-				)
+			controller, k8s, done := buildController(
+				t,
+				grant.StaticFactory{
+					testGrantKind: injectMockGranter(&dummyGranter),
+				},
+				[]runtime.Object{&testPolicy, testCase.escalation},
+				escalation.WithRetention(retention),
+				escalation.WithArchiver(&archiver),
 			)
 
 			defer done()
 
-			gotInsight, err := controller.OnUpdate(ctx, nil, &testCase.updatedEscalation)
-			if testCase.wantError != nil {
-				require.Equal(t, testCase.wantError, err)
-				return
-			}
+			var deletedEsc *kudov1alpha1.Escalation
+
+			k8s.kudoClientSet.PrependReactor(
+				"delete",
+				"escalations",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					obj, err := k8s.kudoClientSet.Tracker().Get(
+						action.GetResource(),
+						"",
+						action.(k8stesting.DeleteAction).GetName(),
+					)
+					if err == nil {
+						deletedEsc = obj.(*kudov1alpha1.Escalation)
+					}
+
+					return false, nil, nil
+				},
+			)
+
+			insight, err := controller.OnUpdate(ctx, nil, testCase.escalation)
 			require.NoError(t, err)
 
-			assert.Equal(t, testCase.wantNextResync, gotInsight.ResyncAfter)
+			assert.Equal(t, testCase.wantNextResync, insight.ResyncAfter)
 
-			gotEscalation, err := k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
+			if testCase.wantArchived {
+				assert.Equal(t, []*kudov1alpha1.Escalation{testCase.escalation}, archiver.archived)
+			} else {
+				assert.Empty(t, archiver.archived)
+			}
+
+			_, err = k8s.kudoClientSet.K8sV1alpha1().Escalations().Get(
 				ctx,
-				testCase.updatedEscalation.Name,
+				testCase.escalation.Name,
 				metav1.GetOptions{},
 			)
-			require.NoError(t, err)
 
-			sort.Slice(gotEscalation.Status.GrantRefs, func(i, j int) bool {
-				vi, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](gotEscalation.Status.GrantRefs[i].Ref)
-				require.NoError(t, err)
-				vj, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](gotEscalation.Status.GrantRefs[j].Ref)
-				require.NoError(t, err)
+			if testCase.wantDeleted {
+				assert.True(t, kerrors.IsNotFound(err))
 
-				return vi.Name < vj.Name
-			})
+				// The escalation is marked as archived before being deleted, so its deletion event is ignored.
+				require.NotNil(t, deletedEsc)
+				assert.False(t, deletedEsc.Status.ArchivedAt.IsZero())
+
+				_, err = controller.OnDelete(ctx, deletedEsc)
+				require.NoError(t, err)
+				assert.Zero(t, reclaimed)
+				return
+			}
 
-			assert.Equal(t, testCase.wantEscalationStatus, gotEscalation.Status)
+			require.NoError(t, err)
+			assert.Nil(t, deletedEsc)
 		})
 	}
 }
 
+type mockArchiver struct {
+	archived []*kudov1alpha1.Escalation
+	err      error
+}
+
+func (a *mockArchiver) Archive(_ context.Context, esc *kudov1alpha1.Escalation) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	a.archived = append(a.archived, esc)
+
+	return nil
+}
+
 func injectMockGranter(g *mockGranter) func() (grant.Granter, error) {
 	return func() (grant.Granter, error) { return g, nil }
 }
 
 type mockGranter struct {
-	CreateFn   func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error)
-	ReclaimFn  func(kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error)
-	ValidateFn func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) error
+	CreateFn         func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error)
+	ReclaimFn        func(kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error)
+	ValidateFn       func(*kudov1alpha1.Escalation, kudov1alpha1.ValueWithKind) error
+	ValidatePolicyFn func(kudov1alpha1.ValueWithKind) error
+	WidensFn         func(previous, current kudov1alpha1.ValueWithKind) (bool, error)
 }
 
 func (g *mockGranter) Create(_ context.Context, esc *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
@@ -1052,14 +3682,26 @@ func (g *mockGranter) Validate(_ context.Context, esc *kudov1alpha1.Escalation,
 	return g.ValidateFn(esc, grant)
 }
 
+func (g *mockGranter) ValidatePolicy(_ context.Context, grant kudov1alpha1.ValueWithKind) error {
+	return g.ValidatePolicyFn(grant)
+}
+
+func (g *mockGranter) Widens(_ context.Context, _ *kudov1alpha1.Escalation, previous, current kudov1alpha1.ValueWithKind) (bool, error) {
+	if g.WidensFn == nil {
+		return false, nil
+	}
+
+	return g.WidensFn(previous, current)
+}
+
 type fakeK8s struct {
-	kudoClientSet        kudoclientset.Interface
+	kudoClientSet        *kudofake.Clientset
 	kudoInformersFactory kudoinformers.SharedInformerFactory
 }
 
 type doneFunc func()
 
-func buildController(t *testing.T, granterFactory grant.Factory, kudoSeed []runtime.Object) (*escalation.Controller, fakeK8s, doneFunc) {
+func buildController(t *testing.T, granterFactory grant.Factory, kudoSeed []runtime.Object, opts ...escalation.ControllerOpt) (*escalation.Controller, fakeK8s, doneFunc) {
 	t.Helper()
 
 	var (
@@ -1072,13 +3714,24 @@ func buildController(t *testing.T, granterFactory grant.Factory, kudoSeed []runt
 			),
 		}
 		controller = escalation.NewController(
-			k8s.kudoInformersFactory.K8s().V1alpha1().EscalationPolicies().Lister(),
+			escalation.NewPolicyResolver(
+				k8s.kudoInformersFactory.K8s().V1alpha1().EscalationPolicies().Lister(),
+				k8s.kudoInformersFactory.K8s().V1alpha1().NamespacedEscalationPolicies().Lister(),
+				k8s.kudoInformersFactory.K8s().V1alpha1().EscalationPolicyTemplates().Lister(),
+				testAllowedClusterRoles,
+			),
+			k8s.kudoInformersFactory.K8s().V1alpha1().Escalations().Lister(),
 			k8s.kudoClientSet.K8sV1alpha1().Escalations(),
 			granterFactory,
 			audit.NewK8sEventSink(&record.FakeRecorder{}),
-			escalation.WithNowFunc(nowFunc),
-			escalation.WithResyncInterval(resyncDelay),
-			escalation.WithRetryInterval(retryDelay),
+			append(
+				[]escalation.ControllerOpt{
+					escalation.WithNowFunc(nowFunc),
+					escalation.WithResyncInterval(resyncDelay),
+					escalation.WithRetryInterval(retryDelay),
+				},
+				opts...,
+			)...,
 		)
 		done = make(chan struct{})
 	)
@@ -1094,3 +3747,13 @@ func buildController(t *testing.T, granterFactory grant.Factory, kudoSeed []runt
 }
 
 func nowFunc() time.Time { return now }
+
+// withoutDerivedFields drops the conditions and the history recorded on each transition,
+// they're covered by TestEscalationController_OnUpdate_Conditions and TestEscalationController_OnUpdate_History.
+func withoutDerivedFields(status kudov1alpha1.EscalationStatus) kudov1alpha1.EscalationStatus {
+	status.ObservedGeneration = 0
+	status.Conditions = nil
+	status.History = nil
+
+	return status
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -13,23 +14,23 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
+	"github.com/jlevesy/kudo/escalationpolicy"
 	"github.com/jlevesy/kudo/grant"
-	"github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"github.com/jlevesy/kudo/pkg/generics"
 )
 
-type EscalationPoliciesGetter interface {
-	Get(name string) (*v1alpha1.EscalationPolicy, error)
-}
+// maxStartDelay is how far ahead of its creation an escalation can be scheduled.
+const maxStartDelay = 30 * 24 * time.Hour
 
 type createAdmissionReviewer struct {
-	policiesGetter EscalationPoliciesGetter
-	grantFactory   grant.Factory
+	policyResolver    PolicyResolver
+	escalationsLister EscalationsLister
+	grantFactory      grant.Factory
 }
 
-func NewCreateAdmissionReviewer(g EscalationPoliciesGetter, f grant.Factory) *createAdmissionReviewer {
-	return &createAdmissionReviewer{policiesGetter: g, grantFactory: f}
+func NewCreateAdmissionReviewer(p PolicyResolver, l EscalationsLister, f grant.Factory) *createAdmissionReviewer {
+	return &createAdmissionReviewer{policyResolver: p, escalationsLister: l, grantFactory: f}
 }
 
 func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
@@ -56,7 +57,103 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 		}, nil
 	}
 
-	policy, err := r.policiesGetter.Get(escalation.Spec.PolicyName)
+	if escalation.Spec.Released {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "An escalation can't be released at creation",
+			},
+		}, nil
+	}
+
+	if escalation.Spec.Revocation != nil {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "An escalation can't be revoked at creation",
+			},
+		}, nil
+	}
+
+	if escalation.Spec.ApprovedBy != "" {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "An escalation can't be approved at creation",
+			},
+		}, nil
+	}
+
+	if escalation.Spec.Acknowledged {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "An escalation can't be acknowledged at creation",
+			},
+		}, nil
+	}
+
+	if escalation.Spec.Delegate != nil || (escalation.Spec.OnBehalfOf != "" && escalation.Spec.RequestorInfo != nil) {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "The delegate and the requestor identity of an escalation are recorded by Kudo",
+			},
+		}, nil
+	}
+
+	if escalation.Spec.OnBehalfOf != "" && escalation.Spec.OnBehalfOf == req.UserInfo.Username {
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "An escalation can't be created on behalf of its own delegate",
+			},
+		}, nil
+	}
+
+	if len(escalation.Spec.Extensions) > 0 {
+		klog.InfoS(
+			"User submitted an escalation request with extensions",
+			"username",
+			req.UserInfo.Username,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "Extensions can only be requested once the escalation is accepted",
+			},
+		}, nil
+	}
+
+	if startAt := escalation.Spec.StartAt; startAt != nil {
+		now := time.Now()
+
+		// The escalation expires after its duration from its start, a start in the past would shorten it.
+		if startAt.Time.Before(now) {
+			return &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: fmt.Sprintf("Escalation start time %s is in the past", startAt.UTC().Format(time.RFC3339)),
+				},
+			}, nil
+		}
+
+		if startAt.Time.After(now.Add(maxStartDelay)) {
+			return &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure,
+					Message: fmt.Sprintf(
+						"Escalation start time %s is too far ahead, escalations can be scheduled at most %s in advance",
+						startAt.UTC().Format(time.RFC3339),
+						maxStartDelay,
+					),
+				},
+			}, nil
+		}
+	}
+
+	policy, err := r.policyResolver.Resolve(&escalation.Spec)
 
 	switch {
 	case errors.IsNotFound(err):
@@ -64,14 +161,32 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 			"User submitted an escalation request refering to a policy that doesn't exist",
 			usernameAndPolicyTags(
 				req.UserInfo.Username,
-				escalation.Spec.PolicyName,
+				escalation.Spec.PolicyRef(),
 			)...,
 		)
 
 		return &admissionv1.AdmissionResponse{
 			Result: &metav1.Status{
 				Status:  metav1.StatusFailure,
-				Message: fmt.Sprintf("Unknown policy: %s", escalation.Spec.PolicyName),
+				Message: fmt.Sprintf("Unknown policy: %s", escalation.Spec.PolicyRef()),
+			},
+		}, nil
+
+	case isUnusablePolicy(err):
+		klog.InfoS(
+			"User submitted an escalation request refering to a policy that can't be used",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				escalation.Spec.PolicyRef(),
+				"err",
+				err,
+			)...,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("Policy %s can't be used, reason is: %s", escalation.Spec.PolicyRef(), err),
 			},
 		}, nil
 
@@ -81,11 +196,47 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 		// We're good.
 	}
 
-	if !userAllowed(*policy, req.UserInfo) {
+	var (
+		requestor = req.UserInfo
+		delegated = escalation.Spec.OnBehalfOf != ""
+		// A beneficiary that has to acknowledge the escalation is reviewed once its full identity is known.
+		awaitsAcknowledgement = delegated && requiresAcknowledgement(policy)
+	)
+
+	if delegated {
+		if !delegateAllowed(*policy, req.UserInfo) {
+			klog.InfoS(
+				"User attempted to escalate on behalf of another user, but is not part of the policy delegates",
+				usernameAndPolicyTags(
+					req.UserInfo.Username,
+					policy.Name,
+					"onBehalfOf",
+					escalation.Spec.OnBehalfOf,
+				)...,
+			)
+
+			return &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure,
+					Message: fmt.Sprintf(
+						"User %q is not allowed to escalate on behalf of another user with the escalation policy %q",
+						req.UserInfo.Username,
+						policy.Name,
+					),
+				},
+			}, nil
+		}
+
+		requestor = authenticationv1.UserInfo{Username: escalation.Spec.OnBehalfOf}
+	}
+
+	escalation.Spec.Requestor = requestor.Username
+
+	if !awaitsAcknowledgement && !userAllowed(*policy, requestor) {
 		klog.InfoS(
 			"User attempted to use an escalation policy, but is not part of the policy subjects",
 			usernameAndPolicyTags(
-				req.UserInfo.Username,
+				requestor.Username,
 				req.Name,
 			)...,
 		)
@@ -95,13 +246,38 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 				Status: metav1.StatusFailure,
 				Message: fmt.Sprintf(
 					"User %q is not allowed to use the escalation policy %q",
-					req.UserInfo.Username,
+					requestor.Username,
 					policy.Name,
 				),
 			},
 		}, nil
 	}
 
+	if reason := beneficiariesDenialReason(policy, &escalation); reason != "" {
+		klog.InfoS(
+			"User submitted an escalation request with invalid beneficiaries",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"beneficiaries",
+				escalation.Spec.Beneficiaries,
+				"reason",
+				reason,
+			)...,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure,
+				Message: fmt.Sprintf(
+					"Invalid beneficiaries for policy %q, reason is: %s",
+					policy.Name,
+					reason,
+				),
+			},
+		}, nil
+	}
+
 	if escalation.Spec.Duration.Duration > 0 && escalation.Spec.Duration.Duration > policy.Spec.Target.MaxDuration.Duration {
 		klog.InfoS(
 			"User attempted to escalate for a duration that exceeds the maximum duration of the policy",
@@ -127,7 +303,82 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 		}, nil
 	}
 
-	for _, grant := range policy.Spec.Target.Grants {
+	var conditions escalationpolicy.ConditionsResult
+
+	if !awaitsAcknowledgement {
+		conditions, err = evaluateConditions(ctx, r.escalationsLister, policy, &escalation, requestor, time.Now())
+	}
+
+	if err != nil {
+		klog.ErrorS(
+			err,
+			"Unable to evaluate the policy conditions",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+			)...,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure,
+				Message: fmt.Sprintf(
+					"Unable to evaluate the conditions of the escalation policy %q, reason is: %s",
+					policy.Name,
+					err,
+				),
+			},
+		}, nil
+	}
+
+	if conditions.Denied {
+		klog.InfoS(
+			"User submitted an escalation request denied by the policy conditions",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"reason",
+				conditions.Reason,
+			)...,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure,
+				Message: fmt.Sprintf(
+					"Escalation is denied by the conditions of the escalation policy %q, reason is: %s",
+					policy.Name,
+					conditions.Reason,
+				),
+			},
+		}, nil
+	}
+
+	grants, err := policy.Spec.Target.SelectGrants(escalation.Spec.Grants)
+	if err != nil {
+		klog.InfoS(
+			"User selected grants that are not part of the policy",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"grants",
+				escalation.Spec.Grants,
+			)...,
+		)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure,
+				Message: fmt.Sprintf(
+					"Invalid grant selection for policy %q, reason is: %s",
+					policy.Name,
+					err,
+				),
+			},
+		}, nil
+	}
+
+	for _, grant := range grants {
 		granter, err := r.grantFactory.Get(grant.Kind)
 		if err != nil {
 			klog.InfoS(
@@ -172,7 +423,7 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 		}
 	}
 
-	patch, err := genObjectPatch(req.UserInfo)
+	patch, err := genObjectPatch(req.UserInfo, escalation.Spec.OnBehalfOf)
 	if err != nil {
 		klog.ErrorS(
 			err,
@@ -189,9 +440,13 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 	klog.InfoS(
 		"User submitted an escalation request",
 		"requestor",
-		req.UserInfo.Username,
+		requestor.Username,
 		"policy",
 		escalation.Spec.PolicyName,
+		"delegated",
+		delegated,
+		"beneficiaries",
+		escalation.Spec.Beneficiaries,
 	)
 
 	return &admissionv1.AdmissionResponse{
@@ -204,18 +459,84 @@ func (r *createAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admi
 
 // userAllowed returns true when an user is allowed to use an escalation policy based
 // on the policy subjects.
-// An user is allowed if and only if one of the policy subject:
-// - is of Kind user with the same username.
-// - is of Kind group and the user belongs to this group.
+// An user is allowed if and only if one of the policy subjects matches the user, and none of the excluded subjects does.
+// A policy subject matches an user if:
+// - it is of Kind user with the same username.
+// - it is of Kind group and the user belongs to this group.
+// - it is of Kind service account and the user is authenticated as this service account.
+// - it has no Kind, and its extra attributes are not empty.
+// And if the user has one of the subject extra values for each of the subject extra keys.
+// An excluded subject that can't be matched, because its Kind is unknown or because it has neither Kind nor extra attributes, excludes every user.
 func userAllowed(policy kudov1alpha1.EscalationPolicy, user authenticationv1.UserInfo) bool {
-	for _, subject := range policy.Spec.Subjects {
+	return userInPolicySubjects(policy.Spec.Subjects, user) && !userExcluded(policy.Spec.ExcludedSubjects, user)
+}
+
+// userExcluded returns true if the user matches one of the excluded subjects, or if one of them can't be matched.
+// Policies are validated upon admission, this only protects the policies admitted before from letting excluded users in.
+func userExcluded(excludedSubjects []kudov1alpha1.PolicySubject, user authenticationv1.UserInfo) bool {
+	for _, subject := range excludedSubjects {
+		if !subject.IsMatchable() {
+			return true
+		}
+	}
+
+	return userInPolicySubjects(excludedSubjects, user)
+}
+
+func userInPolicySubjects(subjects []kudov1alpha1.PolicySubject, user authenticationv1.UserInfo) bool {
+	for _, subject := range subjects {
+		if subject.Kind == "" && len(subject.Extra) == 0 {
+			continue
+		}
+
+		if subject.Kind != "" && !userInSubjects([]rbacv1.Subject{subject.Subject}, user.Username, user.Groups) {
+			continue
+		}
+
+		if userHasExtra(user, subject.Extra) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userHasExtra returns true if the user has one of the wanted values for each of the wanted extra keys.
+func userHasExtra(user authenticationv1.UserInfo, extra map[string][]string) bool {
+	for key, values := range extra {
+		if !extraValueIn(user.Extra[key], values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func extraValueIn(userValues authenticationv1.ExtraValue, values []string) bool {
+	for _, value := range values {
+		if generics.Contains(userValues, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userInSubjects returns true if one of the subjects is of kind user with the same username,
+// of kind group and the user belongs to this group, or of kind service account and the user is this service account.
+func userInSubjects(subjects []rbacv1.Subject, username string, groups []string) bool {
+	for _, subject := range subjects {
 		switch subject.Kind {
 		case rbacv1.GroupKind:
-			if generics.Contains(user.Groups, subject.Name) {
+			if generics.Contains(groups, subject.Name) {
 				return true
 			}
 		case rbacv1.UserKind:
-			if subject.Name == user.Username {
+			if subject.Name == username {
+				return true
+			}
+		case rbacv1.ServiceAccountKind:
+			if kudov1alpha1.ServiceAccountUsername(subject.Namespace, subject.Name) == username {
 				return true
 			}
 		}
@@ -224,17 +545,41 @@ func userAllowed(policy kudov1alpha1.EscalationPolicy, user authenticationv1.Use
 	return false
 }
 
-func genObjectPatch(user authenticationv1.UserInfo) ([]byte, error) {
-	patch := []struct {
-		Op    string `json:"op"`
-		Path  string `json:"path"`
-		Value string `json:"value"`
-	}{
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// genObjectPatch records the identity of the user creating the escalation.
+// A delegate is recorded on its own, the escalation beneficiary becomes its requestor and its identity stays unknown until it acknowledges it.
+func genObjectPatch(user authenticationv1.UserInfo, onBehalfOf string) ([]byte, error) {
+	if onBehalfOf != "" {
+		return json.Marshal([]patchOperation{
+			{
+				Op:    "replace",
+				Path:  "/spec/requestor",
+				Value: onBehalfOf,
+			},
+			{
+				Op:    "add",
+				Path:  "/spec/delegate",
+				Value: user,
+			},
+		})
+	}
+
+	patch := []patchOperation{
 		{
 			Op:    "replace",
 			Path:  "/spec/requestor",
 			Value: user.Username,
 		},
+		{
+			Op:    "add",
+			Path:  "/spec/requestorInfo",
+			Value: user,
+		},
 	}
 
 	return json.Marshal(&patch)
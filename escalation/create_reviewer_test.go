@@ -34,14 +34,121 @@ var (
 				Name: "policy-1",
 			},
 			Spec: kudov1alpha1.EscalationPolicySpec{
-				Subjects: []rbacv1.Subject{
+				Subjects: []kudov1alpha1.PolicySubject{
 					{
-						Kind: rbacv1.GroupKind,
-						Name: "group-b@org.com",
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.GroupKind,
+							Name: "group-b@org.com",
+						},
+					},
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+					{
+						Subject: rbacv1.Subject{
+							Kind:      rbacv1.ServiceAccountKind,
+							Namespace: "ci",
+							Name:      "deployer",
+						},
+					},
+					{
+						Extra: map[string][]string{
+							"department": {"sre", "security"},
+							"mfa-level":  {"high"},
+						},
+					},
+				},
+				ExcludedSubjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.GroupKind,
+							Name: "group-b@org.com",
+						},
+						Extra: map[string][]string{
+							"employment-type": {"contractor"},
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-team",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+					{
+						Subject: rbacv1.Subject{
+							Kind:      rbacv1.ServiceAccountKind,
+							Namespace: "ci",
+							Name:      "deployer",
+						},
+					},
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-e",
+						},
+					},
+				},
+				ExcludedSubjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-e",
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-unknown-exclusion",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
 					},
+				},
+				ExcludedSubjects: []kudov1alpha1.PolicySubject{
 					{
-						Kind: rbacv1.UserKind,
-						Name: "user-c",
+						Subject: rbacv1.Subject{
+							Kind: "Users",
+							Name: "user-e",
+						},
 					},
 				},
 				Target: kudov1alpha1.EscalationTarget{
@@ -61,14 +168,18 @@ var (
 				Name: "policy-bad-grant-kind",
 			},
 			Spec: kudov1alpha1.EscalationPolicySpec{
-				Subjects: []rbacv1.Subject{
+				Subjects: []kudov1alpha1.PolicySubject{
 					{
-						Kind: rbacv1.GroupKind,
-						Name: "group-b@org.com",
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.GroupKind,
+							Name: "group-b@org.com",
+						},
 					},
 					{
-						Kind: rbacv1.UserKind,
-						Name: "user-c",
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
 					},
 				},
 				Target: kudov1alpha1.EscalationTarget{
@@ -78,6 +189,278 @@ var (
 				},
 			},
 		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-conditions",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.GroupKind,
+							Name: "group-b@org.com",
+						},
+					},
+				},
+				Conditions: []kudov1alpha1.PolicyCondition{
+					{
+						Expression: `reason.startsWith("INC-")`,
+						Action:     kudov1alpha1.ConditionActionAllow,
+					},
+					{
+						Expression: "size(activeEscalations) > 0",
+						Action:     kudov1alpha1.ConditionActionDeny,
+						Message:    "only one active escalation is allowed",
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-delegation",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+				},
+				Delegation: &kudov1alpha1.EscalationDelegation{
+					Delegates: []kudov1alpha1.PolicySubject{
+						{
+							Subject: rbacv1.Subject{
+								Kind: rbacv1.GroupKind,
+								Name: "oncall-leads@org.com",
+							},
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-delegation-groups",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.GroupKind,
+							Name: "group-b@org.com",
+						},
+					},
+				},
+				Delegation: &kudov1alpha1.EscalationDelegation{
+					Delegates: []kudov1alpha1.PolicySubject{
+						{
+							Subject: rbacv1.Subject{
+								Kind: rbacv1.GroupKind,
+								Name: "oncall-leads@org.com",
+							},
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-delegation-conditions",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+				},
+				Conditions: []kudov1alpha1.PolicyCondition{
+					{
+						Expression: `"contractors" in groups`,
+						Action:     kudov1alpha1.ConditionActionDeny,
+					},
+				},
+				Delegation: &kudov1alpha1.EscalationDelegation{
+					Delegates: []kudov1alpha1.PolicySubject{
+						{
+							Subject: rbacv1.Subject{
+								Kind: rbacv1.GroupKind,
+								Name: "oncall-leads@org.com",
+							},
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.EscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "policy-delegation-acknowledgement",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+				},
+				Delegation: &kudov1alpha1.EscalationDelegation{
+					Delegates: []kudov1alpha1.PolicySubject{
+						{
+							Subject: rbacv1.Subject{
+								Kind: rbacv1.GroupKind,
+								Name: "oncall-leads@org.com",
+							},
+						},
+					},
+					RequireAcknowledgement: true,
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(testGrantKind, struct{}{}),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.NamespacedEscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindNamespacedEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-policy",
+				Namespace: "team-a",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(
+							kudov1alpha1.GrantKindK8sRoleBinding,
+							kudov1alpha1.K8sRoleBindingGrant{
+								DefaultNamespace: "team-a",
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: rbacv1.GroupName,
+									Kind:     "Role",
+									Name:     "deployer",
+								},
+							},
+						),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.NamespacedEscalationPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindNamespacedEscalationPolicy,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-admin-policy",
+				Namespace: "team-a",
+			},
+			Spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: []kudov1alpha1.PolicySubject{
+					{
+						Subject: rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "user-c",
+						},
+					},
+				},
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(
+							kudov1alpha1.GrantKindK8sRoleBinding,
+							kudov1alpha1.K8sRoleBindingGrant{
+								DefaultNamespace: "team-a",
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: rbacv1.GroupName,
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						),
+					},
+				},
+			},
+		},
+		&kudov1alpha1.Escalation{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       kudov1alpha1.KindEscalation,
+				APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "active-escalation",
+			},
+			Spec: kudov1alpha1.EscalationSpec{
+				PolicyName: "policy-1",
+				Requestor:  "user-f",
+				Reason:     "INC-1233",
+			},
+			Status: kudov1alpha1.EscalationStatus{
+				State: kudov1alpha1.StateAccepted,
+			},
+		},
 	}
 )
 
@@ -193,15 +576,16 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 			},
 		},
 		{
-			desc: "denies if the refered policy has an unsupported grant kind",
+			desc: "denies if the selected grants are not part of the policy",
 			request: &admissionv1.AdmissionRequest{
 				Object: runtime.RawExtension{
 					Raw: webhooktesting.EncodeObject(
 						t,
 						kudov1alpha1.Escalation{
 							Spec: kudov1alpha1.EscalationSpec{
-								PolicyName: "policy-bad-grant-kind",
+								PolicyName: "policy-1",
 								Reason:     "I need moar power",
+								Grants:     []string{"edit"},
 							},
 						},
 					).Bytes(),
@@ -214,12 +598,12 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 				Allowed: false,
 				Result: &metav1.Status{
 					Status:  metav1.StatusFailure,
-					Message: "Policy \"policy-bad-grant-kind\" refers to an unsuported grant kind \"nonsense\"",
+					Message: "Invalid grant selection for policy \"policy-1\", reason is: unknown grant \"edit\"",
 				},
 			},
 		},
 		{
-			desc: "denies if the escalation is not compatible with grant",
+			desc: "denies if the escalation is created released",
 			request: &admissionv1.AdmissionRequest{
 				Object: runtime.RawExtension{
 					Raw: webhooktesting.EncodeObject(
@@ -228,6 +612,7 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 							Spec: kudov1alpha1.EscalationSpec{
 								PolicyName: "policy-1",
 								Reason:     "I need moar power",
+								Released:   true,
 							},
 						},
 					).Bytes(),
@@ -236,17 +621,16 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 					Username: "user-c",
 				},
 			},
-			grantValidateErr: errors.New("hahahaha"),
 			wantResponse: &admissionv1.AdmissionResponse{
 				Allowed: false,
 				Result: &metav1.Status{
 					Status:  metav1.StatusFailure,
-					Message: "Escalation is impossible to grant, reason is: hahahaha",
+					Message: "An escalation can't be released at creation",
 				},
 			},
 		},
 		{
-			desc: "allows users by username",
+			desc: "denies if the escalation is created revoked",
 			request: &admissionv1.AdmissionRequest{
 				Object: runtime.RawExtension{
 					Raw: webhooktesting.EncodeObject(
@@ -255,6 +639,9 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 							Spec: kudov1alpha1.EscalationSpec{
 								PolicyName: "policy-1",
 								Reason:     "I need moar power",
+								Revocation: &kudov1alpha1.EscalationRevocation{
+									Reason: "Revoked",
+								},
 							},
 						},
 					).Bytes(),
@@ -264,14 +651,15 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 				},
 			},
 			wantResponse: &admissionv1.AdmissionResponse{
-				Allowed:   true,
-				Result:    &metav1.Status{Status: metav1.StatusSuccess},
-				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
-				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"}]`),
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "An escalation can't be revoked at creation",
+				},
 			},
 		},
 		{
-			desc: "allows users by group membership",
+			desc: "denies if the escalation is created with extensions",
 			request: &admissionv1.AdmissionRequest{
 				Object: runtime.RawExtension{
 					Raw: webhooktesting.EncodeObject(
@@ -280,20 +668,945 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 							Spec: kudov1alpha1.EscalationSpec{
 								PolicyName: "policy-1",
 								Reason:     "I need moar power",
+								Extensions: []kudov1alpha1.EscalationExtension{
+									{
+										Duration: metav1.Duration{Duration: time.Hour},
+										Reason:   "Even moar",
+									},
+								},
 							},
 						},
 					).Bytes(),
 				},
 				UserInfo: authenticationv1.UserInfo{
-					Username: "user-b",
-					Groups:   []string{"group-b@org.com"},
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Extensions can only be requested once the escalation is accepted",
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation starts in the past",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+								StartAt:    &metav1.Time{Time: time.Date(2022, time.October, 11, 2, 0, 0, 0, time.UTC)},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Escalation start time 2022-10-11T02:00:00Z is in the past",
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation starts too far ahead",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+								StartAt:    &metav1.Time{Time: time.Date(3022, time.October, 11, 2, 0, 0, 0, time.UTC)},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Escalation start time 3022-10-11T02:00:00Z is too far ahead, escalations can be scheduled at most 720h0m0s in advance",
+				},
+			},
+		},
+		{
+			desc: "allows escalations scheduled ahead",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+								StartAt:    &metav1.Time{Time: time.Now().Add(time.Hour)},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-c"}}]`),
+			},
+		},
+		{
+			desc: "denies if the refered policy has an unsupported grant kind",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-bad-grant-kind",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Policy \"policy-bad-grant-kind\" refers to an unsuported grant kind \"nonsense\"",
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation is not compatible with grant",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			grantValidateErr: errors.New("hahahaha"),
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Escalation is impossible to grant, reason is: hahahaha",
+				},
+			},
+		},
+		{
+			desc: "allows users by username",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-c"}}]`),
+			},
+		},
+		{
+			desc: "allows users by group membership",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-b",
+					Groups:   []string{"group-b@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-b"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-b","groups":["group-b@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "denies if the escalation is created approved",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+								ApprovedBy: "user-c",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "An escalation can't be approved at creation",
+				},
+			},
+		},
+		{
+			desc: "denies if none of the policy allow conditions match",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-conditions",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-b",
+					Groups:   []string{"group-b@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Escalation is denied by the conditions of the escalation policy \"policy-conditions\", reason is: none of the policy allow conditions matched",
+				},
+			},
+		},
+		{
+			desc: "denies if one of the policy deny conditions matches",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-conditions",
+								Reason:     "INC-1234",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-f",
+					Groups:   []string{"group-b@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Escalation is denied by the conditions of the escalation policy \"policy-conditions\", reason is: only one active escalation is allowed",
+				},
+			},
+		},
+		{
+			desc: "allows users matching the policy conditions",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-conditions",
+								Reason:     "INC-1234",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-b",
+					Groups:   []string{"group-b@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-b"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-b","groups":["group-b@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "allows users by extra attributes",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-d",
+					Extra: map[string]authenticationv1.ExtraValue{
+						"department": {"security"},
+						"mfa-level":  {"high"},
+					},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-d"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-d","extra":{"department":["security"],"mfa-level":["high"]}}}]`),
+			},
+		},
+		{
+			desc: "denies users missing one of the extra attributes",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-d",
+					Extra: map[string]authenticationv1.ExtraValue{
+						"department": {"security"},
+						"mfa-level":  {"low"},
+					},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "User \"user-d\" is not allowed to use the escalation policy \"policy-1\"",
+				},
+			},
+		},
+		{
+			desc: "denies excluded users, even if they match one of the subjects",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-e",
+					Groups:   []string{"group-b@org.com"},
+					Extra: map[string]authenticationv1.ExtraValue{
+						"employment-type": {"contractor"},
+					},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "User \"user-e\" is not allowed to use the escalation policy \"policy-1\"",
+				},
+			},
+		},
+		{
+			desc: "denies every user if the policy excludes subjects of an unknown kind",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-unknown-exclusion",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "User \"user-c\" is not allowed to use the escalation policy \"policy-unknown-exclusion\"",
+				},
+			},
+		},
+		{
+			desc: "allows service accounts by service account subject",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:ci:deployer",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"system:serviceaccount:ci:deployer"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"system:serviceaccount:ci:deployer"}}]`),
+			},
+		},
+		{
+			desc: "denies service accounts of another namespace",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "I need moar power",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:default:deployer",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "User \"system:serviceaccount:default:deployer\" is not allowed to use the escalation policy \"policy-1\"",
+				},
+			},
+		},
+		{
+			desc: "denies if the user is not a delegate of the policy",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-1",
+								Reason:     "user-c is locked out",
+								OnBehalfOf: "user-c",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-d",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `User "user-d" is not allowed to escalate on behalf of another user with the escalation policy "policy-1"`,
+				},
+			},
+		},
+		{
+			desc: "denies if the beneficiary is not allowed to use the policy",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation",
+								Reason:     "user-c is locked out",
+								OnBehalfOf: "user-x",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `User "user-x" is not allowed to use the escalation policy "policy-delegation"`,
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation is created on behalf of its own delegate",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation",
+								Reason:     "user-c is locked out",
+								OnBehalfOf: "lead",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "An escalation can't be created on behalf of its own delegate",
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation is created acknowledged",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:   "policy-delegation",
+								Reason:       "user-c is locked out",
+								OnBehalfOf:   "user-c",
+								Acknowledged: true,
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "An escalation can't be acknowledged at creation",
+				},
+			},
+		},
+		{
+			desc: "denies if the escalation is created with a delegate",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation",
+								Reason:     "user-c is locked out",
+								Delegate:   &authenticationv1.UserInfo{Username: "lead"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "The delegate and the requestor identity of an escalation are recorded by Kudo",
+				},
+			},
+		},
+		{
+			desc: "allows delegates to escalate on behalf of an allowed beneficiary",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation",
+								Reason:     "user-c is locked out",
+								OnBehalfOf: "user-c",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"},{"op":"add","path":"/spec/delegate","value":{"username":"lead","groups":["oncall-leads@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "allows delegates to escalate on behalf of a beneficiary that has to acknowledge the escalation",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation-acknowledgement",
+								Reason:     "user-c is locked out",
+								OnBehalfOf: "user-x",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-x"},{"op":"add","path":"/spec/delegate","value":{"username":"lead","groups":["oncall-leads@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "allows delegates to escalate on behalf of a beneficiary that has to acknowledge the escalation, if the policy subjects are groups",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation-groups",
+								Reason:     "user-x is locked out",
+								OnBehalfOf: "user-x",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-x"},{"op":"add","path":"/spec/delegate","value":{"username":"lead","groups":["oncall-leads@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "allows delegates to escalate on behalf of a beneficiary that has to acknowledge the escalation, if the policy has conditions",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName: "policy-delegation-conditions",
+								Reason:     "user-x is locked out",
+								OnBehalfOf: "user-x",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "lead",
+					Groups:   []string{"oncall-leads@org.com"},
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-x"},{"op":"add","path":"/spec/delegate","value":{"username":"lead","groups":["oncall-leads@org.com"]}}]`),
+			},
+		},
+		{
+			desc: "allows escalations granting permissions to several beneficiaries",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:    "policy-team",
+								Reason:        "War room",
+								Beneficiaries: []string{"system:serviceaccount:ci:deployer"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed:   true,
+				Result:    &metav1.Status{Status: metav1.StatusSuccess},
+				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-c"}}]`),
+			},
+		},
+		{
+			desc: "denies if one of the beneficiaries is not allowed to use the policy",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:    "policy-team",
+								Reason:        "War room",
+								Beneficiaries: []string{"system:serviceaccount:ci:deployer", "user-d"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `Invalid beneficiaries for policy "policy-team", reason is: beneficiary "user-d" is not allowed to use the escalation policy "policy-team"`,
+				},
+			},
+		},
+		{
+			desc: "denies if the requestor is listed as a beneficiary",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:    "policy-team",
+								Reason:        "War room",
+								Beneficiaries: []string{"user-c"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `Invalid beneficiaries for policy "policy-team", reason is: beneficiary "user-c" is listed more than once`,
+				},
+			},
+		},
+		{
+			desc: "denies if one of the beneficiaries is excluded from the policy",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:    "policy-team",
+								Reason:        "War room",
+								Beneficiaries: []string{"user-e"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `Invalid beneficiaries for policy "policy-team", reason is: beneficiary "user-e" is not allowed to use the escalation policy "policy-team"`,
+				},
+			},
+		},
+		{
+			desc: "denies beneficiaries if the policy excludes subjects that are not users",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:    "policy-1",
+								Reason:        "War room",
+								Beneficiaries: []string{"system:serviceaccount:ci:deployer"},
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `Invalid beneficiaries for policy "policy-1", reason is: the escalation policy "policy-1" excludes subjects that are not users, it can't grant permissions to beneficiaries`,
+				},
+			},
+		},
+		{
+			desc: "allows escalations refering to a namespaced policy",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:      "team-policy",
+								PolicyNamespace: "team-a",
+								Reason:          "Deploy a hotfix",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
 				},
 			},
 			wantResponse: &admissionv1.AdmissionResponse{
 				Allowed:   true,
 				Result:    &metav1.Status{Status: metav1.StatusSuccess},
 				PatchType: generics.Ptr(admissionv1.PatchTypeJSONPatch),
-				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-b"}]`),
+				Patch:     []byte(`[{"op":"replace","path":"/spec/requestor","value":"user-c"},{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-c"}}]`),
+			},
+		},
+		{
+			desc: "denies if the namespaced policy is not known",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:      "team-policy",
+								PolicyNamespace: "team-b",
+								Reason:          "Deploy a hotfix",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Unknown policy: team-b/team-policy",
+				},
+			},
+		},
+		{
+			desc: "denies if the namespaced policy binds a cluster role that is not allowed",
+			request: &admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.Escalation{
+							Spec: kudov1alpha1.EscalationSpec{
+								PolicyName:      "team-admin-policy",
+								PolicyNamespace: "team-a",
+								Reason:          "Deploy a hotfix",
+							},
+						},
+					).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: "user-c",
+				},
+			},
+			wantResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: `Policy team-a/team-admin-policy can't be used, reason is: namespaced policy target is not confined to its namespace, grant 0 binds the cluster role "admin", allowed cluster roles are [view]`,
+				},
 			},
 		},
 	}
@@ -308,7 +1621,10 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 					fakeClient,
 					60*time.Second,
 				)
-				escalationPolicyInformer = informersFactories.K8s().V1alpha1().EscalationPolicies()
+				escalationPolicyInformer           = informersFactories.K8s().V1alpha1().EscalationPolicies()
+				namespacedEscalationPolicyInformer = informersFactories.K8s().V1alpha1().NamespacedEscalationPolicies()
+				escalationPolicyTemplateInformer   = informersFactories.K8s().V1alpha1().EscalationPolicyTemplates()
+				escalationInformer                 = informersFactories.K8s().V1alpha1().Escalations()
 
 				dummyGranter = mockGranter{
 					ValidateFn: func(_ *kudov1alpha1.Escalation, _ kudov1alpha1.ValueWithKind) error {
@@ -317,9 +1633,16 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 				}
 
 				reviewer = escalation.NewCreateAdmissionReviewer(
-					escalationPolicyInformer.Lister(),
+					escalation.NewPolicyResolver(
+						escalationPolicyInformer.Lister(),
+						namespacedEscalationPolicyInformer.Lister(),
+						escalationPolicyTemplateInformer.Lister(),
+						testAllowedClusterRoles,
+					),
+					escalationInformer.Lister(),
 					grant.StaticFactory{
-						testGrantKind: injectMockGranter(&dummyGranter),
+						testGrantKind:                        injectMockGranter(&dummyGranter),
+						kudov1alpha1.GrantKindK8sRoleBinding: injectMockGranter(&dummyGranter),
 					},
 				)
 			)
@@ -328,7 +1651,13 @@ func TestCreateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
 
 			informersFactories.Start(ctx.Done())
 
-			if ok := cache.WaitForCacheSync(ctx.Done(), escalationPolicyInformer.Informer().HasSynced); !ok {
+			if ok := cache.WaitForCacheSync(
+				ctx.Done(),
+				escalationPolicyInformer.Informer().HasSynced,
+				namespacedEscalationPolicyInformer.Informer().HasSynced,
+				escalationPolicyTemplateInformer.Informer().HasSynced,
+				escalationInformer.Informer().HasSynced,
+			); !ok {
 				t.Fatal("Cache sync failed, failing test...")
 			}
 
@@ -0,0 +1,48 @@
+package escalation
+
+import (
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+// delegateAllowed returns true if an user is allowed to create escalations on behalf of another user with the policy.
+func delegateAllowed(policy kudov1alpha1.EscalationPolicy, user authenticationv1.UserInfo) bool {
+	return policy.Spec.Delegation != nil && userInPolicySubjects(policy.Spec.Delegation.Delegates, user)
+}
+
+// requiresAcknowledgement returns true if the policy makes delegated escalations wait for their beneficiary to acknowledge them.
+// Delegates only know the beneficiary username: an acknowledgement is always required if the policy subjects can't be reviewed
+// from a username alone, or if the policy has conditions, which can depend on the beneficiary groups and extra attributes.
+func requiresAcknowledgement(policy *kudov1alpha1.EscalationPolicy) bool {
+	return policy.Spec.Delegation != nil &&
+		(policy.Spec.Delegation.RequireAcknowledgement || len(policy.Spec.Conditions) > 0 || !subjectsMatchUsernames(policy))
+}
+
+// subjectsMatchUsernames returns true if whether a user is allowed to use the policy only depends on their username:
+// the policy subjects are all users without extra attributes, and no subject is excluded.
+func subjectsMatchUsernames(policy *kudov1alpha1.EscalationPolicy) bool {
+	if len(policy.Spec.ExcludedSubjects) > 0 {
+		return false
+	}
+
+	for _, subject := range policy.Spec.Subjects {
+		if subject.Kind != rbacv1.UserKind || len(subject.Extra) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// awaitsAcknowledgement returns true if a delegated escalation can't proceed until its beneficiary acknowledges it.
+// Until then, only the beneficiary username is known.
+func awaitsAcknowledgement(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) bool {
+	return esc.Spec.IsDelegated() && !esc.Spec.Acknowledged && requiresAcknowledgement(policy)
+}
+
+// isDelegate returns true if the user created the escalation on behalf of its requestor.
+func isDelegate(esc *kudov1alpha1.Escalation, username string) bool {
+	return esc.Spec.IsDelegated() && esc.Spec.Delegate.Username == username
+}
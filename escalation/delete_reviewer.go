@@ -0,0 +1,69 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/klog/v2"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+type deleteAdmissionReviewer struct {
+	adminSubjects []rbacv1.Subject
+}
+
+func NewDeleteAdmissionReviewer(adminSubjects []rbacv1.Subject) *deleteAdmissionReviewer {
+	return &deleteAdmissionReviewer{adminSubjects: adminSubjects}
+}
+
+// ReviewAdmission makes sure that an escalation is finished before being deleted, so its grants are reclaimed
+// and its history is kept. Only admin subjects are allowed to delete an escalation that is not finished.
+func (r *deleteAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	var escalation kudov1alpha1.Escalation
+
+	if err := json.Unmarshal(req.OldObject.Raw, &escalation); err != nil {
+		klog.ErrorS(err, "Can't unmarhal deleted object")
+
+		return nil, err
+	}
+
+	if escalation.Status.IsTerminal() {
+		return allowedResponse(nil)
+	}
+
+	if userInSubjects(r.adminSubjects, req.UserInfo.Username, req.UserInfo.Groups) {
+		klog.InfoS(
+			"Admin deleted an escalation that is not finished",
+			"username",
+			req.UserInfo.Username,
+			"escalation",
+			escalation.Name,
+			"state",
+			escalation.Status.State,
+		)
+
+		return allowedResponse(nil)
+	}
+
+	klog.InfoS(
+		"User attempted to delete an escalation that is not finished",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		escalation.Name,
+		"state",
+		escalation.Status.State,
+	)
+
+	return deniedResponse(
+		fmt.Sprintf(
+			"Escalation %q can't be deleted in state %q, please release or revoke it first",
+			escalation.Name,
+			escalation.Status.State,
+		),
+	), nil
+}
@@ -0,0 +1,127 @@
+package escalation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/jlevesy/kudo/escalation"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/webhooksupport/webhooktesting"
+)
+
+func TestDeleteEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
+	var (
+		adminSubjects = []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, Name: "admin"},
+			{Kind: rbacv1.GroupKind, Name: "admins@org.com"},
+		}
+
+		escalationInState = func(state kudov1alpha1.EscalationState) kudov1alpha1.Escalation {
+			return kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "escalation-1",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: "policy",
+					Requestor:  "user-a",
+					Reason:     "Needs access",
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State: state,
+				},
+			}
+		}
+
+		allowed = &admissionv1.AdmissionResponse{
+			Allowed: true,
+			Result:  &metav1.Status{Status: metav1.StatusSuccess},
+		}
+
+		denied = func(message string) *admissionv1.AdmissionResponse {
+			return &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: message,
+				},
+			}
+		}
+	)
+
+	testCases := []struct {
+		desc         string
+		username     string
+		groups       []string
+		esc          kudov1alpha1.Escalation
+		wantResponse *admissionv1.AdmissionResponse
+	}{
+		{
+			desc:         "allows to delete an expired escalation",
+			username:     "user-a",
+			esc:          escalationInState(kudov1alpha1.StateExpired),
+			wantResponse: allowed,
+		},
+		{
+			desc:         "allows to delete a denied escalation",
+			username:     "user-a",
+			esc:          escalationInState(kudov1alpha1.StateDenied),
+			wantResponse: allowed,
+		},
+		{
+			desc:         "denies to delete an accepted escalation",
+			username:     "user-a",
+			esc:          escalationInState(kudov1alpha1.StateAccepted),
+			wantResponse: denied(`Escalation "escalation-1" can't be deleted in state "ACCEPTED", please release or revoke it first`),
+		},
+		{
+			desc:         "denies to delete a pending escalation",
+			username:     "user-a",
+			groups:       []string{"users@org.com"},
+			esc:          escalationInState(kudov1alpha1.StatePending),
+			wantResponse: denied(`Escalation "escalation-1" can't be deleted in state "PENDING", please release or revoke it first`),
+		},
+		{
+			desc:         "allows an admin user to delete an accepted escalation",
+			username:     "admin",
+			esc:          escalationInState(kudov1alpha1.StateAccepted),
+			wantResponse: allowed,
+		},
+		{
+			desc:         "allows a member of an admin group to delete an accepted escalation",
+			username:     "user-b",
+			groups:       []string{"admins@org.com"},
+			esc:          escalationInState(kudov1alpha1.StateAccepted),
+			wantResponse: allowed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			reviewer := escalation.NewDeleteAdmissionReviewer(adminSubjects)
+
+			gotResp, err := reviewer.ReviewAdmission(
+				context.Background(),
+				&admissionv1.AdmissionRequest{
+					Operation: admissionv1.Delete,
+					OldObject: runtime.RawExtension{
+						Raw: webhooktesting.EncodeObject(t, testCase.esc).Bytes(),
+					},
+					UserInfo: authenticationv1.UserInfo{
+						Username: testCase.username,
+						Groups:   testCase.groups,
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantResponse, gotResp)
+		})
+	}
+}
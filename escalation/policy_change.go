@@ -0,0 +1,316 @@
+package escalation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/klog/v2"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+// reviewPolicyChange makes sure that an escalation is still allowed by its policy, if the policy has changed since the escalation was created.
+// The escalation is denied if the change narrows, widens or invalidates it. Otherwise, the grants that have changed are recorded along with
+// the policy snapshot and reclaimed to be created again, and the escalation follows the new policy version.
+// It returns true if the escalation status has to be updated.
+func (c *Controller) reviewPolicyChange(ctx context.Context, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationStatus, bool) {
+	// The policy has been deleted and created again, it is a different policy.
+	if policy.UID != esc.Status.PolicyUID {
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(DeniedPolicyChangedStateDetails),
+		), true
+	}
+
+	version := policyVersion(policy)
+	if version == esc.Status.PolicyVersion {
+		return statusZero, false
+	}
+
+	if legacyPolicyVersionUnchanged(esc, policy) {
+		klog.InfoS("Escalation references a policy version recorded by a previous release, following the current one", "escalation", esc.Name, "policy", policy.Name)
+
+		return esc.Status.TransitionTo(
+			esc.Status.State,
+			kudov1alpha1.WithPolicyInfo(policy.UID, version),
+		), true
+	}
+
+	changedGrants, reason := c.policyChangeDenialReason(ctx, esc, policy)
+	if reason != "" {
+		klog.InfoS("Policy change narrows the escalation, denying it", "escalation", esc.Name, "policy", policy.Name, "reason", reason)
+
+		return esc.Status.TransitionTo(
+			kudov1alpha1.StateDenied,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation references a policy that has changed, all granted permissions are reclaimed. Reason is: %s",
+					reason,
+				),
+			),
+		), true
+	}
+
+	grantRefs, reclaimed, err := c.reclaimChangedGrants(ctx, esc, changedGrants)
+	if err != nil {
+		return esc.Status.TransitionTo(
+			esc.Status.State,
+			kudov1alpha1.WithDetails(
+				fmt.Sprintf(
+					"This escalation references a policy that has changed, but changed grants have been partially reclaimed. Reason is: %s",
+					err.Error(),
+				),
+			),
+			kudov1alpha1.WithNewGrantRefs(grantRefs),
+		), true
+	}
+
+	klog.InfoS("Escalation policy has changed, following the new version", "escalation", esc.Name, "policy", policy.Name, "reclaimedGrants", reclaimed)
+
+	mutations := []kudov1alpha1.TransitionMutation{
+		kudov1alpha1.WithPolicyInfo(policy.UID, version),
+		kudov1alpha1.WithNewGrantRefs(grantRefs),
+	}
+
+	if len(changedGrants) > 0 {
+		currentGrants := make([]kudov1alpha1.ValueWithKind, len(escalationGrants(esc)))
+
+		for i, grant := range escalationGrants(esc) {
+			if changed, ok := changedGrants[i]; ok {
+				grant = changed
+			}
+
+			grant.DeepCopyInto(&currentGrants[i])
+		}
+
+		mutations = append(mutations, kudov1alpha1.WithCurrentGrants(currentGrants))
+	}
+
+	if reclaimed > 0 {
+		mutations = append(mutations, kudov1alpha1.WithDetails(PolicyChangedGrantsRecreatedStateDetails))
+	}
+
+	return esc.Status.TransitionTo(esc.Status.State, mutations...), true
+}
+
+// legacyPolicyVersionUnchanged returns true if the escalation references a policy version recorded by a previous release, when policy
+// versions were resource versions, and the policy spec hasn't changed since then: either the policy resource version is still the same,
+// or the policy spec has never been updated.
+func legacyPolicyVersionUnchanged(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) bool {
+	if esc.Status.PolicyVersion == "" {
+		return false
+	}
+
+	return esc.Status.PolicyVersion == policy.ResourceVersion ||
+		(policy.Generation == 1 && policy.Spec.TemplateRef == nil)
+}
+
+// policyChangeDenialReason returns a non empty reason if the policy does not allow the escalation anymore.
+// Otherwise, it returns the new definition of the escalation grants that have changed in the policy, by index.
+func (c *Controller) policyChangeDenialReason(ctx context.Context, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) (map[int]kudov1alpha1.ValueWithKind, string) {
+	snapshot := esc.Status.PolicySnapshot
+
+	// The identity of requestors is reviewed against the policy subjects on its own. If it hasn't been recorded, the requestor groups
+	// are unknown: only requestors listed by name in the policy subjects are known to be still allowed.
+	if esc.Spec.RequestorInfo == nil &&
+		(snapshot == nil || !sameJSON(snapshot.Subjects, policy.Spec.Subjects)) &&
+		!userAllowed(*policy, authenticationv1.UserInfo{Username: esc.Spec.Requestor}) {
+		return nil, "the policy subjects have changed, and the requestor might not be allowed to use it anymore"
+	}
+
+	if maxDuration := policy.Spec.Target.MaxDuration.Duration; maxDuration > 0 {
+		duration := escalationDuration(esc, policy)
+		if !esc.Status.AcceptedAt.IsZero() {
+			duration = esc.Status.ExpiresAt.Sub(esc.Status.AcceptedAt.Time)
+		}
+
+		if duration > maxDuration {
+			return nil, fmt.Sprintf("the escalation lasts longer than the policy maximum duration [%s]", maxDuration)
+		}
+	}
+
+	policyGrants, err := policy.Spec.Target.SelectGrants(esc.Spec.Grants)
+	if err != nil {
+		return nil, fmt.Sprintf("invalid grant selection, %s", err)
+	}
+
+	grants := policyGrants
+	changedGrants := make(map[int]kudov1alpha1.ValueWithKind)
+
+	if snapshot != nil {
+		// Grants are created from the snapshot, each of them must still be part of the policy, as is or changed.
+		currentGrants := escalationGrants(esc)
+		grants = make([]kudov1alpha1.ValueWithKind, len(currentGrants))
+
+		for i, grant := range currentGrants {
+			newGrant, ok := changedGrant(currentGrants, policyGrants, i)
+			if !ok {
+				return nil, "some of the escalation grants have been removed from the policy"
+			}
+
+			if !sameJSON(grant, newGrant) {
+				// The escalation has been reviewed, and possibly approved, for the permissions it was granted so far.
+				widened, err := c.grantWidens(ctx, esc, grant, newGrant)
+				if err != nil {
+					return nil, fmt.Sprintf("grant %d can't be compared with its new definition, %s", i, err)
+				}
+
+				if widened {
+					return nil, fmt.Sprintf("grant %d gives more permissions than the escalation was allowed", i)
+				}
+
+				changedGrants[i] = newGrant
+			}
+
+			grants[i] = newGrant
+		}
+	} else if len(grants) < len(esc.Status.GrantRefs) {
+		return nil, "some of the escalation grants have been removed from the policy"
+	}
+
+	// A grant that isn't valid for the escalation anymore has been narrowed.
+	for i, grant := range grants {
+		granter, err := c.granterFactory.Get(grant.Kind)
+		if err != nil {
+			return nil, fmt.Sprintf("grant %d is not supported, %s", i, err)
+		}
+
+		if err := granter.Validate(ctx, esc, grant); err != nil {
+			return nil, fmt.Sprintf("grant %d is not valid anymore, %s", i, err)
+		}
+	}
+
+	return changedGrants, ""
+}
+
+// grantWidens returns true if the new definition of a grant gives more permissions to the escalation than the previous one.
+func (c *Controller) grantWidens(ctx context.Context, esc *kudov1alpha1.Escalation, previous, current kudov1alpha1.ValueWithKind) (bool, error) {
+	granter, err := c.granterFactory.Get(current.Kind)
+	if err != nil {
+		return false, err
+	}
+
+	return granter.Widens(ctx, esc, previous, current)
+}
+
+// escalationGrants returns the grants the escalation follows: the ones redefined by a policy change if any, or the policy snapshot ones.
+func escalationGrants(esc *kudov1alpha1.Escalation) []kudov1alpha1.ValueWithKind {
+	if esc.Status.CurrentGrants != nil {
+		return esc.Status.CurrentGrants
+	}
+
+	return esc.Status.PolicySnapshot.Target.Grants
+}
+
+// changedGrant returns the current definition of the escalation grant at the given index, among the policy grants selected by the escalation.
+// The escalation grants are the ones of the policy snapshot, or their redefinition by a previous policy change.
+// A grant that is still part of the policy as is is unchanged. Otherwise, a named grant is matched by name and other grants by index,
+// as long as the matching grant is of the same kind and isn't the unchanged definition of another snapshot grant.
+func changedGrant(escalationGrants, policyGrants []kudov1alpha1.ValueWithKind, i int) (kudov1alpha1.ValueWithKind, bool) {
+	grant := escalationGrants[i]
+
+	if containsGrant(policyGrants, grant) {
+		return grant, true
+	}
+
+	name := grantName(grant)
+
+	for j, candidate := range policyGrants {
+		candidateName := grantName(candidate)
+
+		switch {
+		case name != "" && candidateName != name:
+			continue
+		case name == "" && (candidateName != "" || j != i):
+			continue
+		case candidate.Kind != grant.Kind || containsGrant(escalationGrants, candidate):
+			return kudov1alpha1.ValueWithKind{}, false
+		default:
+			return candidate, true
+		}
+	}
+
+	return kudov1alpha1.ValueWithKind{}, false
+}
+
+// reclaimChangedGrants reclaims the created grants whose definition has changed in the policy, so they're created again.
+func (c *Controller) reclaimChangedGrants(ctx context.Context, esc *kudov1alpha1.Escalation, changedGrants map[int]kudov1alpha1.ValueWithKind) ([]kudov1alpha1.EscalationGrantRef, int, error) {
+	var (
+		changedIdx   []int
+		changedRefs  []kudov1alpha1.EscalationGrantRef
+		newGrantRefs = make([]kudov1alpha1.EscalationGrantRef, len(esc.Status.GrantRefs))
+	)
+
+	copy(newGrantRefs, esc.Status.GrantRefs)
+
+	for i, ref := range esc.Status.GrantRefs {
+		if _, changed := changedGrants[i]; !changed || ref.Status != kudov1alpha1.GrantStatusCreated {
+			continue
+		}
+
+		changedIdx = append(changedIdx, i)
+		changedRefs = append(changedRefs, ref)
+	}
+
+	if len(changedRefs) == 0 {
+		return newGrantRefs, 0, nil
+	}
+
+	reclaimedRefs, err := c.reclaimGrantRefs(ctx, esc.Name, changedRefs)
+	if err != nil {
+		return esc.Status.GrantRefs, 0, err
+	}
+
+	for i, idx := range changedIdx {
+		newGrantRefs[idx] = reclaimedRefs[i]
+	}
+
+	return newGrantRefs, len(changedRefs), nil
+}
+
+// policySnapshot copies the policy spec, restricted to the grants selected by the escalation.
+// If the selection is invalid, no snapshot is taken and the escalation is denied once accepted.
+func policySnapshot(esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy) *kudov1alpha1.EscalationPolicySpec {
+	grants, err := policy.Spec.Target.SelectGrants(esc.Spec.Grants)
+	if err != nil {
+		return nil
+	}
+
+	snapshot := policy.Spec.DeepCopy()
+	snapshot.Target.Grants = make([]kudov1alpha1.ValueWithKind, len(grants))
+
+	for i := range grants {
+		grants[i].DeepCopyInto(&snapshot.Target.Grants[i])
+	}
+
+	return snapshot
+}
+
+func grantName(grant kudov1alpha1.ValueWithKind) string {
+	commonSpec, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.GrantCommonSpec](grant)
+	if err != nil {
+		return ""
+	}
+
+	return commonSpec.Name
+}
+
+func containsGrant(grants []kudov1alpha1.ValueWithKind, grant kudov1alpha1.ValueWithKind) bool {
+	for _, candidate := range grants {
+		if sameJSON(candidate, grant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameJSON(a, b any) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
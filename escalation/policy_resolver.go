@@ -0,0 +1,159 @@
+package escalation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jlevesy/kudo/escalationpolicy"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	kudolisters "github.com/jlevesy/kudo/pkg/generated/listers/k8s.kudo.dev/v1alpha1"
+)
+
+// templateGenerationAnnotation records the generation of the template an effective policy inherits from.
+// It is only set on the policies returned by the resolver, and never written to the API server.
+const templateGenerationAnnotation = "k8s.kudo.dev/template-generation"
+
+var (
+	ErrPolicyNotConfined      = errors.New("namespaced policy target is not confined to its namespace")
+	ErrPolicyTemplateUnusable = errors.New("policy template can't be applied")
+)
+
+type EscalationPoliciesGetter interface {
+	Get(name string) (*kudov1alpha1.EscalationPolicy, error)
+}
+
+type NamespacedEscalationPoliciesGetter interface {
+	NamespacedEscalationPolicies(namespace string) kudolisters.NamespacedEscalationPolicyNamespaceLister
+}
+
+// PolicyResolver finds the effective policy an escalation refers to.
+type PolicyResolver interface {
+	Resolve(spec *kudov1alpha1.EscalationSpec) (*kudov1alpha1.EscalationPolicy, error)
+}
+
+type policyResolver struct {
+	policiesGetter           EscalationPoliciesGetter
+	namespacedPoliciesGetter NamespacedEscalationPoliciesGetter
+	templatesGetter          escalationpolicy.EscalationPolicyTemplatesGetter
+	allowedClusterRoles      []string
+}
+
+// NewPolicyResolver returns a resolver looking up escalation policies, or namespaced escalation policies if the escalation
+// specifies a policy namespace. A namespaced policy is resolved as an escalation policy living in its namespace,
+// and only if its target is still confined to its namespace and to the allowed cluster roles.
+// Policies inheriting from a template are resolved to their effective spec.
+func NewPolicyResolver(
+	policiesGetter EscalationPoliciesGetter,
+	namespacedPoliciesGetter NamespacedEscalationPoliciesGetter,
+	templatesGetter escalationpolicy.EscalationPolicyTemplatesGetter,
+	allowedClusterRoles []string,
+) PolicyResolver {
+	return &policyResolver{
+		policiesGetter:           policiesGetter,
+		namespacedPoliciesGetter: namespacedPoliciesGetter,
+		templatesGetter:          templatesGetter,
+		allowedClusterRoles:      allowedClusterRoles,
+	}
+}
+
+// Resolve returns a NotFound error if the policy doesn't exist, ErrPolicyTemplateUnusable if its template can't be applied,
+// and ErrPolicyNotConfined if the policy is namespaced and its effective target is not confined to its namespace.
+func (r *policyResolver) Resolve(spec *kudov1alpha1.EscalationSpec) (*kudov1alpha1.EscalationPolicy, error) {
+	policy, err := r.getPolicy(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.Spec.TemplateRef != nil {
+		policy, err = r.applyTemplate(policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.PolicyNamespace == "" {
+		return policy, nil
+	}
+
+	if err := escalationpolicy.ValidateNamespacedTarget(
+		policy.Namespace,
+		policy.Spec.Target,
+		r.allowedClusterRoles,
+	); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrPolicyNotConfined, err)
+	}
+
+	return policy, nil
+}
+
+func (r *policyResolver) getPolicy(spec *kudov1alpha1.EscalationSpec) (*kudov1alpha1.EscalationPolicy, error) {
+	if spec.PolicyNamespace == "" {
+		return r.policiesGetter.Get(spec.PolicyName)
+	}
+
+	namespacedPolicy, err := r.namespacedPoliciesGetter.NamespacedEscalationPolicies(spec.PolicyNamespace).Get(spec.PolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kudov1alpha1.EscalationPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kudov1alpha1.SchemeGroupVersion.String(),
+			Kind:       kudov1alpha1.KindNamespacedEscalationPolicy,
+		},
+		// Lister objects are shared, never mutate them.
+		ObjectMeta: *namespacedPolicy.ObjectMeta.DeepCopy(),
+		Spec:       *namespacedPolicy.Spec.DeepCopy(),
+	}, nil
+}
+
+// applyTemplate returns the effective policy, inheriting from its template.
+// The template generation is recorded, so that a template change is reviewed as any other policy change.
+func (r *policyResolver) applyTemplate(policy *kudov1alpha1.EscalationPolicy) (*kudov1alpha1.EscalationPolicy, error) {
+	template, err := r.templatesGetter.Get(policy.Spec.TemplateRef.Name)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil, fmt.Errorf("%w, template %q doesn't exist", ErrPolicyTemplateUnusable, policy.Spec.TemplateRef.Name)
+	case err != nil:
+		return nil, err
+	}
+
+	spec, err := escalationpolicy.ApplyTemplate(policy.Spec, template.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrPolicyTemplateUnusable, err)
+	}
+
+	effectivePolicy := policy.DeepCopy()
+	effectivePolicy.Spec = spec
+
+	annotations := make(map[string]string, len(policy.Annotations)+1)
+	for key, value := range policy.Annotations {
+		annotations[key] = value
+	}
+
+	annotations[templateGenerationAnnotation] = strconv.FormatInt(template.Generation, 10)
+	effectivePolicy.Annotations = annotations
+
+	return effectivePolicy, nil
+}
+
+// policyVersion returns the version of an effective policy: its generation, followed by the generation of its template
+// if it inherits from one. Status updates don't change it, so that escalations are only reviewed again when the policy spec changes.
+func policyVersion(policy *kudov1alpha1.EscalationPolicy) string {
+	version := strconv.FormatInt(policy.Generation, 10)
+
+	if templateGeneration, ok := policy.Annotations[templateGenerationAnnotation]; ok {
+		version += "/" + templateGeneration
+	}
+
+	return version
+}
+
+// isUnusablePolicy returns true if the policy exists, but can't be used by escalations.
+func isUnusablePolicy(err error) bool {
+	return errors.Is(err, ErrPolicyNotConfined) || errors.Is(err, ErrPolicyTemplateUnusable)
+}
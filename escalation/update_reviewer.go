@@ -0,0 +1,621 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	kudo "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+// revokeVerb is the verb a user must be allowed to perform on escalations to revoke them.
+const revokeVerb = "revoke"
+
+type SubjectAccessReviewCreator interface {
+	Create(ctx context.Context, review *authorizationv1.SubjectAccessReview, opts metav1.CreateOptions) (*authorizationv1.SubjectAccessReview, error)
+}
+
+type updateAdmissionReviewer struct {
+	policyResolver PolicyResolver
+	accessReviewer SubjectAccessReviewCreator
+}
+
+func NewUpdateAdmissionReviewer(p PolicyResolver, accessReviewer SubjectAccessReviewCreator) *updateAdmissionReviewer {
+	return &updateAdmissionReviewer{policyResolver: p, accessReviewer: accessReviewer}
+}
+
+// ReviewAdmission makes sure that the only changes made to an escalation spec are extensions being requested
+// by the escalation requestor, extensions or the escalation being approved by one of the policy reviewers,
+// a delegated escalation being acknowledged by its requestor, the escalation being released by its requestor,
+// or the escalation being revoked by an administrator.
+func (r *updateAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	var oldEscalation, newEscalation kudov1alpha1.Escalation
+
+	if err := json.Unmarshal(req.OldObject.Raw, &oldEscalation); err != nil {
+		klog.ErrorS(err, "Can't unmarhal old object")
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(req.Object.Raw, &newEscalation); err != nil {
+		klog.ErrorS(err, "Can't unmarhal updated object")
+
+		return nil, err
+	}
+
+	// Spec has not changed, nothing to review.
+	if equality.Semantic.DeepEqual(oldEscalation.Spec, newEscalation.Spec) {
+		return allowedResponse(nil)
+	}
+
+	if !equality.Semantic.DeepEqual(immutableSpec(oldEscalation.Spec), immutableSpec(newEscalation.Spec)) {
+		klog.InfoS(
+			"User attempted to change the spec of an escalation",
+			"username",
+			req.UserInfo.Username,
+			"escalation",
+			oldEscalation.Name,
+		)
+
+		return deniedResponse("Only extensions, an approval, an acknowledgement, a release or a revocation can be requested on an existing escalation"), nil
+	}
+
+	if oldEscalation.Spec.Acknowledged != newEscalation.Spec.Acknowledged {
+		return r.reviewAcknowledgement(req, &oldEscalation, &newEscalation)
+	}
+
+	if !equality.Semantic.DeepEqual(oldEscalation.Spec.Revocation, newEscalation.Spec.Revocation) {
+		return r.reviewRevocation(ctx, req, &oldEscalation, &newEscalation)
+	}
+
+	if oldEscalation.Spec.Released != newEscalation.Spec.Released {
+		return reviewRelease(req, &oldEscalation, &newEscalation), nil
+	}
+
+	if oldEscalation.Spec.ApprovedBy != newEscalation.Spec.ApprovedBy {
+		return r.reviewApproval(req, &oldEscalation, &newEscalation)
+	}
+
+	var (
+		oldExtensions = oldEscalation.Spec.Extensions
+		newExtensions = newEscalation.Spec.Extensions
+	)
+
+	if len(newExtensions) < len(oldExtensions) {
+		return deniedResponse("Escalation extensions can't be removed"), nil
+	}
+
+	policy, err := r.policyResolver.Resolve(&oldEscalation.Spec)
+	switch {
+	case errors.IsNotFound(err):
+		return deniedResponse(fmt.Sprintf("Unknown policy: %s", oldEscalation.Spec.PolicyRef())), nil
+	case isUnusablePolicy(err):
+		return deniedResponse(fmt.Sprintf("Policy %s can't be used, reason is: %s", oldEscalation.Spec.PolicyRef(), err)), nil
+	case err != nil:
+		return nil, err
+	}
+
+	var patch []patchOperation
+
+	for i, oldExtension := range oldExtensions {
+		newExtension := newExtensions[i]
+
+		if equality.Semantic.DeepEqual(oldExtension, newExtension) {
+			continue
+		}
+
+		approvedExtension := oldExtension
+		approvedExtension.ApprovedBy = newExtension.ApprovedBy
+
+		if oldExtension.ApprovedBy != "" ||
+			newExtension.ApprovedBy == "" ||
+			!equality.Semantic.DeepEqual(approvedExtension, newExtension) {
+			return deniedResponse("Escalation extensions can't be modified once requested, except to approve them"), nil
+		}
+
+		if !policy.Spec.Target.ExtensionsRequireApproval {
+			return deniedResponse(
+				fmt.Sprintf("Policy %q does not require extensions to be approved", policy.Name),
+			), nil
+		}
+
+		// Users gaining access through the escalation, and the user that created it, can't approve its extensions.
+		if req.UserInfo.Username == oldExtension.Requestor ||
+			generics.Contains(oldEscalation.Spec.Grantees(), req.UserInfo.Username) ||
+			isDelegate(&oldEscalation, req.UserInfo.Username) ||
+			!userInSubjects(policyReviewers(policy), req.UserInfo.Username, req.UserInfo.Groups) {
+			klog.InfoS(
+				"User attempted to approve an escalation extension, but is not allowed to",
+				usernameAndPolicyTags(
+					req.UserInfo.Username,
+					policy.Name,
+					"escalation",
+					oldEscalation.Name,
+				)...,
+			)
+
+			return deniedResponse(
+				fmt.Sprintf(
+					"User %q is not allowed to approve extensions of the escalation %q",
+					req.UserInfo.Username,
+					oldEscalation.Name,
+				),
+			), nil
+		}
+
+		patch = append(
+			patch,
+			patchOperation{
+				Op:    "add",
+				Path:  fmt.Sprintf("/spec/extensions/%d/approvedBy", i),
+				Value: req.UserInfo.Username,
+			},
+		)
+	}
+
+	if len(newExtensions) > len(oldExtensions) {
+		if resp := reviewNewExtensions(req, &oldEscalation, policy, newExtensions[len(oldExtensions):]); resp != nil {
+			return resp, nil
+		}
+
+		for i := len(oldExtensions); i < len(newExtensions); i++ {
+			patch = append(
+				patch,
+				patchOperation{
+					Op:    "add",
+					Path:  fmt.Sprintf("/spec/extensions/%d/requestor", i),
+					Value: req.UserInfo.Username,
+				},
+				patchOperation{
+					Op:    "add",
+					Path:  fmt.Sprintf("/spec/extensions/%d/approvedBy", i),
+					Value: "",
+				},
+			)
+		}
+	}
+
+	klog.InfoS(
+		"User updated escalation extensions",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		oldEscalation.Name,
+	)
+
+	return allowedResponse(patch)
+}
+
+// reviewNewExtensions returns a denied response if the requested extensions can't be added to the escalation.
+func reviewNewExtensions(req *admissionv1.AdmissionRequest, esc *kudov1alpha1.Escalation, policy *kudov1alpha1.EscalationPolicy, extensions []kudov1alpha1.EscalationExtension) *admissionv1.AdmissionResponse {
+	if req.UserInfo.Username != esc.Spec.Requestor {
+		klog.InfoS(
+			"User attempted to extend an escalation they did not request",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"escalation",
+				esc.Name,
+			)...,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to extend the escalation %q",
+				req.UserInfo.Username,
+				esc.Name,
+			),
+		)
+	}
+
+	if esc.Status.State != kudov1alpha1.StateAccepted {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q can't be extended in state %s", esc.Name, esc.Status.State),
+		)
+	}
+
+	for _, extension := range extensions {
+		if strings.TrimSpace(extension.Reason) == "" {
+			return deniedResponse("Please provide a reason for your extension request")
+		}
+
+		if extension.Duration.Duration <= 0 {
+			return deniedResponse("Please provide a positive duration for your extension request")
+		}
+	}
+
+	if !esc.Status.ExpiresAt.Time.Before(esc.MaxExpiresAt(policy)) {
+		return deniedResponse(
+			fmt.Sprintf(
+				"Escalation %q can't be extended beyond the maximum duration allowed by the policy [%s]",
+				esc.Name,
+				policy.Spec.Target.MaxDuration.Duration,
+			),
+		)
+	}
+
+	return nil
+}
+
+// reviewRelease makes sure that only the requestor releases an escalation, and that a release is never undone.
+// A release can't be requested along with other changes.
+func reviewRelease(req *admissionv1.AdmissionRequest, oldEsc, newEsc *kudov1alpha1.Escalation) *admissionv1.AdmissionResponse {
+	if oldEsc.Spec.Released {
+		return deniedResponse("A released escalation can't be resumed")
+	}
+
+	if !equality.Semantic.DeepEqual(oldEsc.Spec.Extensions, newEsc.Spec.Extensions) {
+		return deniedResponse("Escalation extensions can't be changed while releasing an escalation")
+	}
+
+	if oldEsc.Spec.ApprovedBy != newEsc.Spec.ApprovedBy ||
+		oldEsc.Spec.Acknowledged != newEsc.Spec.Acknowledged ||
+		!equality.Semantic.DeepEqual(oldEsc.Spec.Revocation, newEsc.Spec.Revocation) {
+		return deniedResponse("An escalation can't be changed while being released")
+	}
+
+	if req.UserInfo.Username != oldEsc.Spec.Requestor {
+		klog.InfoS(
+			"User attempted to release an escalation they did not request",
+			"username",
+			req.UserInfo.Username,
+			"escalation",
+			oldEsc.Name,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to release the escalation %q",
+				req.UserInfo.Username,
+				oldEsc.Name,
+			),
+		)
+	}
+
+	if !isReleasable(oldEsc.Status.State) {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q can't be released in state %s", oldEsc.Name, oldEsc.Status.State),
+		)
+	}
+
+	klog.InfoS(
+		"User released an escalation",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		oldEsc.Name,
+	)
+
+	return &admissionv1.AdmissionResponse{
+		Allowed: true,
+		Result:  &metav1.Status{Status: metav1.StatusSuccess},
+	}
+}
+
+// reviewApproval makes sure that only one of the policy reviewers approves a pending escalation, and that an approval is never undone.
+// Reviewers gaining access through the escalation, or that created it on behalf of its requestor, can't approve it.
+// An approval can't be requested along with other changes.
+func (r *updateAdmissionReviewer) reviewApproval(req *admissionv1.AdmissionRequest, oldEsc, newEsc *kudov1alpha1.Escalation) (*admissionv1.AdmissionResponse, error) {
+	if oldEsc.Spec.ApprovedBy != "" {
+		return deniedResponse("An approval can't be changed once made"), nil
+	}
+
+	if oldEsc.Spec.Released != newEsc.Spec.Released ||
+		!equality.Semantic.DeepEqual(oldEsc.Spec.Extensions, newEsc.Spec.Extensions) {
+		return deniedResponse("An escalation can't be changed while being approved"), nil
+	}
+
+	policy, err := r.policyResolver.Resolve(&oldEsc.Spec)
+	switch {
+	case errors.IsNotFound(err):
+		return deniedResponse(fmt.Sprintf("Unknown policy: %s", oldEsc.Spec.PolicyRef())), nil
+	case isUnusablePolicy(err):
+		return deniedResponse(fmt.Sprintf("Policy %s can't be used, reason is: %s", oldEsc.Spec.PolicyRef(), err)), nil
+	case err != nil:
+		return nil, err
+	}
+
+	// Users gaining access through the escalation, and the user that created it, can't approve it.
+	if generics.Contains(oldEsc.Spec.Grantees(), req.UserInfo.Username) ||
+		isDelegate(oldEsc, req.UserInfo.Username) ||
+		!userInSubjects(policyReviewers(policy), req.UserInfo.Username, req.UserInfo.Groups) {
+		klog.InfoS(
+			"User attempted to approve an escalation, but is not allowed to",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"escalation",
+				oldEsc.Name,
+			)...,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to approve the escalation %q",
+				req.UserInfo.Username,
+				oldEsc.Name,
+			),
+		), nil
+	}
+
+	if oldEsc.Status.State != kudov1alpha1.StatePending {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q can't be approved in state %s", oldEsc.Name, oldEsc.Status.State),
+		), nil
+	}
+
+	klog.InfoS(
+		"User approved an escalation",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		oldEsc.Name,
+	)
+
+	return allowedResponse(
+		[]patchOperation{
+			{
+				Op:    "replace",
+				Path:  "/spec/approvedBy",
+				Value: req.UserInfo.Username,
+			},
+		},
+	)
+}
+
+// reviewAcknowledgement makes sure that only the requestor of a pending delegated escalation acknowledges it, and that
+// an acknowledgement is never undone. The requestor identity is recorded, and reviewed against the policy subjects.
+// An acknowledgement can't be requested along with other changes.
+func (r *updateAdmissionReviewer) reviewAcknowledgement(req *admissionv1.AdmissionRequest, oldEsc, newEsc *kudov1alpha1.Escalation) (*admissionv1.AdmissionResponse, error) {
+	if oldEsc.Spec.Acknowledged {
+		return deniedResponse("An acknowledgement can't be undone"), nil
+	}
+
+	if oldEsc.Spec.Released != newEsc.Spec.Released ||
+		oldEsc.Spec.ApprovedBy != newEsc.Spec.ApprovedBy ||
+		!equality.Semantic.DeepEqual(oldEsc.Spec.Revocation, newEsc.Spec.Revocation) ||
+		!equality.Semantic.DeepEqual(oldEsc.Spec.Extensions, newEsc.Spec.Extensions) {
+		return deniedResponse("An escalation can't be changed while being acknowledged"), nil
+	}
+
+	if !oldEsc.Spec.IsDelegated() {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q has not been created on behalf of its requestor, it can't be acknowledged", oldEsc.Name),
+		), nil
+	}
+
+	if req.UserInfo.Username != oldEsc.Spec.Requestor {
+		klog.InfoS(
+			"User attempted to acknowledge an escalation they are not the beneficiary of",
+			"username",
+			req.UserInfo.Username,
+			"escalation",
+			oldEsc.Name,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to acknowledge the escalation %q",
+				req.UserInfo.Username,
+				oldEsc.Name,
+			),
+		), nil
+	}
+
+	if oldEsc.Status.State != kudov1alpha1.StatePending {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q can't be acknowledged in state %s", oldEsc.Name, oldEsc.Status.State),
+		), nil
+	}
+
+	policy, err := r.policyResolver.Resolve(&oldEsc.Spec)
+	switch {
+	case errors.IsNotFound(err):
+		return deniedResponse(fmt.Sprintf("Unknown policy: %s", oldEsc.Spec.PolicyRef())), nil
+	case isUnusablePolicy(err):
+		return deniedResponse(fmt.Sprintf("Policy %s can't be used, reason is: %s", oldEsc.Spec.PolicyRef(), err)), nil
+	case err != nil:
+		return nil, err
+	}
+
+	if !userAllowed(*policy, req.UserInfo) {
+		klog.InfoS(
+			"User attempted to acknowledge an escalation, but is not part of the policy subjects",
+			usernameAndPolicyTags(
+				req.UserInfo.Username,
+				policy.Name,
+				"escalation",
+				oldEsc.Name,
+			)...,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to use the escalation policy %q",
+				req.UserInfo.Username,
+				policy.Name,
+			),
+		), nil
+	}
+
+	klog.InfoS(
+		"User acknowledged an escalation created on their behalf",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		oldEsc.Name,
+		"delegate",
+		oldEsc.Spec.Delegate.Username,
+	)
+
+	return allowedResponse(
+		[]patchOperation{
+			{
+				Op:    "add",
+				Path:  "/spec/requestorInfo",
+				Value: req.UserInfo,
+			},
+		},
+	)
+}
+
+// reviewRevocation makes sure that only users allowed to revoke escalations do it, with a reason.
+// A revocation can't be changed once made, and can't be requested along with other changes.
+func (r *updateAdmissionReviewer) reviewRevocation(ctx context.Context, req *admissionv1.AdmissionRequest, oldEsc, newEsc *kudov1alpha1.Escalation) (*admissionv1.AdmissionResponse, error) {
+	if oldEsc.Spec.Revocation != nil {
+		return deniedResponse("A revocation can't be changed once made"), nil
+	}
+
+	if oldEsc.Spec.Released != newEsc.Spec.Released ||
+		oldEsc.Spec.ApprovedBy != newEsc.Spec.ApprovedBy ||
+		oldEsc.Spec.Acknowledged != newEsc.Spec.Acknowledged ||
+		!equality.Semantic.DeepEqual(oldEsc.Spec.Extensions, newEsc.Spec.Extensions) {
+		return deniedResponse("An escalation can't be changed while being revoked"), nil
+	}
+
+	if strings.TrimSpace(newEsc.Spec.Revocation.Reason) == "" {
+		return deniedResponse("Please provide a reason for your revocation"), nil
+	}
+
+	allowed, err := r.canRevoke(ctx, req.UserInfo, oldEsc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		klog.InfoS(
+			"User attempted to revoke an escalation, but is not allowed to",
+			"username",
+			req.UserInfo.Username,
+			"escalation",
+			oldEsc.Name,
+		)
+
+		return deniedResponse(
+			fmt.Sprintf(
+				"User %q is not allowed to revoke the escalation %q",
+				req.UserInfo.Username,
+				oldEsc.Name,
+			),
+		), nil
+	}
+
+	if !isReleasable(oldEsc.Status.State) {
+		return deniedResponse(
+			fmt.Sprintf("Escalation %q can't be revoked in state %s", oldEsc.Name, oldEsc.Status.State),
+		), nil
+	}
+
+	klog.InfoS(
+		"User revoked an escalation",
+		"username",
+		req.UserInfo.Username,
+		"escalation",
+		oldEsc.Name,
+	)
+
+	return allowedResponse(
+		[]patchOperation{
+			{
+				Op:    "add",
+				Path:  "/spec/revocation/revokedBy",
+				Value: req.UserInfo.Username,
+			},
+		},
+	)
+}
+
+// canRevoke asks the API server if the user is allowed to revoke the escalation.
+func (r *updateAdmissionReviewer) canRevoke(ctx context.Context, userInfo authenticationv1.UserInfo, esc *kudov1alpha1.Escalation) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review, err := r.accessReviewer.Create(
+		ctx,
+		&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   userInfo.Username,
+				Groups: userInfo.Groups,
+				UID:    userInfo.UID,
+				Extra:  extra,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:    kudo.GroupName,
+					Resource: "escalations",
+					Verb:     revokeVerb,
+					Name:     esc.Name,
+				},
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// immutableSpec returns the part of the spec that can't be changed once the escalation is created.
+func immutableSpec(spec kudov1alpha1.EscalationSpec) kudov1alpha1.EscalationSpec {
+	spec.Extensions = nil
+	spec.Released = false
+	spec.Revocation = nil
+	spec.ApprovedBy = ""
+	spec.Acknowledged = false
+	return spec
+}
+
+// policyReviewers returns all the reviewers of the policy challenges.
+func policyReviewers(policy *kudov1alpha1.EscalationPolicy) []rbacv1.Subject {
+	var reviewers []rbacv1.Subject
+
+	for _, challenge := range policy.Spec.Challenges {
+		reviewers = append(reviewers, challenge.Reviewers...)
+	}
+
+	return reviewers
+}
+
+func allowedResponse(patch []patchOperation) (*admissionv1.AdmissionResponse, error) {
+	resp := admissionv1.AdmissionResponse{
+		Allowed: true,
+		Result:  &metav1.Status{Status: metav1.StatusSuccess},
+	}
+
+	if len(patch) == 0 {
+		return &resp, nil
+	}
+
+	rawPatch, err := json.Marshal(&patch)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PatchType = generics.Ptr(admissionv1.PatchTypeJSONPatch)
+	resp.Patch = rawPatch
+
+	return &resp, nil
+}
+
+func deniedResponse(message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+		},
+	}
+}
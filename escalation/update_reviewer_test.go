@@ -0,0 +1,596 @@
+package escalation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/jlevesy/kudo/escalation"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generated/clientset/versioned/fake"
+	kudoinformers "github.com/jlevesy/kudo/pkg/generated/informers/externalversions"
+	"github.com/jlevesy/kudo/pkg/generics"
+	"github.com/jlevesy/kudo/pkg/webhooksupport/webhooktesting"
+)
+
+func TestUpdateEscalationAdmissionReviewer_ReviewAdmission(t *testing.T) {
+	var (
+		acceptedAt = time.Date(2022, time.October, 10, 1, 0, 0, 0, time.UTC)
+
+		extensionPolicy = func(name string, requireApproval bool) *kudov1alpha1.EscalationPolicy {
+			return &kudov1alpha1.EscalationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: kudov1alpha1.EscalationPolicySpec{
+					Subjects: []kudov1alpha1.PolicySubject{
+						{
+							Subject: rbacv1.Subject{
+								Kind: rbacv1.GroupKind,
+								Name: "requestors@org.com",
+							},
+						},
+					},
+					Challenges: []kudov1alpha1.EscalationChallenge{
+						{
+							Kind: "PeerReview",
+							Reviewers: []rbacv1.Subject{
+								{
+									Kind: rbacv1.GroupKind,
+									Name: "reviewers@org.com",
+								},
+							},
+						},
+					},
+					Target: kudov1alpha1.EscalationTarget{
+						DefaultDuration:           metav1.Duration{Duration: time.Hour},
+						MaxDuration:               metav1.Duration{Duration: 2 * time.Hour},
+						ExtensionsRequireApproval: requireApproval,
+					},
+				},
+			}
+		}
+
+		policies = []runtime.Object{
+			extensionPolicy("policy-extend", false),
+			extensionPolicy("policy-extend-approval", true),
+		}
+
+		acceptedEscalation = func(policyName string, expiresIn time.Duration, extensions ...kudov1alpha1.EscalationExtension) kudov1alpha1.Escalation {
+			return kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "escalation-1",
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName: policyName,
+					Requestor:  "user-a",
+					Reason:     "I need moar power",
+					Extensions: extensions,
+				},
+				Status: kudov1alpha1.EscalationStatus{
+					State:      kudov1alpha1.StateAccepted,
+					AcceptedAt: metav1.Time{Time: acceptedAt},
+					ExpiresAt:  metav1.Time{Time: acceptedAt.Add(expiresIn)},
+				},
+			}
+		}
+
+		extension = kudov1alpha1.EscalationExtension{
+			Duration:  metav1.Duration{Duration: 30 * time.Minute},
+			Reason:    "Still investigating",
+			Requestor: "user-a",
+		}
+
+		approvedExtension = kudov1alpha1.EscalationExtension{
+			Duration:   metav1.Duration{Duration: 30 * time.Minute},
+			Reason:     "Still investigating",
+			Requestor:  "user-a",
+			ApprovedBy: "user-b",
+		}
+
+		released = func(esc kudov1alpha1.Escalation) kudov1alpha1.Escalation {
+			esc.Spec.Released = true
+			return esc
+		}
+
+		pending = func(esc kudov1alpha1.Escalation) kudov1alpha1.Escalation {
+			esc.Status = kudov1alpha1.EscalationStatus{State: kudov1alpha1.StatePending}
+			return esc
+		}
+
+		approved = func(esc kudov1alpha1.Escalation, approvedBy string) kudov1alpha1.Escalation {
+			esc.Spec.ApprovedBy = approvedBy
+			return esc
+		}
+
+		delegated = func(esc kudov1alpha1.Escalation, delegate string) kudov1alpha1.Escalation {
+			esc.Spec.OnBehalfOf = esc.Spec.Requestor
+			esc.Spec.Delegate = &authenticationv1.UserInfo{Username: delegate}
+			return esc
+		}
+
+		withBeneficiaries = func(esc kudov1alpha1.Escalation, beneficiaries ...string) kudov1alpha1.Escalation {
+			esc.Spec.Beneficiaries = beneficiaries
+			return esc
+		}
+
+		acknowledged = func(esc kudov1alpha1.Escalation) kudov1alpha1.Escalation {
+			esc.Spec.Acknowledged = true
+			return esc
+		}
+
+		revoked = func(esc kudov1alpha1.Escalation, reason, revokedBy string) kudov1alpha1.Escalation {
+			esc.Spec.Revocation = &kudov1alpha1.EscalationRevocation{
+				Reason:    reason,
+				RevokedBy: revokedBy,
+			}
+			return esc
+		}
+
+		updateRequest = func(t *testing.T, username string, groups []string, oldEsc, newEsc kudov1alpha1.Escalation) *admissionv1.AdmissionRequest {
+			return &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(t, oldEsc).Bytes(),
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(t, newEsc).Bytes(),
+				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: username,
+					Groups:   groups,
+				},
+			}
+		}
+
+		denied = func(message string) *admissionv1.AdmissionResponse {
+			return &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: message,
+				},
+			}
+		}
+
+		allowed = func(patch string) *admissionv1.AdmissionResponse {
+			resp := admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Status: metav1.StatusSuccess},
+			}
+
+			if patch != "" {
+				resp.PatchType = generics.Ptr(admissionv1.PatchTypeJSONPatch)
+				resp.Patch = []byte(patch)
+			}
+
+			return &resp
+		}
+	)
+
+	testCases := []struct {
+		desc string
+
+		username string
+		groups   []string
+		oldEsc   kudov1alpha1.Escalation
+		newEsc   kudov1alpha1.Escalation
+
+		wantResponse *admissionv1.AdmissionResponse
+	}{
+		{
+			desc:         "allows updates that do not change the spec",
+			username:     "someone",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour),
+			wantResponse: allowed(""),
+		},
+		{
+			desc:     "denies spec changes other than extensions",
+			username: "user-a",
+			oldEsc:   acceptedEscalation("policy-extend", time.Hour),
+			newEsc: func() kudov1alpha1.Escalation {
+				esc := acceptedEscalation("policy-extend", time.Hour)
+				esc.Spec.Reason = "Something else"
+				return esc
+			}(),
+			wantResponse: denied("Only extensions, an approval, an acknowledgement, a release or a revocation can be requested on an existing escalation"),
+		},
+		{
+			desc:         "denies removing extensions",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour, extension),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour),
+			wantResponse: denied("Escalation extensions can't be removed"),
+		},
+		{
+			desc:         "denies extensions requested by someone else than the requestor",
+			username:     "user-b",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour, extension),
+			wantResponse: denied("User \"user-b\" is not allowed to extend the escalation \"escalation-1\""),
+		},
+		{
+			desc:     "denies extensions of an escalation that is not accepted",
+			username: "user-a",
+			oldEsc: func() kudov1alpha1.Escalation {
+				esc := acceptedEscalation("policy-extend", time.Hour)
+				esc.Status.State = kudov1alpha1.StateExpired
+				return esc
+			}(),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour, extension),
+			wantResponse: denied("Escalation \"escalation-1\" can't be extended in state EXPIRED"),
+		},
+		{
+			desc:     "denies extensions without reason",
+			username: "user-a",
+			oldEsc:   acceptedEscalation("policy-extend", time.Hour),
+			newEsc: acceptedEscalation(
+				"policy-extend",
+				time.Hour,
+				kudov1alpha1.EscalationExtension{Duration: metav1.Duration{Duration: time.Minute}},
+			),
+			wantResponse: denied("Please provide a reason for your extension request"),
+		},
+		{
+			desc:         "denies extensions of an escalation that already lasts the policy max duration",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", 2*time.Hour),
+			newEsc:       acceptedEscalation("policy-extend", 2*time.Hour, extension),
+			wantResponse: denied("Escalation \"escalation-1\" can't be extended beyond the maximum duration allowed by the policy [2h0m0s]"),
+		},
+		{
+			desc:         "allows the requestor to extend an escalation",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour, extension),
+			wantResponse: allowed(`[{"op":"add","path":"/spec/extensions/0/requestor","value":"user-a"},{"op":"add","path":"/spec/extensions/0/approvedBy","value":""}]`),
+		},
+		{
+			desc:         "denies approving extensions if the policy does not require it",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour, extension),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour, approvedExtension),
+			wantResponse: denied("Policy \"policy-extend\" does not require extensions to be approved"),
+		},
+		{
+			desc:         "denies approvals from users that are not reviewers",
+			username:     "user-b",
+			oldEsc:       acceptedEscalation("policy-extend-approval", time.Hour, extension),
+			newEsc:       acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension),
+			wantResponse: denied("User \"user-b\" is not allowed to approve extensions of the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies the requestor approving their own extension",
+			username:     "user-a",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       acceptedEscalation("policy-extend-approval", time.Hour, extension),
+			newEsc:       acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension),
+			wantResponse: denied("User \"user-a\" is not allowed to approve extensions of the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies a beneficiary approving an extension",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       withBeneficiaries(acceptedEscalation("policy-extend-approval", time.Hour, extension), "user-b"),
+			newEsc:       withBeneficiaries(acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension), "user-b"),
+			wantResponse: denied("User \"user-b\" is not allowed to approve extensions of the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies the delegate approving an extension",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       delegated(acceptedEscalation("policy-extend-approval", time.Hour, extension), "user-b"),
+			newEsc:       delegated(acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension), "user-b"),
+			wantResponse: denied("User \"user-b\" is not allowed to approve extensions of the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies modifying an approved extension",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension),
+			newEsc:       acceptedEscalation("policy-extend-approval", time.Hour, extension),
+			wantResponse: denied("Escalation extensions can't be modified once requested, except to approve them"),
+		},
+		{
+			desc:         "allows reviewers to approve an extension",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       acceptedEscalation("policy-extend-approval", time.Hour, extension),
+			newEsc:       acceptedEscalation("policy-extend-approval", time.Hour, approvedExtension),
+			wantResponse: allowed(`[{"op":"add","path":"/spec/extensions/0/approvedBy","value":"user-b"}]`),
+		},
+		{
+			desc:         "allows the requestor to release an escalation",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       released(acceptedEscalation("policy-extend", time.Hour)),
+			wantResponse: allowed(""),
+		},
+		{
+			desc:         "denies releases from someone else than the requestor",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       released(acceptedEscalation("policy-extend", time.Hour)),
+			wantResponse: denied("User \"user-b\" is not allowed to release the escalation \"escalation-1\""),
+		},
+		{
+			desc:     "denies releasing an escalation that is not active",
+			username: "user-a",
+			oldEsc: func() kudov1alpha1.Escalation {
+				esc := acceptedEscalation("policy-extend", time.Hour)
+				esc.Status.State = kudov1alpha1.StateDenied
+				return esc
+			}(),
+			newEsc:       released(acceptedEscalation("policy-extend", time.Hour)),
+			wantResponse: denied("Escalation \"escalation-1\" can't be released in state DENIED"),
+		},
+		{
+			desc:         "denies resuming a released escalation",
+			username:     "user-a",
+			oldEsc:       released(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour),
+			wantResponse: denied("A released escalation can't be resumed"),
+		},
+		{
+			desc:         "denies extending an escalation while releasing it",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       released(acceptedEscalation("policy-extend", time.Hour, extension)),
+			wantResponse: denied("Escalation extensions can't be changed while releasing an escalation"),
+		},
+		{
+			desc:         "denies approving an escalation while releasing it",
+			username:     "user-a",
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       released(approved(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b")),
+			wantResponse: denied("An escalation can't be changed while being released"),
+		},
+		{
+			desc:         "allows reviewers to approve a pending escalation",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       approved(pending(acceptedEscalation("policy-extend", time.Hour)), "someone-else"),
+			wantResponse: allowed(`[{"op":"replace","path":"/spec/approvedBy","value":"user-b"}]`),
+		},
+		{
+			desc:         "denies escalation approvals from users that are not reviewers",
+			username:     "user-c",
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       approved(pending(acceptedEscalation("policy-extend", time.Hour)), "user-c"),
+			wantResponse: denied("User \"user-c\" is not allowed to approve the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies the requestor approving their own escalation",
+			username:     "user-a",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       approved(pending(acceptedEscalation("policy-extend", time.Hour)), "user-a"),
+			wantResponse: denied("User \"user-a\" is not allowed to approve the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies approving an escalation that is not pending",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       approved(acceptedEscalation("policy-extend", time.Hour), "user-b"),
+			wantResponse: denied("Escalation \"escalation-1\" can't be approved in state ACCEPTED"),
+		},
+		{
+			desc:         "denies changing an approval",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       approved(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       approved(pending(acceptedEscalation("policy-extend", time.Hour)), "user-c"),
+			wantResponse: denied("An approval can't be changed once made"),
+		},
+		{
+			desc:         "denies the delegate approving the escalation they created",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       approved(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"), "user-b"),
+			wantResponse: denied("User \"user-b\" is not allowed to approve the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies a beneficiary approving the escalation",
+			username:     "user-b",
+			groups:       []string{"reviewers@org.com"},
+			oldEsc:       withBeneficiaries(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       approved(withBeneficiaries(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"), "user-b"),
+			wantResponse: denied("User \"user-b\" is not allowed to approve the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "allows the requestor to acknowledge a delegated escalation",
+			username:     "user-a",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       acknowledged(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b")),
+			wantResponse: allowed(`[{"op":"add","path":"/spec/requestorInfo","value":{"username":"user-a","groups":["requestors@org.com"]}}]`),
+		},
+		{
+			desc:         "denies acknowledgements from someone else than the requestor",
+			username:     "user-b",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       acknowledged(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b")),
+			wantResponse: denied("User \"user-b\" is not allowed to acknowledge the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies acknowledgements from a requestor that is not allowed to use the policy",
+			username:     "user-a",
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       acknowledged(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b")),
+			wantResponse: denied("User \"user-a\" is not allowed to use the escalation policy \"policy-extend\""),
+		},
+		{
+			desc:         "denies acknowledging an escalation that is not delegated",
+			username:     "user-a",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       acknowledged(pending(acceptedEscalation("policy-extend", time.Hour))),
+			wantResponse: denied("Escalation \"escalation-1\" has not been created on behalf of its requestor, it can't be acknowledged"),
+		},
+		{
+			desc:         "denies acknowledging an escalation that is not pending",
+			username:     "user-a",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       delegated(acceptedEscalation("policy-extend", time.Hour), "user-b"),
+			newEsc:       acknowledged(delegated(acceptedEscalation("policy-extend", time.Hour), "user-b")),
+			wantResponse: denied("Escalation \"escalation-1\" can't be acknowledged in state ACCEPTED"),
+		},
+		{
+			desc:         "denies undoing an acknowledgement",
+			username:     "user-a",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       acknowledged(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b")),
+			newEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			wantResponse: denied("An acknowledgement can't be undone"),
+		},
+		{
+			desc:         "denies releasing an escalation while acknowledging it",
+			username:     "user-a",
+			groups:       []string{"requestors@org.com"},
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       released(acknowledged(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"))),
+			wantResponse: denied("An escalation can't be changed while being acknowledged"),
+		},
+		{
+			desc:         "allows admins to revoke an escalation",
+			username:     "admin",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", ""),
+			wantResponse: allowed(`[{"op":"add","path":"/spec/revocation/revokedBy","value":"admin"}]`),
+		},
+		{
+			desc:         "denies revocations from users not allowed to revoke",
+			username:     "user-a",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", ""),
+			wantResponse: denied("User \"user-a\" is not allowed to revoke the escalation \"escalation-1\""),
+		},
+		{
+			desc:         "denies revocations without a reason",
+			username:     "admin",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), " ", ""),
+			wantResponse: denied("Please provide a reason for your revocation"),
+		},
+		{
+			desc:     "denies revoking an escalation that is not active",
+			username: "admin",
+			oldEsc: func() kudov1alpha1.Escalation {
+				esc := acceptedEscalation("policy-extend", time.Hour)
+				esc.Status.State = kudov1alpha1.StateExpired
+				return esc
+			}(),
+			newEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", ""),
+			wantResponse: denied("Escalation \"escalation-1\" can't be revoked in state EXPIRED"),
+		},
+		{
+			desc:         "denies changing a revocation",
+			username:     "admin",
+			oldEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", "admin"),
+			newEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Something else", "admin"),
+			wantResponse: denied("A revocation can't be changed once made"),
+		},
+		{
+			desc:         "denies removing a revocation",
+			username:     "admin",
+			oldEsc:       revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", "admin"),
+			newEsc:       acceptedEscalation("policy-extend", time.Hour),
+			wantResponse: denied("A revocation can't be changed once made"),
+		},
+		{
+			desc:         "denies releasing an escalation while revoking it",
+			username:     "admin",
+			oldEsc:       acceptedEscalation("policy-extend", time.Hour),
+			newEsc:       released(revoked(acceptedEscalation("policy-extend", time.Hour), "Incident is over", "")),
+			wantResponse: denied("An escalation can't be changed while being revoked"),
+		},
+		{
+			desc:         "denies approving an escalation while revoking it",
+			username:     "admin",
+			oldEsc:       pending(acceptedEscalation("policy-extend", time.Hour)),
+			newEsc:       approved(revoked(pending(acceptedEscalation("policy-extend", time.Hour)), "Incident is over", ""), "admin"),
+			wantResponse: denied("An escalation can't be changed while being revoked"),
+		},
+		{
+			desc:         "denies acknowledging an escalation while revoking it",
+			username:     "admin",
+			oldEsc:       delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"),
+			newEsc:       acknowledged(revoked(delegated(pending(acceptedEscalation("policy-extend", time.Hour)), "user-b"), "Incident is over", "")),
+			wantResponse: denied("An escalation can't be changed while being acknowledged"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+
+				fakeClient         = fake.NewSimpleClientset(policies...)
+				informersFactories = kudoinformers.NewSharedInformerFactory(
+					fakeClient,
+					60*time.Second,
+				)
+				escalationPolicyInformer = informersFactories.K8s().V1alpha1().EscalationPolicies()
+
+				kubeClient = kubefake.NewSimpleClientset()
+
+				reviewer = escalation.NewUpdateAdmissionReviewer(
+					escalation.NewPolicyResolver(
+						escalationPolicyInformer.Lister(),
+						informersFactories.K8s().V1alpha1().NamespacedEscalationPolicies().Lister(),
+						informersFactories.K8s().V1alpha1().EscalationPolicyTemplates().Lister(),
+						testAllowedClusterRoles,
+					),
+					kubeClient.AuthorizationV1().SubjectAccessReviews(),
+				)
+			)
+
+			// Only the user "admin" is allowed to revoke escalations.
+			kubeClient.PrependReactor(
+				"create",
+				"subjectaccessreviews",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+					review.Status.Allowed = review.Spec.User == "admin" &&
+						review.Spec.ResourceAttributes.Verb == "revoke" &&
+						review.Spec.ResourceAttributes.Resource == "escalations"
+
+					return true, review, nil
+				},
+			)
+
+			defer cancel()
+
+			informersFactories.Start(ctx.Done())
+
+			if ok := cache.WaitForCacheSync(ctx.Done(), escalationPolicyInformer.Informer().HasSynced); !ok {
+				t.Fatal("Cache sync failed, failing test...")
+			}
+
+			gotResp, err := reviewer.ReviewAdmission(
+				ctx,
+				updateRequest(t, testCase.username, testCase.groups, testCase.oldEsc, testCase.newEsc),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantResponse, gotResp)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/jlevesy/kudo/grant"
@@ -21,7 +22,7 @@ var (
 	}
 )
 
-func SetupWebhook(router *http.ServeMux, kudoInformerFactory kudoinformers.SharedInformerFactory, granterFactory grant.Factory) {
+func SetupWebhook(router *http.ServeMux, policyResolver PolicyResolver, kudoInformerFactory kudoinformers.SharedInformerFactory, granterFactory grant.Factory, accessReviewer SubjectAccessReviewCreator, adminSubjects []rbacv1.Subject) {
 	router.Handle(
 		"/v1alpha1/escalations",
 		webhooksupport.NewHandler(
@@ -31,10 +32,22 @@ func SetupWebhook(router *http.ServeMux, kudoInformerFactory kudoinformers.Share
 					webhooksupport.HandleOperation(
 						admissionv1.Create,
 						NewCreateAdmissionReviewer(
-							kudoInformerFactory.K8s().V1alpha1().EscalationPolicies().Lister(),
+							policyResolver,
+							kudoInformerFactory.K8s().V1alpha1().Escalations().Lister(),
 							granterFactory,
 						),
 					),
+					webhooksupport.HandleOperation(
+						admissionv1.Update,
+						NewUpdateAdmissionReviewer(
+							policyResolver,
+							accessReviewer,
+						),
+					),
+					webhooksupport.HandleOperation(
+						admissionv1.Delete,
+						NewDeleteAdmissionReviewer(adminSubjects),
+					),
 				),
 			),
 		),
@@ -0,0 +1,276 @@
+package escalationpolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+// ConditionInput is the escalation request policy conditions are evaluated over.
+type ConditionInput struct {
+	Requestor string
+	// Beneficiaries are the other users the escalation grants permissions to.
+	Beneficiaries []string
+	Groups        []string
+	Extra         map[string][]string
+	// Namespace is exposed as requestedNamespace, namespace is a reserved CEL identifier.
+	Namespace string
+	Duration  time.Duration
+	Reason    string
+	Now       time.Time
+	// ActiveEscalations are the accepted escalations granting permissions to the requestor, the reviewed escalation excluded.
+	ActiveEscalations []*kudov1alpha1.Escalation
+}
+
+// ConditionsResult tells what the policy conditions decided about an escalation request.
+type ConditionsResult struct {
+	// Denied is true if the escalation is not allowed by the policy conditions, Reason tells why.
+	Denied bool
+	Reason string
+	// ChallengeRequired is true if the escalation has to be approved by one of the policy challenges reviewers.
+	ChallengeRequired bool
+}
+
+const (
+	// maxConditionLength is the maximum length of a condition expression.
+	maxConditionLength = 1024
+	// conditionCostLimit bounds the cost of evaluating a condition, conditions whose estimated cost is higher are rejected.
+	conditionCostLimit = 1000000
+	// conditionInputMaxSize is the size assumed for the lists, maps and strings of the input when estimating the cost of a condition.
+	conditionInputMaxSize = 256
+	// conditionEvaluationTimeout bounds the time spent evaluating a condition, in case its cost is underestimated.
+	conditionEvaluationTimeout = 100 * time.Millisecond
+
+	compiledConditionsCacheSize = 512
+	compiledConditionsCacheTTL  = time.Hour
+)
+
+var (
+	conditionsEnv = mustConditionsEnv()
+	// compiledConditions holds the programs of the conditions already compiled, by expression.
+	compiledConditions = cache.NewLRUExpireCache(compiledConditionsCacheSize)
+)
+
+func mustConditionsEnv() *cel.Env {
+	env, err := cel.NewEnv(
+		cel.Variable("requestor", cel.StringType),
+		cel.Variable("beneficiaries", cel.ListType(cel.StringType)),
+		cel.Variable("groups", cel.ListType(cel.StringType)),
+		cel.Variable("extra", cel.MapType(cel.StringType, cel.ListType(cel.StringType))),
+		cel.Variable("requestedNamespace", cel.StringType),
+		cel.Variable("duration", cel.DurationType),
+		cel.Variable("reason", cel.StringType),
+		cel.Variable("now", cel.TimestampType),
+		cel.Variable("activeEscalations", cel.ListType(cel.MapType(cel.StringType, cel.StringType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return env
+}
+
+// ValidateConditions makes sure that all the conditions are valid CEL expressions returning a boolean, with a known action.
+// Conditions that are too long or too expensive to evaluate are rejected.
+func ValidateConditions(conditions []kudov1alpha1.PolicyCondition) error {
+	for i, condition := range conditions {
+		switch condition.Action {
+		case kudov1alpha1.ConditionActionAllow, kudov1alpha1.ConditionActionDeny, kudov1alpha1.ConditionActionRequireChallenge:
+		default:
+			return fmt.Errorf(
+				"condition %d action must be one of %s, %s or %s, got %q",
+				i,
+				kudov1alpha1.ConditionActionAllow,
+				kudov1alpha1.ConditionActionDeny,
+				kudov1alpha1.ConditionActionRequireChallenge,
+				condition.Action,
+			)
+		}
+
+		if len(condition.Expression) > maxConditionLength {
+			return fmt.Errorf("condition %d is too long, expressions must be at most %d characters long", i, maxConditionLength)
+		}
+
+		ast, err := checkCondition(condition)
+		if err != nil {
+			return fmt.Errorf("condition %d is invalid, %w", i, err)
+		}
+
+		cost, err := conditionsEnv.EstimateCost(ast, conditionCostEstimator{})
+		if err != nil {
+			return fmt.Errorf("condition %d cost can't be estimated, %w", i, err)
+		}
+
+		if cost.Max > conditionCostLimit {
+			return fmt.Errorf("condition %d is too expensive to evaluate, its estimated cost is %d, at most %d is allowed", i, cost.Max, conditionCostLimit)
+		}
+	}
+
+	return nil
+}
+
+// EvaluateConditions evaluates the policy conditions over an escalation request.
+// An escalation is denied if one of the deny conditions is true, or if the policy has allow conditions and none of them is true.
+// Each condition evaluation is bounded by a cost limit and a timeout.
+func EvaluateConditions(ctx context.Context, conditions []kudov1alpha1.PolicyCondition, input ConditionInput) (ConditionsResult, error) {
+	var (
+		result     ConditionsResult
+		hasAllow   bool
+		allowed    bool
+		activation = input.activation()
+	)
+
+	for i, condition := range conditions {
+		program, err := compileCondition(condition)
+		if err != nil {
+			return ConditionsResult{}, fmt.Errorf("condition %d is invalid, %w", i, err)
+		}
+
+		out, err := evalCondition(ctx, program, activation)
+		if err != nil {
+			return ConditionsResult{}, fmt.Errorf("unable to evaluate condition %d, %w", i, err)
+		}
+
+		matched, ok := out.(bool)
+		if !ok {
+			return ConditionsResult{}, fmt.Errorf("condition %d did not evaluate to a boolean", i)
+		}
+
+		switch condition.Action {
+		case kudov1alpha1.ConditionActionAllow:
+			hasAllow = true
+			allowed = allowed || matched
+		case kudov1alpha1.ConditionActionDeny:
+			if matched && !result.Denied {
+				result.Denied = true
+				result.Reason = conditionMessage(i, condition)
+			}
+		case kudov1alpha1.ConditionActionRequireChallenge:
+			result.ChallengeRequired = result.ChallengeRequired || matched
+		}
+	}
+
+	if !result.Denied && hasAllow && !allowed {
+		result.Denied = true
+		result.Reason = "none of the policy allow conditions matched"
+	}
+
+	return result, nil
+}
+
+// compileCondition returns the program of a condition, compiling it only if it hasn't been already.
+func compileCondition(condition kudov1alpha1.PolicyCondition) (cel.Program, error) {
+	if program, ok := compiledConditions.Get(condition.Expression); ok {
+		return program.(cel.Program), nil
+	}
+
+	ast, err := checkCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := conditionsEnv.Program(
+		ast,
+		cel.CostLimit(conditionCostLimit),
+		cel.InterruptCheckFrequency(100),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledConditions.Add(condition.Expression, program, compiledConditionsCacheTTL)
+
+	return program, nil
+}
+
+func checkCondition(condition kudov1alpha1.PolicyCondition) (*cel.Ast, error) {
+	ast, issues := conditionsEnv.Compile(condition.Expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if !cel.BoolType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+	}
+
+	return ast, nil
+}
+
+func evalCondition(ctx context.Context, program cel.Program, activation map[string]any) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, conditionEvaluationTimeout)
+	defer cancel()
+
+	out, _, err := program.ContextEval(ctx, activation)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Value(), nil
+}
+
+// conditionCostEstimator bounds the size of the conditions input, as its actual size is only known at evaluation time.
+type conditionCostEstimator struct{}
+
+func (conditionCostEstimator) EstimateSize(element checker.AstNode) *checker.SizeEstimate {
+	if len(element.Path()) == 0 {
+		return nil
+	}
+
+	return &checker.SizeEstimate{Min: 0, Max: conditionInputMaxSize}
+}
+
+func (conditionCostEstimator) EstimateCallCost(string, string, *checker.AstNode, []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
+
+func conditionMessage(i int, condition kudov1alpha1.PolicyCondition) string {
+	if condition.Message != "" {
+		return condition.Message
+	}
+
+	return fmt.Sprintf("denied by condition %d", i)
+}
+
+func (i ConditionInput) activation() map[string]any {
+	activeEscalations := make([]map[string]string, len(i.ActiveEscalations))
+
+	for j, esc := range i.ActiveEscalations {
+		activeEscalations[j] = map[string]string{
+			"name":   esc.Name,
+			"policy": esc.Spec.PolicyName,
+		}
+	}
+
+	groups := i.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+
+	beneficiaries := i.Beneficiaries
+	if beneficiaries == nil {
+		beneficiaries = []string{}
+	}
+
+	extra := i.Extra
+	if extra == nil {
+		extra = map[string][]string{}
+	}
+
+	return map[string]any{
+		"requestor":          i.Requestor,
+		"beneficiaries":      beneficiaries,
+		"groups":             groups,
+		"extra":              extra,
+		"requestedNamespace": i.Namespace,
+		"duration":           i.Duration,
+		"reason":             i.Reason,
+		"now":                i.Now,
+		"activeEscalations":  activeEscalations,
+	}
+}
@@ -0,0 +1,202 @@
+package escalationpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jlevesy/kudo/escalationpolicy"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestEvaluateConditions(t *testing.T) {
+	input := escalationpolicy.ConditionInput{
+		Requestor:     "jean-testor",
+		Beneficiaries: []string{"jeanne-testeuse"},
+		Groups:        []string{"sre"},
+		Extra:         map[string][]string{"mfa-level": {"high"}},
+		Namespace:     "some-app",
+		Duration:      2 * time.Hour,
+		Reason:        "INC-1234 database is down",
+		Now:           time.Date(2022, time.October, 10, 22, 30, 0, 0, time.UTC),
+		ActiveEscalations: []*kudov1alpha1.Escalation{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-escalation"},
+				Spec:       kudov1alpha1.EscalationSpec{PolicyName: "prod-read"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc       string
+		conditions []kudov1alpha1.PolicyCondition
+		wantResult escalationpolicy.ConditionsResult
+		wantErr    bool
+	}{
+		{
+			desc:       "allows without conditions",
+			wantResult: escalationpolicy.ConditionsResult{},
+		},
+		{
+			desc: "denies with the condition message",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{
+					Expression: `duration > duration("1h")`,
+					Action:     kudov1alpha1.ConditionActionDeny,
+					Message:    "escalations can't last more than an hour",
+				},
+			},
+			wantResult: escalationpolicy.ConditionsResult{
+				Denied: true,
+				Reason: "escalations can't last more than an hour",
+			},
+		},
+		{
+			desc: "denies with a default message",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `activeEscalations.exists(e, e.policy == "prod-read")`, Action: kudov1alpha1.ConditionActionDeny},
+			},
+			wantResult: escalationpolicy.ConditionsResult{
+				Denied: true,
+				Reason: "denied by condition 0",
+			},
+		},
+		{
+			desc: "denies over the escalation beneficiaries",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{
+					Expression: `size(beneficiaries) > 0 && !reason.startsWith("WAR-ROOM")`,
+					Action:     kudov1alpha1.ConditionActionDeny,
+					Message:    "only war rooms can be shared",
+				},
+			},
+			wantResult: escalationpolicy.ConditionsResult{
+				Denied: true,
+				Reason: "only war rooms can be shared",
+			},
+		},
+		{
+			desc: "denies if no allow condition matches",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"dba" in groups`, Action: kudov1alpha1.ConditionActionAllow},
+				{Expression: `requestedNamespace == "other-app"`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantResult: escalationpolicy.ConditionsResult{
+				Denied: true,
+				Reason: "none of the policy allow conditions matched",
+			},
+		},
+		{
+			desc: "allows if one allow condition matches",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"dba" in groups`, Action: kudov1alpha1.ConditionActionAllow},
+				{Expression: `reason.startsWith("INC-") && "high" in extra["mfa-level"]`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantResult: escalationpolicy.ConditionsResult{},
+		},
+		{
+			desc: "requires a challenge",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `now.getHours() >= 18`, Action: kudov1alpha1.ConditionActionRequireChallenge},
+				{Expression: `requestor == "jean-testor"`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantResult: escalationpolicy.ConditionsResult{
+				ChallengeRequired: true,
+			},
+		},
+		{
+			desc: "returns an error if a condition can't be evaluated",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `"high" in extra["department"]`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "returns an error if a condition is invalid",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `unknown == "value"`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotResult, err := escalationpolicy.EvaluateConditions(context.Background(), testCase.conditions, input)
+			if testCase.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantResult, gotResult)
+		})
+	}
+}
+
+func TestEvaluateConditions_CostLimit(t *testing.T) {
+	groups := make([]string, 1100)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("group-%d", i)
+	}
+
+	_, err := escalationpolicy.EvaluateConditions(
+		context.Background(),
+		[]kudov1alpha1.PolicyCondition{
+			{Expression: `groups.all(g, groups.all(h, g == h || g != h))`, Action: kudov1alpha1.ConditionActionAllow},
+		},
+		escalationpolicy.ConditionInput{Requestor: "jean-testor", Groups: groups},
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cost limit exceeded")
+}
+
+func TestValidateConditions(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		conditions []kudov1alpha1.PolicyCondition
+		wantErr    string
+	}{
+		{
+			desc: "accepts valid conditions",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `groups.exists(g, g == "sre") && reason.contains("INC-")`, Action: kudov1alpha1.ConditionActionAllow},
+				{Expression: `activeEscalations.exists(e, e.policy == "prod-read")`, Action: kudov1alpha1.ConditionActionDeny},
+			},
+		},
+		{
+			desc: "rejects conditions that are too long",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `reason == "` + strings.Repeat("a", 1024) + `"`, Action: kudov1alpha1.ConditionActionAllow},
+			},
+			wantErr: "condition 0 is too long, expressions must be at most 1024 characters long",
+		},
+		{
+			desc: "rejects conditions that are too expensive to evaluate",
+			conditions: []kudov1alpha1.PolicyCondition{
+				{Expression: `requestor == "jean-testor"`, Action: kudov1alpha1.ConditionActionAllow},
+				{Expression: `groups.all(g, groups.all(h, beneficiaries.all(b, g + h != b)))`, Action: kudov1alpha1.ConditionActionDeny},
+			},
+			wantErr: "condition 1 is too expensive to evaluate",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			err := escalationpolicy.ValidateConditions(testCase.conditions)
+			if testCase.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
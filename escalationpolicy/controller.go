@@ -0,0 +1,262 @@
+package escalationpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/jlevesy/kudo/grant"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/controllersupport"
+)
+
+type EscalationPoliciesGetter interface {
+	Get(name string) (*kudov1alpha1.EscalationPolicy, error)
+}
+
+type EscalationPoliciesLister interface {
+	List(selector labels.Selector) ([]*kudov1alpha1.EscalationPolicy, error)
+}
+
+type EscalationPolicyStatusUpdater interface {
+	UpdateStatus(ctx context.Context, policy *kudov1alpha1.EscalationPolicy, opts metav1.UpdateOptions) (*kudov1alpha1.EscalationPolicy, error)
+}
+
+type EscalationsLister interface {
+	List(selector labels.Selector) ([]*kudov1alpha1.Escalation, error)
+}
+
+type EventInsight = controllersupport.EventInsight[kudov1alpha1.EscalationPolicy]
+
+// Controller maintains the status of escalation policies.
+// It reports whether a policy can be used, and how it is used by escalations.
+type Controller struct {
+	policiesGetter    EscalationPoliciesGetter
+	templatesGetter   EscalationPolicyTemplatesGetter
+	escalationsLister EscalationsLister
+	policiesClient    EscalationPolicyStatusUpdater
+	granterFactory    grant.Factory
+}
+
+func NewController(
+	policiesGetter EscalationPoliciesGetter,
+	templatesGetter EscalationPolicyTemplatesGetter,
+	escalationsLister EscalationsLister,
+	policiesClient EscalationPolicyStatusUpdater,
+	granterFactory grant.Factory,
+) *Controller {
+	return &Controller{
+		policiesGetter:    policiesGetter,
+		templatesGetter:   templatesGetter,
+		escalationsLister: escalationsLister,
+		policiesClient:    policiesClient,
+		granterFactory:    granterFactory,
+	}
+}
+
+func (c *Controller) OnAdd(ctx context.Context, policy *kudov1alpha1.EscalationPolicy) (EventInsight, error) {
+	return EventInsight{}, c.reconcile(ctx, policy.Name)
+}
+
+func (c *Controller) OnUpdate(ctx context.Context, _, policy *kudov1alpha1.EscalationPolicy) (EventInsight, error) {
+	return EventInsight{}, c.reconcile(ctx, policy.Name)
+}
+
+func (c *Controller) OnDelete(_ context.Context, _ *kudov1alpha1.EscalationPolicy) (EventInsight, error) {
+	return EventInsight{}, nil
+}
+
+// reconcile works on the latest known version of the policy, as queued events can be outdated.
+func (c *Controller) reconcile(ctx context.Context, name string) error {
+	policy, err := c.policiesGetter.Get(name)
+	switch {
+	case errors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	status, err := c.buildStatus(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(policy.Status, status) {
+		return nil
+	}
+
+	clonedPolicy := policy.DeepCopy()
+	clonedPolicy.Status = status
+
+	_, err = c.policiesClient.UpdateStatus(ctx, clonedPolicy, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	klog.InfoS("Updated policy status", "policy", policy.Name, "valid", status.Valid)
+
+	return nil
+}
+
+func (c *Controller) buildStatus(ctx context.Context, policy *kudov1alpha1.EscalationPolicy) (kudov1alpha1.EscalationPolicyStatus, error) {
+	problems, err := c.validate(ctx, policy)
+	if err != nil {
+		return kudov1alpha1.EscalationPolicyStatus{}, err
+	}
+
+	status := kudov1alpha1.EscalationPolicyStatus{
+		ObservedGeneration: policy.Generation,
+		Valid:              len(problems) == 0,
+		Problems:           problems,
+		LastUsedAt:         policy.Status.LastUsedAt.DeepCopy(),
+	}
+
+	escalations, err := c.escalationsLister.List(labels.Everything())
+	if err != nil {
+		return kudov1alpha1.EscalationPolicyStatus{}, err
+	}
+
+	for _, esc := range escalations {
+		if esc.Spec.PolicyNamespace != "" || esc.Spec.PolicyName != policy.Name {
+			continue
+		}
+
+		switch esc.Status.State {
+		case kudov1alpha1.StateAccepted:
+			status.ActiveEscalations++
+		case kudov1alpha1.StatePending, kudov1alpha1.StateScheduled:
+			status.PendingEscalations++
+		}
+
+		// Escalations can be deleted once finished, keep the previous value if it is more recent.
+		if status.LastUsedAt == nil || status.LastUsedAt.Before(&esc.CreationTimestamp) {
+			lastUsedAt := esc.CreationTimestamp
+			status.LastUsedAt = &lastUsedAt
+		}
+	}
+
+	return status, nil
+}
+
+// validate returns the problems preventing escalations from using the policy.
+func (c *Controller) validate(ctx context.Context, policy *kudov1alpha1.EscalationPolicy) ([]string, error) {
+	spec := policy.Spec
+
+	if spec.TemplateRef != nil {
+		template, err := c.templatesGetter.Get(spec.TemplateRef.Name)
+		switch {
+		case errors.IsNotFound(err):
+			return []string{fmt.Sprintf("template %q doesn't exist", spec.TemplateRef.Name)}, nil
+		case err != nil:
+			return nil, err
+		}
+
+		spec, err = ApplyTemplate(spec, template.Spec)
+		if err != nil {
+			return []string{fmt.Sprintf("template %q can't be applied: %s", policy.Spec.TemplateRef.Name, err)}, nil
+		}
+	}
+
+	var problems []string
+
+	for i, grant := range spec.Target.Grants {
+		granter, err := c.granterFactory.Get(grant.Kind)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("grant %d: %s", i, err))
+			continue
+		}
+
+		if err := granter.ValidatePolicy(ctx, grant); err != nil {
+			problems = append(problems, fmt.Sprintf("grant %d: %s", i, err))
+		}
+	}
+
+	return problems, nil
+}
+
+// NewEscalationEventHandler returns an event handler requeuing the policy of an escalation each time the escalation
+// is created, changes state or is deleted, so that the usage reported in the policy status stays up to date.
+func NewEscalationEventHandler(policiesGetter EscalationPoliciesGetter, policyHandler cache.ResourceEventHandler) cache.ResourceEventHandler {
+	requeue := func(obj any) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		esc, ok := obj.(*kudov1alpha1.Escalation)
+		if !ok || esc.Spec.PolicyNamespace != "" {
+			return
+		}
+
+		policy, err := policiesGetter.Get(esc.Spec.PolicyName)
+		if err != nil {
+			return
+		}
+
+		policyHandler.OnUpdate(policy, policy)
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: requeue,
+		UpdateFunc: func(oldObj, newObj any) {
+			oldEsc, okOld := oldObj.(*kudov1alpha1.Escalation)
+			newEsc, okNew := newObj.(*kudov1alpha1.Escalation)
+
+			if okOld && okNew && oldEsc.Status.State == newEsc.Status.State {
+				return
+			}
+
+			requeue(newObj)
+		},
+		DeleteFunc: requeue,
+	}
+}
+
+// NewTemplateEventHandler returns an event handler requeuing the policies inheriting from a template each time the template
+// is created, its spec changes or it is deleted, so that the validity reported in the policies status stays up to date.
+func NewTemplateEventHandler(policiesLister EscalationPoliciesLister, policyHandler cache.ResourceEventHandler) cache.ResourceEventHandler {
+	requeue := func(obj any) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		template, ok := obj.(*kudov1alpha1.EscalationPolicyTemplate)
+		if !ok {
+			return
+		}
+
+		policies, err := policiesLister.List(labels.Everything())
+		if err != nil {
+			klog.ErrorS(err, "Unable to list the policies inheriting from a template", "template", template.Name)
+			return
+		}
+
+		for _, policy := range policies {
+			if policy.Spec.TemplateRef == nil || policy.Spec.TemplateRef.Name != template.Name {
+				continue
+			}
+
+			policyHandler.OnUpdate(policy, policy)
+		}
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: requeue,
+		UpdateFunc: func(oldObj, newObj any) {
+			oldTemplate, okOld := oldObj.(*kudov1alpha1.EscalationPolicyTemplate)
+			newTemplate, okNew := newObj.(*kudov1alpha1.EscalationPolicyTemplate)
+
+			if okOld && okNew && oldTemplate.Generation == newTemplate.Generation {
+				return
+			}
+
+			requeue(newObj)
+		},
+		DeleteFunc: requeue,
+	}
+}
@@ -0,0 +1,326 @@
+package escalationpolicy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/jlevesy/kudo/escalationpolicy"
+	"github.com/jlevesy/kudo/grant"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/controllersupport"
+	kudofake "github.com/jlevesy/kudo/pkg/generated/clientset/versioned/fake"
+	kudoinformers "github.com/jlevesy/kudo/pkg/generated/informers/externalversions"
+)
+
+func TestController_OnAdd(t *testing.T) {
+	var (
+		now = time.Date(2022, time.October, 3, 12, 0, 0, 0, time.UTC)
+
+		clusterRole = &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}}
+
+		clusterRoleGrant = kudov1alpha1.MustEncodeValueWithKind(
+			kudov1alpha1.GrantKindK8sRoleBinding,
+			kudov1alpha1.K8sRoleBindingGrant{
+				DefaultNamespace: "some-app",
+				RoleRef:          rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			},
+		)
+
+		policy = func(status kudov1alpha1.EscalationPolicyStatus, grants ...kudov1alpha1.ValueWithKind) *kudov1alpha1.EscalationPolicy {
+			return &kudov1alpha1.EscalationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-policy", Generation: 3},
+				Spec: kudov1alpha1.EscalationPolicySpec{
+					Target: kudov1alpha1.EscalationTarget{Grants: grants},
+				},
+				Status: status,
+			}
+		}
+
+		escalation = func(name, policyNamespace, policyName string, state kudov1alpha1.EscalationState, age time.Duration) *kudov1alpha1.Escalation {
+			return &kudov1alpha1.Escalation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				},
+				Spec: kudov1alpha1.EscalationSpec{
+					PolicyName:      policyName,
+					PolicyNamespace: policyNamespace,
+				},
+				Status: kudov1alpha1.EscalationStatus{State: state},
+			}
+		}
+
+		timeAt = func(age time.Duration) *metav1.Time {
+			t := metav1.NewTime(now.Add(-age))
+			return &t
+		}
+	)
+
+	testCases := []struct {
+		desc       string
+		policy     *kudov1alpha1.EscalationPolicy
+		kudoSeed   []runtime.Object
+		kubeSeed   []runtime.Object
+		wantStatus kudov1alpha1.EscalationPolicyStatus
+	}{
+		{
+			desc:   "reports a valid policy without any escalation",
+			policy: policy(kudov1alpha1.EscalationPolicyStatus{}, clusterRoleGrant),
+			kubeSeed: []runtime.Object{
+				clusterRole,
+			},
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Valid:              true,
+			},
+		},
+		{
+			desc:   "counts the active and pending escalations using the policy",
+			policy: policy(kudov1alpha1.EscalationPolicyStatus{}, clusterRoleGrant),
+			kudoSeed: []runtime.Object{
+				escalation("accepted", "", "some-policy", kudov1alpha1.StateAccepted, 3*time.Hour),
+				escalation("pending", "", "some-policy", kudov1alpha1.StatePending, 2*time.Hour),
+				escalation("scheduled", "", "some-policy", kudov1alpha1.StateScheduled, time.Hour),
+				escalation("expired", "", "some-policy", kudov1alpha1.StateExpired, 4*time.Hour),
+				escalation("other-policy", "", "other-policy", kudov1alpha1.StateAccepted, time.Minute),
+				escalation("namespaced-policy", "team-a", "some-policy", kudov1alpha1.StateAccepted, time.Minute),
+			},
+			kubeSeed: []runtime.Object{
+				clusterRole,
+			},
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Valid:              true,
+				ActiveEscalations:  1,
+				PendingEscalations: 2,
+				LastUsedAt:         timeAt(time.Hour),
+			},
+		},
+		{
+			desc: "keeps the last usage if it is more recent than the existing escalations",
+			policy: policy(
+				kudov1alpha1.EscalationPolicyStatus{LastUsedAt: timeAt(time.Minute)},
+				clusterRoleGrant,
+			),
+			kudoSeed: []runtime.Object{
+				escalation("expired", "", "some-policy", kudov1alpha1.StateExpired, 4*time.Hour),
+			},
+			kubeSeed: []runtime.Object{
+				clusterRole,
+			},
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Valid:              true,
+				LastUsedAt:         timeAt(time.Minute),
+			},
+		},
+		{
+			desc: "reports the grants binding a role that doesn't exist and the unknown grant kinds",
+			policy: policy(
+				kudov1alpha1.EscalationPolicyStatus{},
+				clusterRoleGrant,
+				kudov1alpha1.MustEncodeValueWithKind("Unknown", struct{}{}),
+			),
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Problems: []string{
+					"grant 0: grant role does not exist: cluster role edit",
+					"grant 1: unknown kind Unknown",
+				},
+			},
+		},
+		{
+			desc: "reports a missing template",
+			policy: &kudov1alpha1.EscalationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-policy", Generation: 3},
+				Spec: kudov1alpha1.EscalationPolicySpec{
+					TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+				},
+			},
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Problems:           []string{`template "base" doesn't exist`},
+			},
+		},
+		{
+			desc: "validates the grants inherited from the template",
+			policy: &kudov1alpha1.EscalationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-policy", Generation: 3},
+				Spec: kudov1alpha1.EscalationPolicySpec{
+					TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+				},
+			},
+			kudoSeed: []runtime.Object{
+				&kudov1alpha1.EscalationPolicyTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "base"},
+					Spec: kudov1alpha1.EscalationPolicySpec{
+						Target: kudov1alpha1.EscalationTarget{
+							Grants: []kudov1alpha1.ValueWithKind{clusterRoleGrant},
+						},
+					},
+				},
+			},
+			wantStatus: kudov1alpha1.EscalationPolicyStatus{
+				ObservedGeneration: 3,
+				Problems:           []string{"grant 0: grant role does not exist: cluster role edit"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller, kudoClientSet, done := buildController(
+				t,
+				append([]runtime.Object{testCase.policy}, testCase.kudoSeed...),
+				testCase.kubeSeed,
+			)
+			defer done()
+
+			_, err := controller.OnAdd(ctx, testCase.policy)
+			require.NoError(t, err)
+
+			gotPolicy, err := kudoClientSet.K8sV1alpha1().EscalationPolicies().Get(ctx, testCase.policy.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, gotPolicy.Status)
+		})
+	}
+}
+
+func buildController(t *testing.T, kudoSeed, kubeSeed []runtime.Object) (*escalationpolicy.Controller, *kudofake.Clientset, func()) {
+	t.Helper()
+
+	var (
+		kudoClientSet        = kudofake.NewSimpleClientset(kudoSeed...)
+		kudoInformersFactory = kudoinformers.NewSharedInformerFactory(kudoClientSet, 60*time.Second)
+		controller           = escalationpolicy.NewController(
+			kudoInformersFactory.K8s().V1alpha1().EscalationPolicies().Lister(),
+			kudoInformersFactory.K8s().V1alpha1().EscalationPolicyTemplates().Lister(),
+			kudoInformersFactory.K8s().V1alpha1().Escalations().Lister(),
+			kudoClientSet.K8sV1alpha1().EscalationPolicies(),
+			buildGranterFactory(t, kubeSeed...),
+		)
+		done = make(chan struct{})
+	)
+
+	kudoInformersFactory.Start(done)
+
+	require.NoError(t, controllersupport.CheckInformerSync(kudoInformersFactory.WaitForCacheSync(done)))
+
+	return controller, kudoClientSet, func() { close(done) }
+}
+
+// buildGranterFactory returns the default granter factory, backed by a fake cluster holding the given objects.
+func buildGranterFactory(t *testing.T, kubeSeed ...runtime.Object) grant.Factory {
+	t.Helper()
+
+	var (
+		kubeClientSet        = kubefake.NewSimpleClientset(kubeSeed...)
+		kubeInformersFactory = kubeinformers.NewSharedInformerFactory(kubeClientSet, 60*time.Second)
+		granterFactory       = grant.DefaultGranterFactory(kubeInformersFactory, kubeClientSet)
+		done                 = make(chan struct{})
+	)
+
+	t.Cleanup(func() { close(done) })
+
+	kubeInformersFactory.Start(done)
+
+	require.NoError(t, controllersupport.CheckInformerSync(kubeInformersFactory.WaitForCacheSync(done)))
+
+	return granterFactory
+}
+
+func TestTemplateEventHandler(t *testing.T) {
+	var (
+		template = func(generation int64) *kudov1alpha1.EscalationPolicyTemplate {
+			return &kudov1alpha1.EscalationPolicyTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-template", Generation: generation},
+			}
+		}
+
+		policy = func(name, templateName string) *kudov1alpha1.EscalationPolicy {
+			policy := &kudov1alpha1.EscalationPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if templateName != "" {
+				policy.Spec.TemplateRef = &kudov1alpha1.PolicyTemplateRef{Name: templateName}
+			}
+			return policy
+		}
+
+		policies = []runtime.Object{
+			policy("templated-policy", "some-template"),
+			policy("other-templated-policy", "other-template"),
+			policy("plain-policy", ""),
+		}
+	)
+
+	testCases := []struct {
+		desc         string
+		notify       func(handler cache.ResourceEventHandler)
+		wantRequeued []string
+	}{
+		{
+			desc:         "requeues the policies inheriting from a created template",
+			notify:       func(handler cache.ResourceEventHandler) { handler.OnAdd(template(1)) },
+			wantRequeued: []string{"templated-policy"},
+		},
+		{
+			desc:         "requeues the policies inheriting from a template whose spec has changed",
+			notify:       func(handler cache.ResourceEventHandler) { handler.OnUpdate(template(1), template(2)) },
+			wantRequeued: []string{"templated-policy"},
+		},
+		{
+			desc:   "ignores template updates that don't change its spec",
+			notify: func(handler cache.ResourceEventHandler) { handler.OnUpdate(template(2), template(2)) },
+		},
+		{
+			desc: "requeues the policies inheriting from a deleted template",
+			notify: func(handler cache.ResourceEventHandler) {
+				handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "some-template", Obj: template(2)})
+			},
+			wantRequeued: []string{"templated-policy"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				kudoClientSet        = kudofake.NewSimpleClientset(policies...)
+				kudoInformersFactory = kudoinformers.NewSharedInformerFactory(kudoClientSet, 60*time.Second)
+				done                 = make(chan struct{})
+
+				gotRequeued   []string
+				policyHandler = cache.ResourceEventHandlerFuncs{
+					UpdateFunc: func(_, newObj any) {
+						gotRequeued = append(gotRequeued, newObj.(*kudov1alpha1.EscalationPolicy).Name)
+					},
+				}
+				handler = escalationpolicy.NewTemplateEventHandler(
+					kudoInformersFactory.K8s().V1alpha1().EscalationPolicies().Lister(),
+					policyHandler,
+				)
+			)
+
+			defer close(done)
+
+			kudoInformersFactory.Start(done)
+
+			require.NoError(t, controllersupport.CheckInformerSync(kudoInformersFactory.WaitForCacheSync(done)))
+
+			testCase.notify(handler)
+
+			assert.Equal(t, testCase.wantRequeued, gotRequeued)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package escalationpolicy
+
+import (
+	"fmt"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+// ValidateNamespacedTarget makes sure that the target of a namespaced policy is confined to the given namespace.
+// Only Kubernetes role bindings to the policy namespace are allowed, binding roles of this namespace or one of the allowed cluster roles.
+func ValidateNamespacedTarget(namespace string, target kudov1alpha1.EscalationTarget, allowedClusterRoles []string) error {
+	for i, grant := range target.Grants {
+		if grant.Kind != kudov1alpha1.GrantKindK8sRoleBinding {
+			return fmt.Errorf("grant %d is of kind %s, only %s grants are allowed", i, grant.Kind, kudov1alpha1.GrantKindK8sRoleBinding)
+		}
+
+		k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+		if err != nil {
+			return fmt.Errorf("grant %d can't be decoded, %w", i, err)
+		}
+
+		if k8sGrant.DefaultNamespace != "" && k8sGrant.DefaultNamespace != namespace {
+			return fmt.Errorf("grant %d default namespace must be %q, got %q", i, namespace, k8sGrant.DefaultNamespace)
+		}
+
+		for _, allowedNamespace := range k8sGrant.AllowedNamespaces {
+			if allowedNamespace != namespace {
+				return fmt.Errorf("grant %d allowed namespaces must only contain %q, got %q", i, namespace, allowedNamespace)
+			}
+		}
+
+		switch k8sGrant.RoleRef.Kind {
+		case "Role":
+		case "ClusterRole":
+			if !generics.Contains(allowedClusterRoles, k8sGrant.RoleRef.Name) {
+				return fmt.Errorf("grant %d binds the cluster role %q, allowed cluster roles are %v", i, k8sGrant.RoleRef.Name, allowedClusterRoles)
+			}
+		default:
+			return fmt.Errorf("grant %d binds a role of kind %q, only Role and ClusterRole are allowed", i, k8sGrant.RoleRef.Kind)
+		}
+	}
+
+	return nil
+}
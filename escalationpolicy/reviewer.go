@@ -3,49 +3,380 @@ package escalationpolicy
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
+	"github.com/jlevesy/kudo/grant"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"github.com/jlevesy/kudo/pkg/webhooksupport"
 )
 
-type admissionReviewer struct{}
+type admissionReviewer struct {
+	templatesGetter EscalationPolicyTemplatesGetter
+	granterFactory  grant.Factory
+	verifyRoles     bool
+}
 
-func NewAdmissionReviewer() webhooksupport.AdmissionReviewer {
-	return &admissionReviewer{}
+// NewAdmissionReviewer reviews escalation policies once merged with their template, reporting all their problems at once.
+// Each grant is validated by its granter, the roles bound by grants are only required to exist if verifyRoles is set.
+func NewAdmissionReviewer(templatesGetter EscalationPolicyTemplatesGetter, granterFactory grant.Factory, verifyRoles bool) webhooksupport.AdmissionReviewer {
+	return &admissionReviewer{
+		templatesGetter: templatesGetter,
+		granterFactory:  granterFactory,
+		verifyRoles:     verifyRoles,
+	}
 }
 
 func (r *admissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	spec, resp, err := r.effectiveSpec(req)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	return reviewProblems(r.specProblems(ctx, spec)), nil
+}
+
+// effectiveSpec decodes the reviewed policy spec, and applies its template if it has one.
+// It returns a response if the policy has to be denied.
+func (r *admissionReviewer) effectiveSpec(req *admissionv1.AdmissionRequest) (kudov1alpha1.EscalationPolicySpec, *admissionv1.AdmissionResponse, error) {
 	var policy kudov1alpha1.EscalationPolicy
 
 	if err := json.Unmarshal(req.Object.Raw, &policy); err != nil {
 		klog.ErrorS(err, "Can't unmarhal object to review")
 
+		return kudov1alpha1.EscalationPolicySpec{}, nil, err
+	}
+
+	if policy.Spec.TemplateRef == nil {
+		return policy.Spec, nil, nil
+	}
+
+	template, err := r.templatesGetter.Get(policy.Spec.TemplateRef.Name)
+	switch {
+	case errors.IsNotFound(err):
+		klog.InfoS("policy refers to a template that doesn't exist", "template", policy.Spec.TemplateRef.Name)
+
+		return kudov1alpha1.EscalationPolicySpec{}, &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("Escalation policy template %q doesn't exist", policy.Spec.TemplateRef.Name),
+			},
+		}, nil
+	case err != nil:
+		return kudov1alpha1.EscalationPolicySpec{}, nil, err
+	}
+
+	spec, err := ApplyTemplate(policy.Spec, template.Spec)
+	if err != nil {
+		klog.InfoS("policy template can't be applied", "template", template.Name, "err", err)
+
+		return kudov1alpha1.EscalationPolicySpec{}, &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("Escalation policy template %q can't be applied: %s", template.Name, err),
+			},
+		}, nil
+	}
+
+	return spec, nil, nil
+}
+
+// specProblems returns all the reasons why the effective spec of a policy is not valid.
+func (r *admissionReviewer) specProblems(ctx context.Context, spec kudov1alpha1.EscalationPolicySpec) []string {
+	var problems []string
+
+	if len(spec.Subjects) == 0 {
+		problems = append(problems, "Escalation policy must have at least one subject")
+	}
+
+	if spec.Target.MaxDuration.Duration == 0 ||
+		spec.Target.DefaultDuration.Duration == 0 {
+		problems = append(problems, "Escalation policy must have a default and a max duration")
+	} else if spec.Target.DefaultDuration.Duration > spec.Target.MaxDuration.Duration {
+		problems = append(problems, "Escalation policy default duration must not exceed max duration")
+	}
+
+	problems = append(problems, subjectsProblems("subject", spec.Subjects)...)
+	problems = append(problems, subjectsProblems("excluded subject", spec.ExcludedSubjects)...)
+	problems = append(problems, challengesProblems(spec.Challenges)...)
+	problems = append(problems, r.grantsProblems(ctx, spec.Target)...)
+
+	switch spec.Target.GrantFailurePolicy {
+	case kudov1alpha1.GrantFailurePolicyUnknown, kudov1alpha1.GrantFailurePolicyRetry, kudov1alpha1.GrantFailurePolicyDeny:
+	default:
+		problems = append(
+			problems,
+			fmt.Sprintf(
+				"Escalation policy grant failure policy must be one of %s or %s, got %q",
+				kudov1alpha1.GrantFailurePolicyRetry,
+				kudov1alpha1.GrantFailurePolicyDeny,
+				spec.Target.GrantFailurePolicy,
+			),
+		)
+	}
+
+	if spec.Target.ExtensionsRequireApproval && !hasReviewers(spec) {
+		problems = append(problems, "Escalation policy requiring extensions approval must have at least one challenge reviewer")
+	}
+
+	if err := ValidateConditions(spec.Conditions); err != nil {
+		problems = append(problems, fmt.Sprintf("Escalation policy conditions are invalid: %s", err))
+	} else if requiresChallenge(spec) && !hasReviewers(spec) {
+		problems = append(problems, "Escalation policy with conditions requiring a challenge must have at least one challenge reviewer")
+	}
+
+	if spec.Delegation != nil {
+		if len(spec.Delegation.Delegates) == 0 {
+			problems = append(problems, "Escalation policy delegation must have at least one delegate")
+		}
+
+		problems = append(problems, subjectsProblems("delegation delegate", spec.Delegation.Delegates)...)
+	}
+
+	return problems
+}
+
+// grantsProblems makes sure that each grant is supported and valid, and that grant names and durations are consistent.
+func (r *admissionReviewer) grantsProblems(ctx context.Context, target kudov1alpha1.EscalationTarget) []string {
+	var (
+		problems   []string
+		grantNames = make(map[string]struct{})
+	)
+
+	for i, grant := range target.Grants {
+		commonSpec, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.GrantCommonSpec](grant)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Escalation policy grant %d can't be decoded: %s", i, err))
+			continue
+		}
+
+		if commonSpec.Name != "" {
+			// Escalations select grants by name or by index, a numeric name would be ambiguous.
+			if _, err := strconv.Atoi(commonSpec.Name); err == nil {
+				problems = append(problems, fmt.Sprintf("Escalation policy grant %d name must not be a number, got %q", i, commonSpec.Name))
+			}
+
+			if _, ok := grantNames[commonSpec.Name]; ok {
+				problems = append(problems, fmt.Sprintf("Escalation policy grant names must be unique, %q is used more than once", commonSpec.Name))
+			}
+
+			grantNames[commonSpec.Name] = struct{}{}
+		}
+
+		if target.MaxDuration.Duration != 0 && commonSpec.Duration.Duration > target.MaxDuration.Duration {
+			problems = append(problems, fmt.Sprintf("Escalation policy grant %d duration must not exceed max duration", i))
+		}
+
+		granter, err := r.granterFactory.Get(grant.Kind)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Escalation policy grant %d is not supported: %s", i, err))
+			continue
+		}
+
+		if err := granter.ValidatePolicy(ctx, grant); err != nil && !r.isIgnored(err) {
+			problems = append(problems, fmt.Sprintf("Escalation policy grant %d is invalid: %s", i, err))
+		}
+	}
+
+	return problems
+}
+
+// isIgnored returns true if the grant validation error is about a missing role, and roles are not verified.
+func (r *admissionReviewer) isIgnored(err error) bool {
+	return !r.verifyRoles && stderrors.Is(err, grant.ErrRoleNotFound)
+}
+
+// subjectsProblems makes sure that subjects can be matched against users: they must be of a known kind,
+// or have no kind and some extra attributes. Otherwise they would never match, which silently lets excluded users in.
+func subjectsProblems(field string, subjects []kudov1alpha1.PolicySubject) []string {
+	var problems []string
+
+	for i, subject := range subjects {
+		if subject.IsMatchable() {
+			continue
+		}
+
+		if subject.Kind == "" {
+			problems = append(problems, fmt.Sprintf("Escalation policy %s %d must have a kind or extra attributes", field, i))
+			continue
+		}
+
+		problems = append(
+			problems,
+			fmt.Sprintf(
+				"Escalation policy %s %d kind must be one of %s, %s or %s, got %q",
+				field,
+				i,
+				rbacv1.UserKind,
+				rbacv1.GroupKind,
+				rbacv1.ServiceAccountKind,
+				subject.Kind,
+			),
+		)
+	}
+
+	return problems
+}
+
+// challengesProblems makes sure that challenges are of a known kind, and have valid reviewers.
+func challengesProblems(challenges []kudov1alpha1.EscalationChallenge) []string {
+	var problems []string
+
+	for i, challenge := range challenges {
+		if challenge.Kind != kudov1alpha1.ChallengeKindPeerReview {
+			problems = append(
+				problems,
+				fmt.Sprintf("Escalation policy challenge %d kind must be %s, got %q", i, kudov1alpha1.ChallengeKindPeerReview, challenge.Kind),
+			)
+		}
+
+		if len(challenge.Reviewers) == 0 {
+			problems = append(problems, fmt.Sprintf("Escalation policy challenge %d must have at least one reviewer", i))
+		}
+
+		for j, reviewer := range challenge.Reviewers {
+			switch reviewer.Kind {
+			case rbacv1.UserKind, rbacv1.GroupKind:
+			case rbacv1.ServiceAccountKind:
+				if reviewer.Namespace == "" {
+					problems = append(problems, fmt.Sprintf("Escalation policy challenge %d reviewer %d must have a namespace", i, j))
+				}
+			default:
+				problems = append(
+					problems,
+					fmt.Sprintf(
+						"Escalation policy challenge %d reviewer %d kind must be one of %s, %s or %s, got %q",
+						i,
+						j,
+						rbacv1.UserKind,
+						rbacv1.GroupKind,
+						rbacv1.ServiceAccountKind,
+						reviewer.Kind,
+					),
+				)
+			}
+
+			if reviewer.Name == "" {
+				problems = append(problems, fmt.Sprintf("Escalation policy challenge %d reviewer %d must have a name", i, j))
+			}
+		}
+	}
+
+	return problems
+}
+
+// reviewProblems denies the policy if it has any problem, all of them are reported at once.
+func reviewProblems(problems []string) *admissionv1.AdmissionResponse {
+	if len(problems) > 0 {
+		klog.InfoS("policy is invalid", "problems", problems)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: strings.Join(problems, "; "),
+			},
+		}
+	}
+
+	return &admissionv1.AdmissionResponse{
+		Allowed: true,
+		Result:  &metav1.Status{Status: metav1.StatusSuccess},
+	}
+}
+
+type namespacedAdmissionReviewer struct {
+	policyReviewer      *admissionReviewer
+	allowedClusterRoles []string
+}
+
+// NewNamespacedAdmissionReviewer reviews namespaced policies like cluster wide ones,
+// and makes sure that their grants are confined to their namespace.
+func NewNamespacedAdmissionReviewer(
+	templatesGetter EscalationPolicyTemplatesGetter,
+	granterFactory grant.Factory,
+	verifyRoles bool,
+	allowedClusterRoles []string,
+) webhooksupport.AdmissionReviewer {
+	return &namespacedAdmissionReviewer{
+		policyReviewer: &admissionReviewer{
+			templatesGetter: templatesGetter,
+			granterFactory:  granterFactory,
+			verifyRoles:     verifyRoles,
+		},
+		allowedClusterRoles: allowedClusterRoles,
+	}
+}
+
+func (r *namespacedAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	spec, resp, err := r.policyReviewer.effectiveSpec(req)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	problems := r.policyReviewer.specProblems(ctx, spec)
+
+	if err := ValidateNamespacedTarget(req.Namespace, spec.Target, r.allowedClusterRoles); err != nil {
+		problems = append(problems, fmt.Sprintf("Namespaced escalation policy target is not allowed: %s", err))
+	}
+
+	return reviewProblems(problems), nil
+}
+
+type templateAdmissionReviewer struct{}
+
+// NewTemplateAdmissionReviewer reviews policy templates. A template can be partial,
+// policies are reviewed once merged with their template.
+func NewTemplateAdmissionReviewer() webhooksupport.AdmissionReviewer {
+	return &templateAdmissionReviewer{}
+}
+
+func (r *templateAdmissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	var template kudov1alpha1.EscalationPolicyTemplate
+
+	if err := json.Unmarshal(req.Object.Raw, &template); err != nil {
+		klog.ErrorS(err, "Can't unmarhal object to review")
+
 		return nil, err
 	}
 
-	if policy.Spec.Target.MaxDuration.Duration == 0 ||
-		policy.Spec.Target.DefaultDuration.Duration == 0 {
-		klog.Info("policy doesn't have a default or a max duration")
+	if template.Spec.TemplateRef != nil {
+		klog.InfoS("policy template refers to another template", "template", template.Spec.TemplateRef.Name)
 
 		return &admissionv1.AdmissionResponse{
 			Result: &metav1.Status{
 				Status:  metav1.StatusFailure,
-				Message: "Escalation policy must have a default and a max duration",
+				Message: "Escalation policy templates can't inherit from another template",
 			},
 		}, nil
 	}
 
-	if policy.Spec.Target.DefaultDuration.Duration > policy.Spec.Target.MaxDuration.Duration {
-		klog.Info("policy has a default duration that exceeds the max duration")
+	if template.Spec.Target.MaxDuration.Duration != 0 &&
+		template.Spec.Target.DefaultDuration.Duration > template.Spec.Target.MaxDuration.Duration {
+		klog.Info("policy template has a default duration that exceeds the max duration")
 
 		return &admissionv1.AdmissionResponse{
 			Result: &metav1.Status{
 				Status:  metav1.StatusFailure,
-				Message: "Escalation policy default duration must not exceed max duration",
+				Message: "Escalation policy template default duration must not exceed max duration",
+			},
+		}, nil
+	}
+
+	if err := ValidateConditions(template.Spec.Conditions); err != nil {
+		klog.InfoS("policy template has invalid conditions", "err", err)
+
+		return &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("Escalation policy template conditions are invalid: %s", err),
 			},
 		}, nil
 	}
@@ -55,3 +386,23 @@ func (r *admissionReviewer) ReviewAdmission(ctx context.Context, req *admissionv
 		Result:  &metav1.Status{Status: metav1.StatusSuccess},
 	}, nil
 }
+
+func hasReviewers(spec kudov1alpha1.EscalationPolicySpec) bool {
+	for _, challenge := range spec.Challenges {
+		if len(challenge.Reviewers) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func requiresChallenge(spec kudov1alpha1.EscalationPolicySpec) bool {
+	for _, condition := range spec.Conditions {
+		if condition.Action == kudov1alpha1.ConditionActionRequireChallenge {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -16,6 +18,56 @@ import (
 	"github.com/jlevesy/kudo/pkg/webhooksupport/webhooktesting"
 )
 
+type staticTemplatesGetter map[string]*kudov1alpha1.EscalationPolicyTemplate
+
+func (g staticTemplatesGetter) Get(name string) (*kudov1alpha1.EscalationPolicyTemplate, error) {
+	template, ok := g[name]
+	if !ok {
+		return nil, errors.NewNotFound(kudov1alpha1.Resource("escalationpolicytemplates"), name)
+	}
+
+	return template, nil
+}
+
+var (
+	testSubjects = []kudov1alpha1.PolicySubject{
+		{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "squad-a"}},
+	}
+
+	roleBindingGrant = func(common kudov1alpha1.GrantCommonSpec) kudov1alpha1.ValueWithKind {
+		return kudov1alpha1.MustEncodeValueWithKind(
+			kudov1alpha1.GrantKindK8sRoleBinding,
+			kudov1alpha1.K8sRoleBindingGrant{
+				GrantCommonSpec:  common,
+				DefaultNamespace: "some-app",
+				RoleRef:          rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			},
+		)
+	}
+)
+
+var templatesGetter = staticTemplatesGetter{
+	"base": &kudov1alpha1.EscalationPolicyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "base"},
+		Spec: kudov1alpha1.EscalationPolicySpec{
+			Subjects: testSubjects,
+			Target: kudov1alpha1.EscalationTarget{
+				DefaultDuration: metav1.Duration{Duration: time.Second},
+				MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+				Grants: []kudov1alpha1.ValueWithKind{
+					kudov1alpha1.MustEncodeValueWithKind(
+						kudov1alpha1.GrantKindK8sRoleBinding,
+						kudov1alpha1.K8sRoleBindingGrant{
+							DefaultNamespace: "some-app",
+							RoleRef:          rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+						},
+					),
+				},
+			},
+		},
+	},
+}
+
 func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -36,6 +88,7 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 						t,
 						kudov1alpha1.EscalationPolicy{
 							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
 								Target: kudov1alpha1.EscalationTarget{
 									MaxDuration: metav1.Duration{Duration: time.Second},
 								},
@@ -65,6 +118,7 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 						t,
 						kudov1alpha1.EscalationPolicy{
 							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
 								Target: kudov1alpha1.EscalationTarget{
 									DefaultDuration: metav1.Duration{Duration: time.Second},
 								},
@@ -94,6 +148,7 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 						t,
 						kudov1alpha1.EscalationPolicy{
 							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
 								Target: kudov1alpha1.EscalationTarget{
 									DefaultDuration: metav1.Duration{Duration: 2 * time.Second},
 									MaxDuration:     metav1.Duration{Duration: time.Second},
@@ -112,7 +167,282 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 			},
 		},
 		{
-			desc: "accepts valid duration",
+			desc: "denies if a grant duration exceeds the max duration",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+									Grants: []kudov1alpha1.ValueWithKind{
+										roleBindingGrant(kudov1alpha1.GrantCommonSpec{Duration: metav1.Duration{Duration: 3 * time.Second}}),
+									},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant 0 duration must not exceed max duration",
+				},
+			},
+		},
+		{
+			desc: "denies if grant names are not unique",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+									Grants: []kudov1alpha1.ValueWithKind{
+										roleBindingGrant(kudov1alpha1.GrantCommonSpec{Name: "view"}),
+										roleBindingGrant(kudov1alpha1.GrantCommonSpec{Name: "view"}),
+									},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant names must be unique, \"view\" is used more than once",
+				},
+			},
+		},
+		{
+			desc: "denies if a grant name is a number",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+									Grants: []kudov1alpha1.ValueWithKind{
+										roleBindingGrant(kudov1alpha1.GrantCommonSpec{Name: "view"}),
+										roleBindingGrant(kudov1alpha1.GrantCommonSpec{Name: "0"}),
+									},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant 1 name must not be a number, got \"0\"",
+				},
+			},
+		},
+		{
+			desc: "denies if a grant can't be decoded",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+									Grants: []kudov1alpha1.ValueWithKind{
+										kudov1alpha1.MustEncodeValueWithKind(
+											kudov1alpha1.GrantKindK8sRoleBinding,
+											map[string]any{"duration": 42},
+										),
+									},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant 0 can't be decoded: json: cannot unmarshal number into Go value of type string",
+				},
+			},
+		},
+		{
+			desc: "denies if grant failure policy is unknown",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration:    metav1.Duration{Duration: time.Second},
+									MaxDuration:        metav1.Duration{Duration: 2 * time.Second},
+									AtomicGrants:       true,
+									GrantFailurePolicy: "Pray",
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant failure policy must be one of Retry or Deny, got \"Pray\"",
+				},
+			},
+		},
+		{
+			desc: "denies if extensions require approval without any reviewer",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration:           metav1.Duration{Duration: time.Second},
+									MaxDuration:               metav1.Duration{Duration: 2 * time.Second},
+									ExtensionsRequireApproval: true,
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy requiring extensions approval must have at least one challenge reviewer",
+				},
+			},
+		},
+		{
+			desc: "denies if a condition action is unknown",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Conditions: []kudov1alpha1.PolicyCondition{
+									{Expression: "true", Action: "Maybe"},
+								},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy conditions are invalid: condition 0 action must be one of Allow, Deny or RequireChallenge, got \"Maybe\"",
+				},
+			},
+		},
+		{
+			desc: "denies if a condition does not return a bool",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Conditions: []kudov1alpha1.PolicyCondition{
+									{Expression: "true", Action: kudov1alpha1.ConditionActionAllow},
+									{Expression: "duration", Action: kudov1alpha1.ConditionActionDeny},
+								},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy conditions are invalid: condition 1 is invalid, expression must return a bool, got google.protobuf.Duration",
+				},
+			},
+		},
+		{
+			desc: "denies if conditions require a challenge without any reviewer",
 			req: &admissionv1.AdmissionRequest{
 				Kind: metav1.GroupVersionKind{
 					Group:   kudo.GroupName,
@@ -124,6 +454,10 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 						t,
 						kudov1alpha1.EscalationPolicy{
 							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Conditions: []kudov1alpha1.PolicyCondition{
+									{Expression: "now.getHours() > 18", Action: kudov1alpha1.ConditionActionRequireChallenge},
+								},
 								Target: kudov1alpha1.EscalationTarget{
 									DefaultDuration: metav1.Duration{Duration: time.Second},
 									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
@@ -133,6 +467,656 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 					).Bytes(),
 				},
 			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy with conditions requiring a challenge must have at least one challenge reviewer",
+				},
+			},
+		},
+		{
+			desc: "denies if delegation has no delegates",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects:   testSubjects,
+								Delegation: &kudov1alpha1.EscalationDelegation{RequireAcknowledgement: true},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy delegation must have at least one delegate",
+				},
+			},
+		},
+		{
+			desc: "denies if subjects can't be matched",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: []kudov1alpha1.PolicySubject{
+									{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "devs"}},
+									{Subject: rbacv1.Subject{Kind: "Team", Name: "sre"}},
+								},
+								ExcludedSubjects: []kudov1alpha1.PolicySubject{
+									{Subject: rbacv1.Subject{Kind: "Users", Name: "jean-testor"}},
+									{Subject: rbacv1.Subject{Name: "jeanne-testeuse"}},
+									{Extra: map[string][]string{"team": {"interns"}}},
+								},
+								Delegation: &kudov1alpha1.EscalationDelegation{
+									Delegates: []kudov1alpha1.PolicySubject{
+										{Subject: rbacv1.Subject{Kind: "Bot", Name: "deployer"}},
+									},
+								},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: "Failure",
+					Message: `Escalation policy subject 1 kind must be one of User, Group or ServiceAccount, got "Team"; ` +
+						`Escalation policy excluded subject 0 kind must be one of User, Group or ServiceAccount, got "Users"; ` +
+						"Escalation policy excluded subject 1 must have a kind or extra attributes; " +
+						`Escalation policy delegation delegate 0 kind must be one of User, Group or ServiceAccount, got "Bot"`,
+				},
+			},
+		},
+		{
+			desc: "accepts valid conditions",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Conditions: []kudov1alpha1.PolicyCondition{
+									{Expression: `"sre" in groups && duration <= duration("1h")`, Action: kudov1alpha1.ConditionActionAllow},
+									{Expression: "size(activeEscalations) > 2", Action: kudov1alpha1.ConditionActionDeny},
+								},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+		{
+			desc: "reports all the problems at once",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Challenges: []kudov1alpha1.EscalationChallenge{
+									{Kind: "Vote", Reviewers: []rbacv1.Subject{{Kind: "Team", Name: "sre"}}},
+									{Kind: kudov1alpha1.ChallengeKindPeerReview},
+								},
+								Target: kudov1alpha1.EscalationTarget{
+									MaxDuration: metav1.Duration{Duration: time.Second},
+									Grants: []kudov1alpha1.ValueWithKind{
+										kudov1alpha1.MustEncodeValueWithKind("Unknown", kudov1alpha1.GrantCommonSpec{Name: "unknown"}),
+										kudov1alpha1.MustEncodeValueWithKind(
+											kudov1alpha1.GrantKindK8sRoleBinding,
+											struct {
+												DefaultNamespace int `json:"defaultNamespace"`
+											}{DefaultNamespace: 42},
+										),
+										kudov1alpha1.MustEncodeValueWithKind(
+											kudov1alpha1.GrantKindEKSAwsAuth,
+											kudov1alpha1.EKSAwsAuthGrant{MapType: kudov1alpha1.EKSAwsAuthMapRoles},
+										),
+									},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: "Failure",
+					Message: "Escalation policy must have at least one subject; " +
+						"Escalation policy must have a default and a max duration; " +
+						`Escalation policy challenge 0 kind must be PeerReview, got "Vote"; ` +
+						`Escalation policy challenge 0 reviewer 0 kind must be one of User, Group or ServiceAccount, got "Team"; ` +
+						"Escalation policy challenge 1 must have at least one reviewer; " +
+						"Escalation policy grant 0 is not supported: unknown kind Unknown; " +
+						"Escalation policy grant 1 is invalid: json: cannot unmarshal number into Go struct field K8sRoleBindingGrant.defaultNamespace of type string; " +
+						"Escalation policy grant 2 is invalid: no ARN to map",
+				},
+			},
+		},
+		{
+			desc: "denies if the policy template doesn't exist",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects:    testSubjects,
+								TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "unknown"},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: `Escalation policy template "unknown" doesn't exist`,
+				},
+			},
+		},
+		{
+			desc: "reviews the policy merged with its template",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects:    testSubjects,
+								TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Hour},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy default duration must not exceed max duration",
+				},
+			},
+		},
+		{
+			desc: "accepts policies inheriting their durations from their template",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects:    testSubjects,
+								TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+		{
+			desc: "accepts valid duration",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindEscalationPolicy,
+				},
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.EscalationPolicy{
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								Subjects: testSubjects,
+								Target: kudov1alpha1.EscalationTarget{
+									DefaultDuration: metav1.Duration{Duration: time.Second},
+									MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+								},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				reviewer = escalationpolicy.NewAdmissionReviewer(templatesGetter, buildGranterFactory(t), false)
+			)
+
+			gotResp, err := reviewer.ReviewAdmission(ctx, testCase.req)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantResp, gotResp)
+		})
+	}
+}
+
+func TestAdmissionReviewer_ReviewAdmission_VerifyRoles(t *testing.T) {
+	policyRequest := &admissionv1.AdmissionRequest{
+		Kind: metav1.GroupVersionKind{
+			Group:   kudo.GroupName,
+			Version: kudov1alpha1.Version,
+			Kind:    kudov1alpha1.KindEscalationPolicy,
+		},
+		Object: runtime.RawExtension{
+			Raw: webhooktesting.EncodeObject(
+				t,
+				kudov1alpha1.EscalationPolicy{
+					Spec: kudov1alpha1.EscalationPolicySpec{
+						Subjects: testSubjects,
+						Challenges: []kudov1alpha1.EscalationChallenge{
+							{
+								Kind:      kudov1alpha1.ChallengeKindPeerReview,
+								Reviewers: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "squad-b"}},
+							},
+						},
+						Target: kudov1alpha1.EscalationTarget{
+							DefaultDuration: metav1.Duration{Duration: time.Second},
+							MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+							Grants:          []kudov1alpha1.ValueWithKind{roleBindingGrant(kudov1alpha1.GrantCommonSpec{})},
+						},
+					},
+				},
+			).Bytes(),
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		kubeSeed    []runtime.Object
+		verifyRoles bool
+		wantResp    *admissionv1.AdmissionResponse
+	}{
+		{
+			desc: "accepts policies binding roles that don't exist if roles are not verified",
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+		{
+			desc:        "denies policies binding roles that don't exist if roles are verified",
+			verifyRoles: true,
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy grant 0 is invalid: grant role does not exist: cluster role edit",
+				},
+			},
+		},
+		{
+			desc:        "accepts policies binding roles that exist if roles are verified",
+			kubeSeed:    []runtime.Object{&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}}},
+			verifyRoles: true,
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				reviewer = escalationpolicy.NewAdmissionReviewer(
+					templatesGetter,
+					buildGranterFactory(t, testCase.kubeSeed...),
+					testCase.verifyRoles,
+				)
+			)
+
+			gotResp, err := reviewer.ReviewAdmission(ctx, policyRequest)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantResp, gotResp)
+		})
+	}
+}
+
+func TestNamespacedAdmissionReviewer_ReviewAdmission(t *testing.T) {
+	namespacedPolicyRequest := func(target kudov1alpha1.EscalationTarget) *admissionv1.AdmissionRequest {
+		return &admissionv1.AdmissionRequest{
+			Kind: metav1.GroupVersionKind{
+				Group:   kudo.GroupName,
+				Version: kudov1alpha1.Version,
+				Kind:    kudov1alpha1.KindNamespacedEscalationPolicy,
+			},
+			Namespace: "team-a",
+			Object: runtime.RawExtension{
+				Raw: webhooktesting.EncodeObject(
+					t,
+					kudov1alpha1.NamespacedEscalationPolicy{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+						Spec:       kudov1alpha1.EscalationPolicySpec{Subjects: testSubjects, Target: target},
+					},
+				).Bytes(),
+			},
+		}
+	}
+
+	roleBindingTarget := func(grant kudov1alpha1.K8sRoleBindingGrant) kudov1alpha1.EscalationTarget {
+		return kudov1alpha1.EscalationTarget{
+			DefaultDuration: metav1.Duration{Duration: time.Second},
+			MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+			Grants: []kudov1alpha1.ValueWithKind{
+				kudov1alpha1.MustEncodeValueWithKind(kudov1alpha1.GrantKindK8sRoleBinding, grant),
+			},
+		}
+	}
+
+	denied := func(message string) *admissionv1.AdmissionResponse {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  "Failure",
+				Message: message,
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		req      *admissionv1.AdmissionRequest
+		wantResp *admissionv1.AdmissionResponse
+		wantErr  error
+	}{
+		{
+			desc: "reviews namespaced policies like escalation policies",
+			req: namespacedPolicyRequest(
+				kudov1alpha1.EscalationTarget{
+					MaxDuration: metav1.Duration{Duration: time.Second},
+				},
+			),
+			wantResp: denied("Escalation policy must have a default and a max duration"),
+		},
+		{
+			desc: "denies grants that are not kubernetes role bindings",
+			req: namespacedPolicyRequest(
+				kudov1alpha1.EscalationTarget{
+					DefaultDuration: metav1.Duration{Duration: time.Second},
+					MaxDuration:     metav1.Duration{Duration: 2 * time.Second},
+					Grants: []kudov1alpha1.ValueWithKind{
+						kudov1alpha1.MustEncodeValueWithKind(
+							kudov1alpha1.GrantKindEKSAwsAuth,
+							kudov1alpha1.EKSAwsAuthGrant{MapType: kudov1alpha1.EKSAwsAuthMapRoles, ARN: "arn:aws:iam::000000000000:role/ops"},
+						),
+					},
+				},
+			),
+			wantResp: denied("Namespaced escalation policy target is not allowed: grant 0 is of kind EKSAwsAuth, only KubernetesRoleBinding grants are allowed"),
+		},
+		{
+			desc: "denies grants with another default namespace",
+			req: namespacedPolicyRequest(
+				roleBindingTarget(
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: "team-b",
+						RoleRef:          rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+					},
+				),
+			),
+			wantResp: denied(`Namespaced escalation policy target is not allowed: grant 0 default namespace must be "team-a", got "team-b"`),
+		},
+		{
+			desc: "denies grants allowing other namespaces",
+			req: namespacedPolicyRequest(
+				roleBindingTarget(
+					kudov1alpha1.K8sRoleBindingGrant{
+						AllowedNamespaces: []string{"team-a", "kube-system"},
+						RoleRef:           rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+					},
+				),
+			),
+			wantResp: denied(`Namespaced escalation policy target is not allowed: grant 0 allowed namespaces must only contain "team-a", got "kube-system"`),
+		},
+		{
+			desc: "denies grants binding a cluster role that is not allowed",
+			req: namespacedPolicyRequest(
+				roleBindingTarget(
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: "team-a",
+						RoleRef:          rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+					},
+				),
+			),
+			wantResp: denied(`Namespaced escalation policy target is not allowed: grant 0 binds the cluster role "cluster-admin", allowed cluster roles are [view edit]`),
+		},
+		{
+			desc: "confines the grants of the policy template to the policy namespace",
+			req: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   kudo.GroupName,
+					Version: kudov1alpha1.Version,
+					Kind:    kudov1alpha1.KindNamespacedEscalationPolicy,
+				},
+				Namespace: "team-a",
+				Object: runtime.RawExtension{
+					Raw: webhooktesting.EncodeObject(
+						t,
+						kudov1alpha1.NamespacedEscalationPolicy{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+							Spec: kudov1alpha1.EscalationPolicySpec{
+								TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base", Namespace: "team-a"},
+							},
+						},
+					).Bytes(),
+				},
+			},
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+		{
+			desc: "accepts grants binding a role of the policy namespace",
+			req: namespacedPolicyRequest(
+				roleBindingTarget(
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace:  "team-a",
+						AllowedNamespaces: []string{"team-a"},
+						RoleRef:           rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+					},
+				),
+			),
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+		{
+			desc: "accepts grants binding an allowed cluster role",
+			req: namespacedPolicyRequest(
+				roleBindingTarget(
+					kudov1alpha1.K8sRoleBindingGrant{
+						DefaultNamespace: "team-a",
+						RoleRef:          rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+					},
+				),
+			),
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Status: "Success",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				reviewer = escalationpolicy.NewNamespacedAdmissionReviewer(
+					templatesGetter,
+					buildGranterFactory(t),
+					false,
+					[]string{"view", "edit"},
+				)
+			)
+
+			gotResp, err := reviewer.ReviewAdmission(ctx, testCase.req)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantResp, gotResp)
+		})
+	}
+}
+
+func TestTemplateAdmissionReviewer_ReviewAdmission(t *testing.T) {
+	templateRequest := func(spec kudov1alpha1.EscalationPolicySpec) *admissionv1.AdmissionRequest {
+		return &admissionv1.AdmissionRequest{
+			Kind: metav1.GroupVersionKind{
+				Group:   kudo.GroupName,
+				Version: kudov1alpha1.Version,
+				Kind:    kudov1alpha1.KindEscalationPolicyTemplate,
+			},
+			Object: runtime.RawExtension{
+				Raw: webhooktesting.EncodeObject(
+					t,
+					kudov1alpha1.EscalationPolicyTemplate{Spec: spec},
+				).Bytes(),
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		req      *admissionv1.AdmissionRequest
+		wantResp *admissionv1.AdmissionResponse
+		wantErr  error
+	}{
+		{
+			desc: "denies templates inheriting from another template",
+			req: templateRequest(
+				kudov1alpha1.EscalationPolicySpec{
+					TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+				},
+			),
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy templates can't inherit from another template",
+				},
+			},
+		},
+		{
+			desc: "denies templates with a default duration exceeding the max duration",
+			req: templateRequest(
+				kudov1alpha1.EscalationPolicySpec{
+					Target: kudov1alpha1.EscalationTarget{
+						DefaultDuration: metav1.Duration{Duration: time.Hour},
+						MaxDuration:     metav1.Duration{Duration: time.Minute},
+					},
+				},
+			),
+			wantResp: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  "Failure",
+					Message: "Escalation policy template default duration must not exceed max duration",
+				},
+			},
+		},
+		{
+			desc: "accepts partial templates",
+			req: templateRequest(
+				kudov1alpha1.EscalationPolicySpec{
+					Target: kudov1alpha1.EscalationTarget{
+						MaxDuration: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			),
 			wantResp: &admissionv1.AdmissionResponse{
 				Allowed: true,
 				Result: &metav1.Status{
@@ -146,7 +1130,7 @@ func TestAdmissionRevierer_ReviewAdmission(t *testing.T) {
 		t.Run(testCase.desc, func(t *testing.T) {
 			var (
 				ctx      = context.Background()
-				reviewer = escalationpolicy.NewAdmissionReviewer()
+				reviewer = escalationpolicy.NewTemplateAdmissionReviewer()
 			)
 
 			gotResp, err := reviewer.ReviewAdmission(ctx, testCase.req)
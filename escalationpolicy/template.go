@@ -0,0 +1,96 @@
+package escalationpolicy
+
+import (
+	"fmt"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+type EscalationPolicyTemplatesGetter interface {
+	Get(name string) (*kudov1alpha1.EscalationPolicyTemplate, error)
+}
+
+// ApplyTemplate returns the effective spec of a policy inheriting from the given template.
+// Each field set by the policy overrides the template one, boolean settings are enabled if either the policy or the template enables them.
+// If the policy template reference has a namespace, it replaces the namespaces of the template KubernetesRoleBinding grants.
+func ApplyTemplate(spec kudov1alpha1.EscalationPolicySpec, template kudov1alpha1.EscalationPolicySpec) (kudov1alpha1.EscalationPolicySpec, error) {
+	effective := *template.DeepCopy()
+	effective.TemplateRef = spec.TemplateRef.DeepCopy()
+
+	if spec.TemplateRef != nil && spec.TemplateRef.Namespace != "" {
+		grants, err := withNamespace(effective.Target.Grants, spec.TemplateRef.Namespace)
+		if err != nil {
+			return kudov1alpha1.EscalationPolicySpec{}, err
+		}
+
+		effective.Target.Grants = grants
+	}
+
+	if len(spec.Subjects) > 0 {
+		effective.Subjects = spec.Subjects
+	}
+
+	if len(spec.ExcludedSubjects) > 0 {
+		effective.ExcludedSubjects = spec.ExcludedSubjects
+	}
+
+	if len(spec.Challenges) > 0 {
+		effective.Challenges = spec.Challenges
+	}
+
+	if len(spec.Conditions) > 0 {
+		effective.Conditions = spec.Conditions
+	}
+
+	if spec.Delegation != nil {
+		effective.Delegation = spec.Delegation
+	}
+
+	if spec.Target.DefaultDuration.Duration != 0 {
+		effective.Target.DefaultDuration = spec.Target.DefaultDuration
+	}
+
+	if spec.Target.MaxDuration.Duration != 0 {
+		effective.Target.MaxDuration = spec.Target.MaxDuration
+	}
+
+	if len(spec.Target.Grants) > 0 {
+		effective.Target.Grants = spec.Target.Grants
+	}
+
+	if spec.Target.GrantFailurePolicy != kudov1alpha1.GrantFailurePolicyUnknown {
+		effective.Target.GrantFailurePolicy = spec.Target.GrantFailurePolicy
+	}
+
+	effective.Target.AtomicGrants = effective.Target.AtomicGrants || spec.Target.AtomicGrants
+	effective.Target.OrderedGrants = effective.Target.OrderedGrants || spec.Target.OrderedGrants
+	effective.Target.ExtensionsRequireApproval = effective.Target.ExtensionsRequireApproval || spec.Target.ExtensionsRequireApproval
+
+	return *effective.DeepCopy(), nil
+}
+
+func withNamespace(grants []kudov1alpha1.ValueWithKind, namespace string) ([]kudov1alpha1.ValueWithKind, error) {
+	namespacedGrants := make([]kudov1alpha1.ValueWithKind, len(grants))
+
+	for i, grant := range grants {
+		if grant.Kind != kudov1alpha1.GrantKindK8sRoleBinding {
+			namespacedGrants[i] = grant
+			continue
+		}
+
+		k8sGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrant](grant)
+		if err != nil {
+			return nil, fmt.Errorf("template grant %d can't be decoded, %w", i, err)
+		}
+
+		k8sGrant.DefaultNamespace = namespace
+		k8sGrant.AllowedNamespaces = []string{namespace}
+
+		namespacedGrants[i], err = kudov1alpha1.EncodeValueWithKind(grant.Kind, *k8sGrant)
+		if err != nil {
+			return nil, fmt.Errorf("template grant %d can't be encoded, %w", i, err)
+		}
+	}
+
+	return namespacedGrants, nil
+}
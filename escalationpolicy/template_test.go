@@ -0,0 +1,125 @@
+package escalationpolicy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jlevesy/kudo/escalationpolicy"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	var (
+		subjects = func(names ...string) []kudov1alpha1.PolicySubject {
+			var subjects []kudov1alpha1.PolicySubject
+
+			for _, name := range names {
+				subjects = append(subjects, kudov1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: name}})
+			}
+
+			return subjects
+		}
+
+		roleBinding = func(defaultNamespace string, allowedNamespaces ...string) kudov1alpha1.ValueWithKind {
+			return kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindK8sRoleBinding,
+				kudov1alpha1.K8sRoleBindingGrant{
+					DefaultNamespace:  defaultNamespace,
+					AllowedNamespaces: allowedNamespaces,
+					RoleRef:           rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+				},
+			)
+		}
+
+		awsAuth = kudov1alpha1.MustEncodeValueWithKind(
+			kudov1alpha1.GrantKindEKSAwsAuth,
+			kudov1alpha1.EKSAwsAuthGrant{MapType: kudov1alpha1.EKSAwsAuthMapRoles, ARN: "arn:aws:iam::1234:role/ops"},
+		)
+
+		template = kudov1alpha1.EscalationPolicySpec{
+			Subjects: subjects("sre"),
+			Challenges: []kudov1alpha1.EscalationChallenge{
+				{Kind: "PeerReview", Reviewers: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "security"}}},
+			},
+			Target: kudov1alpha1.EscalationTarget{
+				DefaultDuration: metav1.Duration{Duration: time.Hour},
+				MaxDuration:     metav1.Duration{Duration: 2 * time.Hour},
+				Grants:          []kudov1alpha1.ValueWithKind{roleBinding("some-app", "some-app", "other-app"), awsAuth},
+				AtomicGrants:    true,
+			},
+		}
+	)
+
+	testCases := []struct {
+		desc     string
+		spec     kudov1alpha1.EscalationPolicySpec
+		wantSpec kudov1alpha1.EscalationPolicySpec
+	}{
+		{
+			desc: "inherits all the template fields",
+			spec: kudov1alpha1.EscalationPolicySpec{
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+			},
+			wantSpec: kudov1alpha1.EscalationPolicySpec{
+				Subjects:    template.Subjects,
+				Challenges:  template.Challenges,
+				Target:      template.Target,
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+			},
+		},
+		{
+			desc: "overrides the fields set by the policy",
+			spec: kudov1alpha1.EscalationPolicySpec{
+				Subjects: subjects("squad-a"),
+				Target: kudov1alpha1.EscalationTarget{
+					MaxDuration:   metav1.Duration{Duration: 4 * time.Hour},
+					OrderedGrants: true,
+				},
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+			},
+			wantSpec: kudov1alpha1.EscalationPolicySpec{
+				Subjects:   subjects("squad-a"),
+				Challenges: template.Challenges,
+				Target: kudov1alpha1.EscalationTarget{
+					DefaultDuration: metav1.Duration{Duration: time.Hour},
+					MaxDuration:     metav1.Duration{Duration: 4 * time.Hour},
+					Grants:          template.Target.Grants,
+					AtomicGrants:    true,
+					OrderedGrants:   true,
+				},
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base"},
+			},
+		},
+		{
+			desc: "replaces the namespaces of the template role bindings",
+			spec: kudov1alpha1.EscalationPolicySpec{
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base", Namespace: "squad-a-app"},
+			},
+			wantSpec: kudov1alpha1.EscalationPolicySpec{
+				Subjects:   template.Subjects,
+				Challenges: template.Challenges,
+				Target: kudov1alpha1.EscalationTarget{
+					DefaultDuration: metav1.Duration{Duration: time.Hour},
+					MaxDuration:     metav1.Duration{Duration: 2 * time.Hour},
+					Grants:          []kudov1alpha1.ValueWithKind{roleBinding("squad-a-app", "squad-a-app"), awsAuth},
+					AtomicGrants:    true,
+				},
+				TemplateRef: &kudov1alpha1.PolicyTemplateRef{Name: "base", Namespace: "squad-a-app"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotSpec, err := escalationpolicy.ApplyTemplate(testCase.spec, template)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantSpec, gotSpec)
+		})
+	}
+}
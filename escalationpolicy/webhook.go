@@ -6,6 +6,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/jlevesy/kudo/grant"
 	kudo "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
 	"github.com/jlevesy/kudo/pkg/webhooksupport"
@@ -17,10 +18,33 @@ var (
 		Version: kudov1alpha1.Version,
 		Kind:    kudov1alpha1.KindEscalationPolicy,
 	}
+	expectedNamespacedKind = metav1.GroupVersionKind{
+		Group:   kudo.GroupName,
+		Version: kudov1alpha1.Version,
+		Kind:    kudov1alpha1.KindNamespacedEscalationPolicy,
+	}
+	expectedTemplateKind = metav1.GroupVersionKind{
+		Group:   kudo.GroupName,
+		Version: kudov1alpha1.Version,
+		Kind:    kudov1alpha1.KindEscalationPolicyTemplate,
+	}
 )
 
-func SetupWebhook(router *http.ServeMux) {
-	reviewer := NewAdmissionReviewer()
+// SetupWebhook registers the policies admission webhooks.
+// Namespaced policies are only allowed to bind the given cluster roles, along with the roles of their namespace.
+// Policies binding roles that don't exist are denied if verifyRoles is set.
+func SetupWebhook(
+	router *http.ServeMux,
+	templatesGetter EscalationPolicyTemplatesGetter,
+	granterFactory grant.Factory,
+	verifyRoles bool,
+	allowedClusterRoles []string,
+) {
+	var (
+		reviewer           = NewAdmissionReviewer(templatesGetter, granterFactory, verifyRoles)
+		namespacedReviewer = NewNamespacedAdmissionReviewer(templatesGetter, granterFactory, verifyRoles, allowedClusterRoles)
+		templateReviewer   = NewTemplateAdmissionReviewer()
+	)
 
 	router.Handle(
 		"/v1alpha1/escalationpolicies",
@@ -34,4 +58,30 @@ func SetupWebhook(router *http.ServeMux) {
 			),
 		),
 	)
+
+	router.Handle(
+		"/v1alpha1/namespacedescalationpolicies",
+		webhooksupport.NewHandler(
+			webhooksupport.RequireKind(
+				expectedNamespacedKind,
+				webhooksupport.RouteByOperation(
+					webhooksupport.HandleOperation(admissionv1.Create, namespacedReviewer),
+					webhooksupport.HandleOperation(admissionv1.Update, namespacedReviewer),
+				),
+			),
+		),
+	)
+
+	router.Handle(
+		"/v1alpha1/escalationpolicytemplates",
+		webhooksupport.NewHandler(
+			webhooksupport.RequireKind(
+				expectedTemplateKind,
+				webhooksupport.RouteByOperation(
+					webhooksupport.HandleOperation(admissionv1.Create, templateReviewer),
+					webhooksupport.HandleOperation(admissionv1.Update, templateReviewer),
+				),
+			),
+		),
+	)
 }
@@ -15,14 +15,22 @@ func DefaultGranterFactory(
 		factory = make(StaticFactory)
 		// This is required to register the informer before startup.
 		roleBindingLister = kubeInformerFactory.Rbac().V1().RoleBindings().Lister()
+		roleLister        = kubeInformerFactory.Rbac().V1().Roles().Lister()
+		clusterRoleLister = kubeInformerFactory.Rbac().V1().ClusterRoles().Lister()
 	)
 
 	factory[kudov1alpha1.GrantKindK8sRoleBinding] = func() (Granter, error) {
 		return newK8sRoleBindingGranter(
 			kubeClient.RbacV1(),
 			roleBindingLister,
+			roleLister,
+			clusterRoleLister,
 		)
 	}
 
+	factory[kudov1alpha1.GrantKindEKSAwsAuth] = func() (Granter, error) {
+		return newEKSAwsAuthGranter(kubeClient.CoreV1())
+	}
+
 	return factory
 }
@@ -0,0 +1,322 @@
+package grant
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"github.com/jlevesy/kudo/pkg/generics"
+)
+
+const (
+	awsAuthNamespace = "kube-system"
+	awsAuthName      = "aws-auth"
+)
+
+var (
+	ErrAwsAuthMalformed      = stderrors.New("aws-auth ConfigMap is malformed")
+	ErrAwsAuthConflict       = stderrors.New("aws-auth ConfigMap already maps this ARN")
+	ErrAwsAuthInvalidMapType = stderrors.New("invalid aws-auth map type")
+	ErrAwsAuthNoARN          = stderrors.New("no ARN to map")
+	ErrAwsAuthSharedARN      = stderrors.New("an ARN can't be mapped to several beneficiaries")
+)
+
+// awsAuthEntry is a single entry of the mapUsers or mapRoles sections of the aws-auth ConfigMap.
+// It is kept as a raw map to preserve entries that kudo does not manage as is.
+type awsAuthEntry = map[string]any
+
+type eksAwsAuthGranter struct {
+	configMapsGetter corev1client.ConfigMapsGetter
+}
+
+func newEKSAwsAuthGranter(configMapsGetter corev1client.ConfigMapsGetter) (*eksAwsAuthGranter, error) {
+	return &eksAwsAuthGranter{configMapsGetter: configMapsGetter}, nil
+}
+
+func (g *eksAwsAuthGranter) Create(ctx context.Context, esc *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) (kudov1alpha1.EscalationGrantRef, error) {
+	awsGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrant](grant)
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	if err = validateAwsAuthGrant(awsGrant); err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	ref := kudov1alpha1.EKSAwsAuthGrantRef{
+		MapType:  awsGrant.MapType,
+		ARN:      awsGrant.ARN,
+		Username: esc.Spec.Requestor,
+		Groups:   awsGrant.Groups,
+	}
+
+	err = g.mutateEntries(ctx, ref.MapType, func(entries []awsAuthEntry) ([]awsAuthEntry, bool, error) {
+		for _, entry := range entries {
+			if entry[arnKey(ref.MapType)] != ref.ARN {
+				continue
+			}
+
+			// The exact same mapping is already there, this is the one we've created earlier.
+			if entryMatchesRef(entry, ref) {
+				return entries, false, nil
+			}
+
+			// The mapping we've created earlier has been changed behind our back.
+			if entry["username"] == ref.Username {
+				return nil, false, fmt.Errorf(
+					"%w: aws-auth %s entry mapping %s to %s",
+					ErrTampered,
+					ref.MapType,
+					ref.ARN,
+					ref.Username,
+				)
+			}
+
+			return nil, false, fmt.Errorf("%w: %s", ErrAwsAuthConflict, ref.ARN)
+		}
+
+		return append(entries, newAwsAuthEntry(ref)), true, nil
+	})
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	klog.InfoS(
+		"Mapped an IAM identity in aws-auth",
+		"escalation",
+		esc.Name,
+		"mapType",
+		ref.MapType,
+		"arn",
+		ref.ARN,
+		"username",
+		ref.Username,
+	)
+
+	encodedRef, err := kudov1alpha1.EncodeValueWithKind(kudov1alpha1.GrantKindEKSAwsAuth, ref)
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	return kudov1alpha1.EscalationGrantRef{
+		Status: kudov1alpha1.GrantStatusCreated,
+		Ref:    encodedRef,
+	}, nil
+}
+
+func (g *eksAwsAuthGranter) Reclaim(ctx context.Context, ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
+	awsRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrantRef](ref.Ref)
+	if err != nil {
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	status := kudov1alpha1.EscalationGrantRef{
+		Status: kudov1alpha1.GrantStatusReclaimed,
+		Ref:    ref.Ref,
+	}
+
+	err = g.mutateEntries(ctx, awsRef.MapType, func(entries []awsAuthEntry) ([]awsAuthEntry, bool, error) {
+		kept := make([]awsAuthEntry, 0, len(entries))
+
+		for _, entry := range entries {
+			// The entry mapping the ARN to the requestor is removed even if its groups have been changed behind our back,
+			// it would otherwise keep granting permissions after the escalation is over.
+			if entry[arnKey(awsRef.MapType)] == awsRef.ARN && entry["username"] == awsRef.Username {
+				if !entryMatchesRef(entry, *awsRef) {
+					klog.InfoS(
+						"Removing a tampered IAM identity mapping from aws-auth",
+						"mapType",
+						awsRef.MapType,
+						"arn",
+						awsRef.ARN,
+						"username",
+						awsRef.Username,
+					)
+				}
+
+				continue
+			}
+
+			kept = append(kept, entry)
+		}
+
+		return kept, len(kept) != len(entries), nil
+	})
+
+	switch {
+	case errors.IsNotFound(err):
+		return status, nil
+	case err != nil:
+		return kudov1alpha1.EscalationGrantRef{}, err
+	}
+
+	klog.InfoS(
+		"Removed an IAM identity mapping from aws-auth",
+		"mapType",
+		awsRef.MapType,
+		"arn",
+		awsRef.ARN,
+		"username",
+		awsRef.Username,
+	)
+
+	return status, nil
+}
+
+// Validate makes sure that the grant describes a mapping that can be written to aws-auth.
+func (g *eksAwsAuthGranter) Validate(_ context.Context, esc *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) error {
+	awsGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrant](grant)
+	if err != nil {
+		return err
+	}
+
+	// An IAM identity is mapped to a single username, it can't be shared by several beneficiaries.
+	if len(esc.Spec.Beneficiaries) > 0 {
+		return fmt.Errorf("%w: %s", ErrAwsAuthSharedARN, awsGrant.ARN)
+	}
+
+	// An IAM identity mapping gives all the permissions of its groups, it can't be restricted to some resources.
+	if len(esc.Spec.Resources) > 0 {
+		return fmt.Errorf("%w: %s grants can't be narrowed", ErrResourceNotNarrowable, kudov1alpha1.GrantKindEKSAwsAuth)
+	}
+
+	return validateAwsAuthGrant(awsGrant)
+}
+
+// ValidatePolicy makes sure that the grant maps an IAM identity to a supported section of the aws-auth ConfigMap.
+func (g *eksAwsAuthGranter) ValidatePolicy(_ context.Context, grant kudov1alpha1.ValueWithKind) error {
+	awsGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrant](grant)
+	if err != nil {
+		return err
+	}
+
+	return validateAwsAuthGrant(awsGrant)
+}
+
+// Widens returns true if the grant now maps another IAM identity, in another section of aws-auth, to more groups or for longer.
+func (g *eksAwsAuthGranter) Widens(_ context.Context, _ *kudov1alpha1.Escalation, previous, current kudov1alpha1.ValueWithKind) (bool, error) {
+	previousGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrant](previous)
+	if err != nil {
+		return false, err
+	}
+
+	currentGrant, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrant](current)
+	if err != nil {
+		return false, err
+	}
+
+	if previousGrant.MapType != currentGrant.MapType || previousGrant.ARN != currentGrant.ARN {
+		return true, nil
+	}
+
+	for _, group := range currentGrant.Groups {
+		if !generics.Contains(previousGrant.Groups, group) {
+			return true, nil
+		}
+	}
+
+	return durationWidens(previousGrant.GrantCommonSpec, currentGrant.GrantCommonSpec), nil
+}
+
+// mutateEntries reads the given section of the aws-auth ConfigMap, applies the mutation and writes it back.
+// Writes are retried on conflicts, and any section that can't be decoded aborts the whole operation.
+func (g *eksAwsAuthGranter) mutateEntries(ctx context.Context, mapType string, mutate func([]awsAuthEntry) ([]awsAuthEntry, bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := g.configMapsGetter.ConfigMaps(awsAuthNamespace).Get(ctx, awsAuthName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		var entries []awsAuthEntry
+
+		if err = yaml.Unmarshal([]byte(configMap.Data[mapType]), &entries); err != nil {
+			return fmt.Errorf("%w: section %s: %s", ErrAwsAuthMalformed, mapType, err.Error())
+		}
+
+		entries, changed, err := mutate(entries)
+		if err != nil || !changed {
+			return err
+		}
+
+		rawEntries, err := yaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+
+		configMap = configMap.DeepCopy()
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+
+		configMap.Data[mapType] = string(rawEntries)
+
+		_, err = g.configMapsGetter.ConfigMaps(awsAuthNamespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func validateAwsAuthGrant(grant *kudov1alpha1.EKSAwsAuthGrant) error {
+	if grant.MapType != kudov1alpha1.EKSAwsAuthMapUsers && grant.MapType != kudov1alpha1.EKSAwsAuthMapRoles {
+		return fmt.Errorf(
+			"%w: %q, allowed values: %v",
+			ErrAwsAuthInvalidMapType,
+			grant.MapType,
+			[]string{kudov1alpha1.EKSAwsAuthMapUsers, kudov1alpha1.EKSAwsAuthMapRoles},
+		)
+	}
+
+	if grant.ARN == "" {
+		return ErrAwsAuthNoARN
+	}
+
+	return nil
+}
+
+func newAwsAuthEntry(ref kudov1alpha1.EKSAwsAuthGrantRef) awsAuthEntry {
+	groups := make([]any, len(ref.Groups))
+	for i, group := range ref.Groups {
+		groups[i] = group
+	}
+
+	return awsAuthEntry{
+		arnKey(ref.MapType): ref.ARN,
+		"username":          ref.Username,
+		"groups":            groups,
+	}
+}
+
+func entryMatchesRef(entry awsAuthEntry, ref kudov1alpha1.EKSAwsAuthGrantRef) bool {
+	if entry["username"] != ref.Username {
+		return false
+	}
+
+	groups, _ := entry["groups"].([]any)
+	if len(groups) != len(ref.Groups) {
+		return false
+	}
+
+	for _, group := range groups {
+		name, ok := group.(string)
+		if !ok || !generics.Contains(ref.Groups, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func arnKey(mapType string) string {
+	if mapType == kudov1alpha1.EKSAwsAuthMapRoles {
+		return "rolearn"
+	}
+
+	return "userarn"
+}
@@ -0,0 +1,526 @@
+package grant_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/jlevesy/kudo/grant"
+	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+var (
+	testAwsAuthGrant = kudov1alpha1.MustEncodeValueWithKind(
+		kudov1alpha1.GrantKindEKSAwsAuth,
+		kudov1alpha1.EKSAwsAuthGrant{
+			MapType: kudov1alpha1.EKSAwsAuthMapRoles,
+			ARN:     "arn:aws:iam::000000000000:role/break-glass",
+			Groups:  []string{"system:masters"},
+		},
+	)
+
+	testAwsAuthGrantRef = kudov1alpha1.MustEncodeValueWithKind(
+		kudov1alpha1.GrantKindEKSAwsAuth,
+		kudov1alpha1.EKSAwsAuthGrantRef{
+			MapType:  kudov1alpha1.EKSAwsAuthMapRoles,
+			ARN:      "arn:aws:iam::000000000000:role/break-glass",
+			Username: "jean-testor",
+			Groups:   []string{"system:masters"},
+		},
+	)
+)
+
+const (
+	nodesMapRoles = `- groups:
+  - system:bootstrappers
+  - system:nodes
+  rolearn: arn:aws:iam::000000000000:role/nodes
+  username: system:node:{{EC2PrivateDNSName}}
+`
+
+	nodesAndBreakGlassMapRoles = `- groups:
+  - system:bootstrappers
+  - system:nodes
+  rolearn: arn:aws:iam::000000000000:role/nodes
+  username: system:node:{{EC2PrivateDNSName}}
+- groups:
+  - system:masters
+  rolearn: arn:aws:iam::000000000000:role/break-glass
+  username: jean-testor
+`
+
+	conflictingMapRoles = `- groups:
+  - system:masters
+  rolearn: arn:aws:iam::000000000000:role/break-glass
+  username: someone-else
+`
+
+	tamperedMapRoles = `- groups:
+  - system:masters
+  - system:nodes
+  rolearn: arn:aws:iam::000000000000:role/break-glass
+  username: jean-testor
+`
+
+	mapUsers = `- groups:
+  - system:masters
+  userarn: arn:aws:iam::000000000000:user/admin
+  username: admin
+`
+)
+
+func TestEKSAwsAuthGranter_Create(t *testing.T) {
+	testCases := []struct {
+		desc string
+
+		seed      []runtime.Object
+		conflicts int
+
+		grant kudov1alpha1.ValueWithKind
+
+		wantRef         kudov1alpha1.EKSAwsAuthGrantRef
+		wantCreateError error
+		wantData        map[string]string
+	}{
+		{
+			desc:  "adds a new entry for the requestor and preserves the existing ones",
+			seed:  []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesMapRoles, "mapUsers": mapUsers})},
+			grant: testAwsAuthGrant,
+			wantRef: kudov1alpha1.EKSAwsAuthGrantRef{
+				MapType:  kudov1alpha1.EKSAwsAuthMapRoles,
+				ARN:      "arn:aws:iam::000000000000:role/break-glass",
+				Username: "jean-testor",
+				Groups:   []string{"system:masters"},
+			},
+			wantData: map[string]string{"mapRoles": nodesAndBreakGlassMapRoles, "mapUsers": mapUsers},
+		},
+		{
+			desc:      "retries on conflicts",
+			seed:      []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesMapRoles})},
+			conflicts: 2,
+			grant:     testAwsAuthGrant,
+			wantRef: kudov1alpha1.EKSAwsAuthGrantRef{
+				MapType:  kudov1alpha1.EKSAwsAuthMapRoles,
+				ARN:      "arn:aws:iam::000000000000:role/break-glass",
+				Username: "jean-testor",
+				Groups:   []string{"system:masters"},
+			},
+			wantData: map[string]string{"mapRoles": nodesAndBreakGlassMapRoles},
+		},
+		{
+			desc:  "reuses an existing entry",
+			seed:  []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesAndBreakGlassMapRoles})},
+			grant: testAwsAuthGrant,
+			wantRef: kudov1alpha1.EKSAwsAuthGrantRef{
+				MapType:  kudov1alpha1.EKSAwsAuthMapRoles,
+				ARN:      "arn:aws:iam::000000000000:role/break-glass",
+				Username: "jean-testor",
+				Groups:   []string{"system:masters"},
+			},
+			wantData: map[string]string{"mapRoles": nodesAndBreakGlassMapRoles},
+		},
+		{
+			desc:            "raises an error if the ARN is already mapped to someone else",
+			seed:            []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": conflictingMapRoles})},
+			grant:           testAwsAuthGrant,
+			wantCreateError: grant.ErrAwsAuthConflict,
+			wantData:        map[string]string{"mapRoles": conflictingMapRoles},
+		},
+		{
+			desc:            "reports a tampering if the groups of the requestor entry have been changed",
+			seed:            []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": tamperedMapRoles})},
+			grant:           testAwsAuthGrant,
+			wantCreateError: grant.ErrTampered,
+			wantData:        map[string]string{"mapRoles": tamperedMapRoles},
+		},
+		{
+			desc:            "fails closed if the ConfigMap is malformed",
+			seed:            []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": "{{{ not yaml"})},
+			grant:           testAwsAuthGrant,
+			wantCreateError: grant.ErrAwsAuthMalformed,
+			wantData:        map[string]string{"mapRoles": "{{{ not yaml"},
+		},
+		{
+			desc: "raises an error if the map type is invalid",
+			seed: []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesMapRoles})},
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindEKSAwsAuth,
+				kudov1alpha1.EKSAwsAuthGrant{
+					MapType: "mapAccounts",
+					ARN:     "arn:aws:iam::000000000000:role/break-glass",
+				},
+			),
+			wantCreateError: grant.ErrAwsAuthInvalidMapType,
+			wantData:        map[string]string{"mapRoles": nodesMapRoles},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                  = context.Background()
+				factory, k8s, cancel = buildTestFactory(t, testCase.seed)
+			)
+
+			defer cancel()
+
+			injectUpdateConflicts(k8s, testCase.conflicts)
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+			require.NoError(t, err)
+
+			gotRef, err := granter.Create(ctx, &testEscalation, testCase.grant)
+			require.ErrorIs(t, err, testCase.wantCreateError)
+
+			gotConfigMap, getErr := k8s.kubeClientSet.CoreV1().ConfigMaps("kube-system").Get(ctx, "aws-auth", metav1.GetOptions{})
+			require.NoError(t, getErr)
+			assert.Equal(t, testCase.wantData, gotConfigMap.Data)
+
+			if testCase.wantCreateError != nil {
+				return
+			}
+
+			gotAwsRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.EKSAwsAuthGrantRef](gotRef.Ref)
+			require.NoError(t, err)
+
+			assert.Equal(t, kudov1alpha1.GrantStatusCreated, gotRef.Status)
+			assert.Equal(t, testCase.wantRef, *gotAwsRef)
+		})
+	}
+}
+
+func TestEKSAwsAuthGranter_Reclaim(t *testing.T) {
+	testCases := []struct {
+		desc string
+
+		seed      []runtime.Object
+		conflicts int
+
+		wantReclaimError error
+		wantData         map[string]string
+	}{
+		{
+			desc:     "removes exactly the entry created for the escalation",
+			seed:     []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesAndBreakGlassMapRoles, "mapUsers": mapUsers})},
+			wantData: map[string]string{"mapRoles": nodesMapRoles, "mapUsers": mapUsers},
+		},
+		{
+			desc:      "retries on conflicts",
+			seed:      []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesAndBreakGlassMapRoles})},
+			conflicts: 1,
+			wantData:  map[string]string{"mapRoles": nodesMapRoles},
+		},
+		{
+			desc:     "does not touch entries mapping the same ARN to someone else",
+			seed:     []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": conflictingMapRoles})},
+			wantData: map[string]string{"mapRoles": conflictingMapRoles},
+		},
+		{
+			desc:     "removes the entry created for the escalation even if its groups have been tampered with",
+			seed:     []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": nodesMapRoles + tamperedMapRoles, "mapUsers": mapUsers})},
+			wantData: map[string]string{"mapRoles": nodesMapRoles, "mapUsers": mapUsers},
+		},
+		{
+			desc:             "fails closed if the ConfigMap is malformed",
+			seed:             []runtime.Object{awsAuthConfigMap(map[string]string{"mapRoles": "{{{ not yaml"})},
+			wantReclaimError: grant.ErrAwsAuthMalformed,
+			wantData:         map[string]string{"mapRoles": "{{{ not yaml"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                  = context.Background()
+				factory, k8s, cancel = buildTestFactory(t, testCase.seed)
+			)
+
+			defer cancel()
+
+			injectUpdateConflicts(k8s, testCase.conflicts)
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+			require.NoError(t, err)
+
+			gotRef, err := granter.Reclaim(ctx, kudov1alpha1.EscalationGrantRef{
+				Status: kudov1alpha1.GrantStatusCreated,
+				Ref:    testAwsAuthGrantRef,
+			})
+			require.ErrorIs(t, err, testCase.wantReclaimError)
+
+			gotConfigMap, getErr := k8s.kubeClientSet.CoreV1().ConfigMaps("kube-system").Get(ctx, "aws-auth", metav1.GetOptions{})
+			require.NoError(t, getErr)
+			assert.Equal(t, testCase.wantData, gotConfigMap.Data)
+
+			if testCase.wantReclaimError != nil {
+				return
+			}
+
+			assert.Equal(t, kudov1alpha1.GrantStatusReclaimed, gotRef.Status)
+		})
+	}
+}
+
+func TestEKSAwsAuthGranter_ReclaimNoConfigMap(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		factory, _, cancel = buildTestFactory(t, nil)
+	)
+
+	defer cancel()
+
+	granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+	require.NoError(t, err)
+
+	gotRef, err := granter.Reclaim(ctx, kudov1alpha1.EscalationGrantRef{
+		Status: kudov1alpha1.GrantStatusCreated,
+		Ref:    testAwsAuthGrantRef,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, kudov1alpha1.GrantStatusReclaimed, gotRef.Status)
+}
+
+func TestEKSAwsAuthGranter_Validate(t *testing.T) {
+	teamEscalation := testEscalation
+	teamEscalation.Spec.Beneficiaries = []string{"jeanne-testeuse"}
+
+	narrowedEscalation := testEscalation
+	narrowedEscalation.Spec.Resources = []kudov1alpha1.EscalationResource{
+		{Resource: "secrets", Name: "some-secret"},
+	}
+
+	testCases := []struct {
+		desc       string
+		escalation *kudov1alpha1.Escalation
+		grant      kudov1alpha1.ValueWithKind
+		wantError  error
+	}{
+		{
+			desc: "raises an error if map type is unknown",
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindEKSAwsAuth,
+				kudov1alpha1.EKSAwsAuthGrant{
+					MapType: "mapAccounts",
+					ARN:     "arn:aws:iam::000000000000:role/break-glass",
+				},
+			),
+			wantError: grant.ErrAwsAuthInvalidMapType,
+		},
+		{
+			desc: "raises an error if ARN is not set",
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindEKSAwsAuth,
+				kudov1alpha1.EKSAwsAuthGrant{
+					MapType: kudov1alpha1.EKSAwsAuthMapUsers,
+				},
+			),
+			wantError: grant.ErrAwsAuthNoARN,
+		},
+		{
+			desc:       "raises an error if the escalation has several beneficiaries",
+			escalation: &teamEscalation,
+			grant:      testAwsAuthGrant,
+			wantError:  grant.ErrAwsAuthSharedARN,
+		},
+		{
+			desc:       "raises an error if the escalation is narrowed to some resources",
+			escalation: &narrowedEscalation,
+			grant:      testAwsAuthGrant,
+			wantError:  grant.ErrResourceNotNarrowable,
+		},
+		{
+			desc:  "raises no error if grant is valid",
+			grant: testAwsAuthGrant,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				factory, _, cancel = buildTestFactory(t, nil)
+			)
+
+			defer cancel()
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+			require.NoError(t, err)
+
+			esc := testCase.escalation
+			if esc == nil {
+				esc = &testEscalation
+			}
+
+			err = granter.Validate(ctx, esc, testCase.grant)
+			assert.ErrorIs(t, err, testCase.wantError)
+		})
+	}
+}
+
+func TestEKSAwsAuthGranter_ValidatePolicy(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		grant     kudov1alpha1.ValueWithKind
+		wantError error
+	}{
+		{
+			desc: "raises an error if map type is unknown",
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindEKSAwsAuth,
+				kudov1alpha1.EKSAwsAuthGrant{
+					MapType: "mapAccounts",
+					ARN:     "arn:aws:iam::000000000000:role/break-glass",
+				},
+			),
+			wantError: grant.ErrAwsAuthInvalidMapType,
+		},
+		{
+			desc: "raises an error if ARN is not set",
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindEKSAwsAuth,
+				kudov1alpha1.EKSAwsAuthGrant{
+					MapType: kudov1alpha1.EKSAwsAuthMapUsers,
+				},
+			),
+			wantError: grant.ErrAwsAuthNoARN,
+		},
+		{
+			desc:  "raises no error if grant is valid",
+			grant: testAwsAuthGrant,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				factory, _, cancel = buildTestFactory(t, nil)
+			)
+
+			defer cancel()
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+			require.NoError(t, err)
+
+			err = granter.ValidatePolicy(ctx, testCase.grant)
+			assert.ErrorIs(t, err, testCase.wantError)
+		})
+	}
+}
+
+func TestEKSAwsAuthGranter_Widens(t *testing.T) {
+	awsAuthGrant := func(mapType, arn string, duration time.Duration, groups ...string) kudov1alpha1.ValueWithKind {
+		return kudov1alpha1.MustEncodeValueWithKind(
+			kudov1alpha1.GrantKindEKSAwsAuth,
+			kudov1alpha1.EKSAwsAuthGrant{
+				GrantCommonSpec: kudov1alpha1.GrantCommonSpec{Duration: metav1.Duration{Duration: duration}},
+				MapType:         mapType,
+				ARN:             arn,
+				Groups:          groups,
+			},
+		)
+	}
+
+	const (
+		roleARN  = "arn:aws:iam::000000000000:role/break-glass"
+		adminARN = "arn:aws:iam::000000000000:role/admin"
+	)
+
+	testCases := []struct {
+		desc        string
+		previous    kudov1alpha1.ValueWithKind
+		current     kudov1alpha1.ValueWithKind
+		wantWidened bool
+	}{
+		{
+			desc:     "does not widen if the grant maps to less groups",
+			previous: awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers", "editors"),
+			current:  awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers"),
+		},
+		{
+			desc:        "widens if the grant maps to another group",
+			previous:    awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers"),
+			current:     awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers", "system:masters"),
+			wantWidened: true,
+		},
+		{
+			desc:        "widens if the grant maps another ARN",
+			previous:    awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers"),
+			current:     awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, adminARN, 0, "viewers"),
+			wantWidened: true,
+		},
+		{
+			desc:        "widens if the grant maps another section of aws-auth",
+			previous:    awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 0, "viewers"),
+			current:     awsAuthGrant(kudov1alpha1.EKSAwsAuthMapUsers, roleARN, 0, "viewers"),
+			wantWidened: true,
+		},
+		{
+			desc:        "widens if the grant lasts longer",
+			previous:    awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, 10*time.Minute, "viewers"),
+			current:     awsAuthGrant(kudov1alpha1.EKSAwsAuthMapRoles, roleARN, time.Hour, "viewers"),
+			wantWidened: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				factory, _, cancel = buildTestFactory(t, nil)
+			)
+
+			defer cancel()
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindEKSAwsAuth)
+			require.NoError(t, err)
+
+			gotWidened, err := granter.Widens(ctx, &kudov1alpha1.Escalation{}, testCase.previous, testCase.current)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantWidened, gotWidened)
+		})
+	}
+}
+
+func awsAuthConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-auth",
+			Namespace: "kube-system",
+		},
+		Data: data,
+	}
+}
+
+// injectUpdateConflicts makes the next n ConfigMap updates fail with a conflict.
+func injectUpdateConflicts(k8s fakeK8s, n int) {
+	fakeClient, ok := k8s.kubeClientSet.(*kubefake.Clientset)
+	if !ok || n == 0 {
+		return
+	}
+
+	fakeClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if n == 0 {
+			return false, nil, nil
+		}
+
+		n--
+
+		return true, nil, errors.NewConflict(
+			schema.GroupResource{Resource: "configmaps"},
+			"aws-auth",
+			stderrors.New("concurrent edit"),
+		)
+	})
+}
@@ -22,4 +22,21 @@ type Granter interface {
 	// Validate returns an error if the given escalation is not compatible with the given grant.
 	// It is used in webhook early catch configuration issues.
 	Validate(ctx context.Context, escalation *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) error
+
+	// ValidatePolicy returns an error if the given policy grant can't be used by any escalation.
+	// It is used to report broken policies in their status.
+	ValidatePolicy(ctx context.Context, grant kudov1alpha1.ValueWithKind) error
+
+	// Widens returns true if the current definition of a grant gives more permissions to the escalation than the previous one.
+	// It is used to review escalations whose policy has changed.
+	Widens(ctx context.Context, escalation *kudov1alpha1.Escalation, previous, current kudov1alpha1.ValueWithKind) (bool, error)
+}
+
+// durationWidens returns true if a grant lasts longer than before, a grant without duration lasting as long as its escalation.
+func durationWidens(previous, current kudov1alpha1.GrantCommonSpec) bool {
+	if previous.Duration.Duration == 0 {
+		return false
+	}
+
+	return current.Duration.Duration == 0 || current.Duration.Duration > previous.Duration.Duration
 }
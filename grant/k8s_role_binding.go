@@ -23,19 +23,32 @@ const (
 )
 
 var (
-	ErrNamespaceNotAllowed = stderrors.New("namespace is not allowed")
-	ErrNoNamespace         = stderrors.New("no namespace could be picked")
+	ErrNamespaceNotAllowed   = stderrors.New("namespace is not allowed")
+	ErrNoNamespace           = stderrors.New("no namespace could be picked")
+	ErrResourceNotNarrowable = stderrors.New("grant can't be restricted to this resource")
+	ErrResourceNotGranted    = stderrors.New("grant role does not give any permission on this resource")
+	ErrRoleNotFound          = stderrors.New("grant role does not exist")
+	ErrUnsupportedRoleKind   = stderrors.New("grant role kind is not supported")
 )
 
 type k8sRoleBindingGranter struct {
 	rbacClient        rbacv1client.RbacV1Interface
 	roleBindingLister rbacv1listers.RoleBindingLister
+	roleLister        rbacv1listers.RoleLister
+	clusterRoleLister rbacv1listers.ClusterRoleLister
 }
 
-func newK8sRoleBindingGranter(rbacClient rbacv1client.RbacV1Interface, rbacLister rbacv1listers.RoleBindingLister) (*k8sRoleBindingGranter, error) {
+func newK8sRoleBindingGranter(
+	rbacClient rbacv1client.RbacV1Interface,
+	rbacLister rbacv1listers.RoleBindingLister,
+	roleLister rbacv1listers.RoleLister,
+	clusterRoleLister rbacv1listers.ClusterRoleLister,
+) (*k8sRoleBindingGranter, error) {
 	return &k8sRoleBindingGranter{
 		rbacClient:        rbacClient,
 		roleBindingLister: rbacLister,
+		roleLister:        roleLister,
+		clusterRoleLister: clusterRoleLister,
 	}, nil
 }
 
@@ -45,7 +58,7 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
 
-	roleBinding, err := g.findRoleBinding(esc, k8sGrant)
+	roleBinding, existingRef, err := g.findRoleBinding(esc, k8sGrant)
 	if err != nil {
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
@@ -58,6 +71,8 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 				Namespace:       roleBinding.Namespace,
 				UID:             roleBinding.UID,
 				ResourceVersion: roleBinding.ResourceVersion,
+				RoleName:        existingRef.RoleName,
+				RoleUID:         existingRef.RoleUID,
 			},
 		)
 
@@ -72,6 +87,25 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
 
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.SchemeGroupVersion.Group,
+		Kind:     k8sGrant.RoleRef.Kind,
+		Name:     k8sGrant.RoleRef.Name,
+	}
+
+	var narrowedRole *rbacv1.Role
+
+	// If the escalation is restricted to some objects, generate a role that only allows them.
+	if len(esc.Spec.Resources) > 0 {
+		narrowedRole, err = g.createNarrowedRole(ctx, esc, k8sGrant, ns)
+		if err != nil {
+			return kudov1alpha1.EscalationGrantRef{}, err
+		}
+
+		roleRef.Kind = "Role"
+		roleRef.Name = narrowedRole.Name
+	}
+
 	roleBinding, err = g.rbacClient.RoleBindings(ns).Create(
 		ctx,
 		&rbacv1.RoleBinding{
@@ -89,22 +123,20 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 					managedByLabel: defaultManagedByValue,
 				},
 			},
-			Subjects: []rbacv1.Subject{
-				{
-					Kind: rbacv1.UserKind,
-					Name: esc.Spec.Requestor,
-				},
-			},
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: rbacv1.SchemeGroupVersion.Group,
-				Kind:     k8sGrant.RoleRef.Kind,
-				Name:     k8sGrant.RoleRef.Name,
-			},
+			Subjects: esc.Spec.GranteeSubjects(),
+			RoleRef:  roleRef,
 		},
 		metav1.CreateOptions{},
 	)
 
 	if err != nil {
+		if narrowedRole != nil {
+			// Don't leave an unreferenced role behind.
+			if deleteErr := g.rbacClient.Roles(ns).Delete(ctx, narrowedRole.Name, metav1.DeleteOptions{}); deleteErr != nil {
+				klog.ErrorS(deleteErr, "Unable to delete a generated role", "namespace", ns, "roleName", narrowedRole.Name)
+			}
+		}
+
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
 
@@ -115,21 +147,24 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 		"namespace",
 		ns,
 		"roleRef",
-		k8sGrant.RoleRef.Name,
+		roleRef.Name,
 		"roleBindingName",
 		roleBinding.Name,
 	)
 
-	encodedRef, err := v1alpha1.EncodeValueWithKind(
-		kudov1alpha1.GrantKindK8sRoleBinding,
-		kudov1alpha1.K8sRoleBindingGrantRef{
-			Name:            roleBinding.Name,
-			Namespace:       roleBinding.Namespace,
-			UID:             roleBinding.UID,
-			ResourceVersion: roleBinding.ResourceVersion,
-		},
-	)
+	k8sRef := kudov1alpha1.K8sRoleBindingGrantRef{
+		Name:            roleBinding.Name,
+		Namespace:       roleBinding.Namespace,
+		UID:             roleBinding.UID,
+		ResourceVersion: roleBinding.ResourceVersion,
+	}
+
+	if narrowedRole != nil {
+		k8sRef.RoleName = narrowedRole.Name
+		k8sRef.RoleUID = narrowedRole.UID
+	}
 
+	encodedRef, err := v1alpha1.EncodeValueWithKind(kudov1alpha1.GrantKindK8sRoleBinding, k8sRef)
 	if err != nil {
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
@@ -140,6 +175,52 @@ func (g *k8sRoleBindingGranter) Create(ctx context.Context, esc *kudov1alpha1.Es
 	}, nil
 }
 
+func (g *k8sRoleBindingGranter) createNarrowedRole(ctx context.Context, esc *kudov1alpha1.Escalation, grant *kudov1alpha1.K8sRoleBindingGrant, ns string) (*rbacv1.Role, error) {
+	rules, err := g.narrowedRules(esc, grant, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := g.rbacClient.Roles(ns).Create(
+		ctx,
+		&rbacv1.Role{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Role",
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "kudo-grant-",
+				Namespace:    ns,
+				OwnerReferences: []metav1.OwnerReference{
+					esc.AsOwnerRef(),
+				},
+				Labels: map[string]string{
+					managedByLabel: defaultManagedByValue,
+				},
+			},
+			Rules: rules,
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.InfoS(
+		"Created a new narrowed role",
+		"escalation",
+		esc.Name,
+		"namespace",
+		ns,
+		"roleRef",
+		grant.RoleRef.Name,
+		"roleName",
+		role.Name,
+	)
+
+	return role, nil
+}
+
 func (g *k8sRoleBindingGranter) Reclaim(ctx context.Context, ref kudov1alpha1.EscalationGrantRef) (kudov1alpha1.EscalationGrantRef, error) {
 	k8sRef, err := kudov1alpha1.DecodeValueWithKind[v1alpha1.K8sRoleBindingGrantRef](ref.Ref)
 	if err != nil {
@@ -153,14 +234,33 @@ func (g *k8sRoleBindingGranter) Reclaim(ctx context.Context, ref kudov1alpha1.Es
 
 	_, err = g.roleBindingLister.RoleBindings(k8sRef.Namespace).Get(k8sRef.Name)
 	switch {
-	case errors.IsNotFound(err):
+	case errors.IsNotFound(err) && k8sRef.RoleName == "":
 		return status, nil
-	case err != nil:
+	case err != nil && !errors.IsNotFound(err):
 		return kudov1alpha1.EscalationGrantRef{}, err
 	}
 
 	err = g.rbacClient.RoleBindings(k8sRef.Namespace).Delete(ctx, k8sRef.Name, metav1.DeleteOptions{})
 	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return kudov1alpha1.EscalationGrantRef{}, err
+	default:
+		klog.InfoS(
+			"Deleted a role binding",
+			"namespace",
+			k8sRef.Namespace,
+			"roleBndingName",
+			k8sRef.Name,
+		)
+	}
+
+	if k8sRef.RoleName == "" {
+		return status, nil
+	}
+
+	err = g.rbacClient.Roles(k8sRef.Namespace).Delete(ctx, k8sRef.RoleName, metav1.DeleteOptions{})
+	switch {
 	case errors.IsNotFound(err):
 		return status, nil
 	case err != nil:
@@ -168,28 +268,202 @@ func (g *k8sRoleBindingGranter) Reclaim(ctx context.Context, ref kudov1alpha1.Es
 	}
 
 	klog.InfoS(
-		"Deleted a role binding",
+		"Deleted a narrowed role",
 		"namespace",
 		k8sRef.Namespace,
-		"roleBndingName",
-		k8sRef.Name,
+		"roleName",
+		k8sRef.RoleName,
 	)
 
 	return status, nil
 }
 
-// Validate makes sure that the target namespace is properly defined.
+// Validate makes sure that the target namespace is properly defined,
+// and that the grant can be restricted to the objects requested by the escalation.
 func (g *k8sRoleBindingGranter) Validate(_ context.Context, esc *kudov1alpha1.Escalation, grant kudov1alpha1.ValueWithKind) error {
 	k8sGrant, err := kudov1alpha1.DecodeValueWithKind[v1alpha1.K8sRoleBindingGrant](grant)
 	if err != nil {
 		return err
 	}
 
-	_, err = targetNamespace(esc, k8sGrant)
+	ns, err := targetNamespace(esc, k8sGrant)
+	if err != nil {
+		return err
+	}
+
+	if len(esc.Spec.Resources) == 0 {
+		return nil
+	}
+
+	_, err = g.narrowedRules(esc, k8sGrant, ns)
 	return err
 }
 
-func (g *k8sRoleBindingGranter) findRoleBinding(esc *kudov1alpha1.Escalation, grant *kudov1alpha1.K8sRoleBindingGrant) (*rbacv1.RoleBinding, error) {
+// ValidatePolicy makes sure that the grant allows at least one namespace, and that the bound role exists
+// in every namespace the grant can target.
+func (g *k8sRoleBindingGranter) ValidatePolicy(_ context.Context, grant kudov1alpha1.ValueWithKind) error {
+	k8sGrant, err := kudov1alpha1.DecodeValueWithKind[v1alpha1.K8sRoleBindingGrant](grant)
+	if err != nil {
+		return err
+	}
+
+	namespaces := k8sGrant.AllowedNamespaces
+	if k8sGrant.DefaultNamespace != "" && !generics.Contains(namespaces, k8sGrant.DefaultNamespace) {
+		namespaces = append([]string{k8sGrant.DefaultNamespace}, namespaces...)
+	}
+
+	if len(namespaces) == 0 {
+		return ErrNoNamespace
+	}
+
+	switch k8sGrant.RoleRef.Kind {
+	case "ClusterRole":
+		_, err = g.clusterRoleLister.Get(k8sGrant.RoleRef.Name)
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("%w: cluster role %s", ErrRoleNotFound, k8sGrant.RoleRef.Name)
+		}
+
+		return err
+	case "Role":
+		for _, ns := range namespaces {
+			_, err = g.roleLister.Roles(ns).Get(k8sGrant.RoleRef.Name)
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("%w: role %s, namespace: %s", ErrRoleNotFound, k8sGrant.RoleRef.Name, ns)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, allowed values: %v", ErrUnsupportedRoleKind, k8sGrant.RoleRef.Kind, []string{"Role", "ClusterRole"})
+	}
+}
+
+// Widens returns true if the grant now binds another role, in another namespace, or for longer.
+func (g *k8sRoleBindingGranter) Widens(_ context.Context, esc *kudov1alpha1.Escalation, previous, current kudov1alpha1.ValueWithKind) (bool, error) {
+	previousGrant, err := kudov1alpha1.DecodeValueWithKind[v1alpha1.K8sRoleBindingGrant](previous)
+	if err != nil {
+		return false, err
+	}
+
+	currentGrant, err := kudov1alpha1.DecodeValueWithKind[v1alpha1.K8sRoleBindingGrant](current)
+	if err != nil {
+		return false, err
+	}
+
+	previousNs, err := targetNamespace(esc, previousGrant)
+	if err != nil {
+		return false, err
+	}
+
+	currentNs, err := targetNamespace(esc, currentGrant)
+	if err != nil {
+		return false, err
+	}
+
+	return previousGrant.RoleRef != currentGrant.RoleRef ||
+		previousNs != currentNs ||
+		durationWidens(previousGrant.GrantCommonSpec, currentGrant.GrantCommonSpec), nil
+}
+
+// narrowedRules intersects the rules of the grant role with the objects requested by the escalation.
+func (g *k8sRoleBindingGranter) narrowedRules(esc *kudov1alpha1.Escalation, grant *kudov1alpha1.K8sRoleBindingGrant, ns string) ([]rbacv1.PolicyRule, error) {
+	for _, resource := range esc.Spec.Resources {
+		narrowable := kudov1alpha1.NarrowableResource{APIGroup: resource.APIGroup, Resource: resource.Resource}
+
+		if !generics.Contains(grant.NarrowableResources, narrowable) {
+			return nil, fmt.Errorf(
+				"%w: %s, allowed values: %v",
+				ErrResourceNotNarrowable,
+				formatResource(resource),
+				grant.NarrowableResources,
+			)
+		}
+	}
+
+	roleRules, err := g.roleRules(grant.RoleRef, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []rbacv1.PolicyRule
+
+	for _, resource := range esc.Spec.Resources {
+		resourceRules := narrowRules(roleRules, resource)
+		if len(resourceRules) == 0 {
+			return nil, fmt.Errorf(
+				"%w: %s, role: %s",
+				ErrResourceNotGranted,
+				formatResource(resource),
+				grant.RoleRef.Name,
+			)
+		}
+
+		rules = append(rules, resourceRules...)
+	}
+
+	return rules, nil
+}
+
+func (g *k8sRoleBindingGranter) roleRules(roleRef rbacv1.RoleRef, ns string) ([]rbacv1.PolicyRule, error) {
+	if roleRef.Kind == "ClusterRole" {
+		clusterRole, err := g.clusterRoleLister.Get(roleRef.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return clusterRole.Rules, nil
+	}
+
+	role, err := g.roleLister.Roles(ns).Get(roleRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return role.Rules, nil
+}
+
+// narrowRules keeps the rules that apply to the given resource, and restricts them to its name.
+func narrowRules(rules []rbacv1.PolicyRule, resource kudov1alpha1.EscalationResource) []rbacv1.PolicyRule {
+	var narrowed []rbacv1.PolicyRule
+
+	for _, rule := range rules {
+		if !matchesOrWildcard(rule.APIGroups, resource.APIGroup) ||
+			!matchesOrWildcard(rule.Resources, resource.Resource) {
+			continue
+		}
+
+		if len(rule.ResourceNames) > 0 && !generics.Contains(rule.ResourceNames, resource.Name) {
+			continue
+		}
+
+		narrowed = append(narrowed, rbacv1.PolicyRule{
+			Verbs:         rule.Verbs,
+			APIGroups:     []string{resource.APIGroup},
+			Resources:     []string{resource.Resource},
+			ResourceNames: []string{resource.Name},
+		})
+	}
+
+	return narrowed
+}
+
+func matchesOrWildcard(values []string, value string) bool {
+	return generics.Contains(values, rbacv1.ResourceAll) || generics.Contains(values, value)
+}
+
+func formatResource(resource kudov1alpha1.EscalationResource) string {
+	if resource.APIGroup == "" {
+		return resource.Resource + "/" + resource.Name
+	}
+
+	return resource.Resource + "." + resource.APIGroup + "/" + resource.Name
+}
+
+func (g *k8sRoleBindingGranter) findRoleBinding(esc *kudov1alpha1.Escalation, grant *kudov1alpha1.K8sRoleBindingGrant) (*rbacv1.RoleBinding, *kudov1alpha1.K8sRoleBindingGrantRef, error) {
 	for _, grantRef := range esc.Status.GrantRefs {
 		if grantRef.Ref.Kind != kudov1alpha1.GrantKindK8sRoleBinding || grantRef.Status != kudov1alpha1.GrantStatusCreated {
 			continue
@@ -197,12 +471,12 @@ func (g *k8sRoleBindingGranter) findRoleBinding(esc *kudov1alpha1.Escalation, gr
 
 		k8sRef, err := v1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](grantRef.Ref)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		ns, err := targetNamespace(esc, grant)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		binding, err := g.roleBindingLister.RoleBindings(ns).Get(k8sRef.Name)
@@ -210,12 +484,12 @@ func (g *k8sRoleBindingGranter) findRoleBinding(esc *kudov1alpha1.Escalation, gr
 		case errors.IsNotFound(err):
 			continue
 		case err != nil:
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Lookup for a binding, check it's UID and ResourceVersion if it has been tampered, fail the escalation.
 		if binding.UID != k8sRef.UID || binding.ResourceVersion != k8sRef.ResourceVersion {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"%w: Role binding %s in namespace %s",
 				ErrTampered,
 				binding.Name,
@@ -224,13 +498,38 @@ func (g *k8sRoleBindingGranter) findRoleBinding(esc *kudov1alpha1.Escalation, gr
 		}
 
 		// If the binding matches the grant we want to create the all good.
-		if binding.RoleRef.Kind == grant.RoleRef.Kind &&
+		if k8sRef.RoleName == "" &&
+			binding.RoleRef.Kind == grant.RoleRef.Kind &&
 			binding.RoleRef.Name == grant.RoleRef.Name {
-			return binding, nil
+			return binding, k8sRef, nil
+		}
+
+		// If the binding refers to a role generated for this escalation, make sure the role has not been tampered with.
+		if k8sRef.RoleName != "" &&
+			binding.RoleRef.Kind == "Role" &&
+			binding.RoleRef.Name == k8sRef.RoleName {
+			role, err := g.roleLister.Roles(ns).Get(k8sRef.RoleName)
+			switch {
+			case errors.IsNotFound(err):
+				continue
+			case err != nil:
+				return nil, nil, err
+			}
+
+			if role.UID != k8sRef.RoleUID {
+				return nil, nil, fmt.Errorf(
+					"%w: Role %s in namespace %s",
+					ErrTampered,
+					role.Name,
+					role.Namespace,
+				)
+			}
+
+			return binding, k8sRef, nil
 		}
 	}
 
-	return nil, nil
+	return nil, nil, nil
 }
 
 func targetNamespace(esc *kudov1alpha1.Escalation, grant *kudov1alpha1.K8sRoleBindingGrant) (string, error) {
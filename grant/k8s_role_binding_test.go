@@ -14,6 +14,7 @@ import (
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 
 	"github.com/jlevesy/kudo/grant"
 	kudov1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
@@ -48,6 +49,33 @@ var (
 		},
 	}
 
+	testEscalationServiceAccount = kudov1alpha1.Escalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-escalation",
+		},
+		Spec: kudov1alpha1.EscalationSpec{
+			Requestor:  "system:serviceaccount:ci:deployer",
+			PolicyName: "rule-the-world",
+		},
+		Status: kudov1alpha1.EscalationStatus{
+			State: kudov1alpha1.StateAccepted,
+		},
+	}
+
+	testEscalationTeam = kudov1alpha1.Escalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-escalation",
+		},
+		Spec: kudov1alpha1.EscalationSpec{
+			Requestor:     "jean-testor",
+			Beneficiaries: []string{"jeanne-testeuse", "system:serviceaccount:ci:deployer"},
+			PolicyName:    "rule-the-world",
+		},
+		Status: kudov1alpha1.EscalationStatus{
+			State: kudov1alpha1.StateAccepted,
+		},
+	}
+
 	testEscalationWithBadTargetNs = kudov1alpha1.Escalation{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "test-escalation",
@@ -149,6 +177,67 @@ var (
 		},
 	)
 
+	testNarrowableGrant = kudov1alpha1.MustEncodeValueWithKind(
+		kudov1alpha1.GrantKindK8sRoleBinding,
+		kudov1alpha1.K8sRoleBindingGrant{
+			DefaultNamespace: "ns-a",
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "test-role",
+			},
+			NarrowableResources: []kudov1alpha1.NarrowableResource{
+				{APIGroup: "", Resource: "pods"},
+				{APIGroup: "apps", Resource: "deployments"},
+			},
+		},
+	)
+
+	testEscalationWithResources = kudov1alpha1.Escalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-escalation",
+		},
+		Spec: kudov1alpha1.EscalationSpec{
+			Requestor:  "jean-testor",
+			PolicyName: "rule-the-world",
+			Resources: []kudov1alpha1.EscalationResource{
+				{APIGroup: "", Resource: "pods", Name: "web-0"},
+			},
+		},
+		Status: kudov1alpha1.EscalationStatus{
+			State: kudov1alpha1.StateAccepted,
+		},
+	}
+
+	narrowableClusterRole = rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-role",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				Verbs:     []string{"get", "delete"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+			{
+				Verbs:     []string{"get"},
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+			},
+			{
+				Verbs:         []string{"update"},
+				APIGroups:     []string{"apps"},
+				Resources:     []string{"deployments"},
+				ResourceNames: []string{"api"},
+			},
+			{
+				Verbs:     []string{"*"},
+				APIGroups: []string{"*"},
+				Resources: []string{"pods"},
+			},
+		},
+	}
+
 	otherBinding = rbacv1.RoleBinding{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: rbacv1.SchemeGroupVersion.String(),
@@ -205,6 +294,72 @@ var (
 		},
 	}
 
+	existingBindingNoUIDServiceAccount = rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kudo-grant-",
+			Namespace:    "ns-a",
+			Labels: map[string]string{
+				"app.kubernetes.io/created-by": "kudo",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				testEscalationServiceAccount.AsOwnerRef(),
+			},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: "ci",
+				Name:      "deployer",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-role",
+		},
+	}
+
+	existingBindingNoUIDTeam = rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kudo-grant-",
+			Namespace:    "ns-a",
+			Labels: map[string]string{
+				"app.kubernetes.io/created-by": "kudo",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				testEscalationTeam.AsOwnerRef(),
+			},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind: rbacv1.UserKind,
+				Name: "jean-testor",
+			},
+			{
+				Kind: rbacv1.UserKind,
+				Name: "jeanne-testeuse",
+			},
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: "ci",
+				Name:      "deployer",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-role",
+		},
+	}
+
 	existingBindingNoUIDNsB = rbacv1.RoleBinding{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: rbacv1.SchemeGroupVersion.String(),
@@ -305,6 +460,32 @@ func TestK8sRoleBindingGranter_Create(t *testing.T) {
 				Items: []rbacv1.RoleBinding{existingBindingNoUIDNsB},
 			},
 		},
+		{
+			desc:          "binds service account requestors as service accounts",
+			escalation:    testEscalationServiceAccount,
+			grant:         testGrant,
+			wantRefStatus: kudov1alpha1.GrantStatusCreated,
+			wantK8sRef: kudov1alpha1.K8sRoleBindingGrantRef{
+				Name:      "", // testclient does not handle generate name.
+				Namespace: "ns-a",
+			},
+			wantBindings: rbacv1.RoleBindingList{
+				Items: []rbacv1.RoleBinding{existingBindingNoUIDServiceAccount},
+			},
+		},
+		{
+			desc:          "binds all the escalation beneficiaries",
+			escalation:    testEscalationTeam,
+			grant:         testGrant,
+			wantRefStatus: kudov1alpha1.GrantStatusCreated,
+			wantK8sRef: kudov1alpha1.K8sRoleBindingGrantRef{
+				Name:      "", // testclient does not handle generate name.
+				Namespace: "ns-a",
+			},
+			wantBindings: rbacv1.RoleBindingList{
+				Items: []rbacv1.RoleBinding{existingBindingNoUIDTeam},
+			},
+		},
 		{
 			desc:            "raises an error if the target namespace is not allowed",
 			escalation:      testEscalationWithBadTargetNs,
@@ -488,6 +669,7 @@ func TestK8sRoleBindingGranter_Reclaim(t *testing.T) {
 func TestK8sRoleBindingGranter_Validate(t *testing.T) {
 	testCases := []struct {
 		desc       string
+		seed       []runtime.Object
 		grant      kudov1alpha1.ValueWithKind
 		escalation kudov1alpha1.Escalation
 		wantError  error
@@ -551,13 +733,39 @@ func TestK8sRoleBindingGranter_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:       "raises an error if the grant can't be restricted to the requested resource",
+			seed:       []runtime.Object{&narrowableClusterRole},
+			grant:      testGrant,
+			escalation: testEscalationWithResources,
+			wantError:  grant.ErrResourceNotNarrowable,
+		},
+		{
+			desc:  "raises an error if the grant role does not give any permission on the requested resource",
+			seed:  []runtime.Object{&narrowableClusterRole},
+			grant: testNarrowableGrant,
+			escalation: kudov1alpha1.Escalation{
+				Spec: kudov1alpha1.EscalationSpec{
+					Resources: []kudov1alpha1.EscalationResource{
+						{APIGroup: "apps", Resource: "deployments", Name: "web"},
+					},
+				},
+			},
+			wantError: grant.ErrResourceNotGranted,
+		},
+		{
+			desc:       "raises no error if the grant can be restricted to the requested resource",
+			seed:       []runtime.Object{&narrowableClusterRole},
+			grant:      testNarrowableGrant,
+			escalation: testEscalationWithResources,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.desc, func(t *testing.T) {
 			var (
 				ctx                = context.Background()
-				factory, _, cancel = buildTestFactory(t, nil)
+				factory, _, cancel = buildTestFactory(t, testCase.seed)
 			)
 
 			defer cancel()
@@ -571,6 +779,263 @@ func TestK8sRoleBindingGranter_Validate(t *testing.T) {
 	}
 }
 
+func TestK8sRoleBindingGranter_ValidatePolicy(t *testing.T) {
+	var (
+		role = func(namespace string) *rbacv1.Role {
+			return &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "app-admin", Namespace: namespace}}
+		}
+
+		roleGrant = func(defaultNamespace string, allowedNamespaces ...string) kudov1alpha1.ValueWithKind {
+			return kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindK8sRoleBinding,
+				kudov1alpha1.K8sRoleBindingGrant{
+					DefaultNamespace:  defaultNamespace,
+					AllowedNamespaces: allowedNamespaces,
+					RoleRef:           rbacv1.RoleRef{Kind: "Role", Name: "app-admin"},
+				},
+			)
+		}
+	)
+
+	testCases := []struct {
+		desc      string
+		seed      []runtime.Object
+		grant     kudov1alpha1.ValueWithKind
+		wantError error
+	}{
+		{
+			desc: "raises an error if the grant allows no namespace",
+			seed: []runtime.Object{&narrowableClusterRole},
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindK8sRoleBinding,
+				kudov1alpha1.K8sRoleBindingGrant{
+					RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "test-role"},
+				},
+			),
+			wantError: grant.ErrNoNamespace,
+		},
+		{
+			desc:      "raises an error if the cluster role does not exist",
+			grant:     testGrant,
+			wantError: grant.ErrRoleNotFound,
+		},
+		{
+			desc:  "raises no error if the cluster role exists",
+			seed:  []runtime.Object{&narrowableClusterRole},
+			grant: testGrant,
+		},
+		{
+			desc:      "raises an error if the role is missing in an allowed namespace",
+			seed:      []runtime.Object{role("ns-a")},
+			grant:     roleGrant("ns-a", "ns-b"),
+			wantError: grant.ErrRoleNotFound,
+		},
+		{
+			desc:  "raises no error if the role exists in every namespace",
+			seed:  []runtime.Object{role("ns-a"), role("ns-b")},
+			grant: roleGrant("ns-a", "ns-b"),
+		},
+		{
+			desc: "raises an error if the role kind is not supported",
+			grant: kudov1alpha1.MustEncodeValueWithKind(
+				kudov1alpha1.GrantKindK8sRoleBinding,
+				kudov1alpha1.K8sRoleBindingGrant{
+					DefaultNamespace: "ns-a",
+					RoleRef:          rbacv1.RoleRef{Kind: "Group", Name: "admins"},
+				},
+			),
+			wantError: grant.ErrUnsupportedRoleKind,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				factory, _, cancel = buildTestFactory(t, testCase.seed)
+			)
+
+			defer cancel()
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindK8sRoleBinding)
+			require.NoError(t, err)
+
+			err = granter.ValidatePolicy(ctx, testCase.grant)
+			assert.ErrorIs(t, err, testCase.wantError)
+		})
+	}
+}
+
+func TestK8sRoleBindingGranter_Widens(t *testing.T) {
+	roleGrant := func(roleName, defaultNamespace string, duration time.Duration, allowedNamespaces ...string) kudov1alpha1.ValueWithKind {
+		return kudov1alpha1.MustEncodeValueWithKind(
+			kudov1alpha1.GrantKindK8sRoleBinding,
+			kudov1alpha1.K8sRoleBindingGrant{
+				GrantCommonSpec:   kudov1alpha1.GrantCommonSpec{Duration: metav1.Duration{Duration: duration}},
+				DefaultNamespace:  defaultNamespace,
+				AllowedNamespaces: allowedNamespaces,
+				RoleRef:           rbacv1.RoleRef{Kind: "ClusterRole", Name: roleName},
+			},
+		)
+	}
+
+	testCases := []struct {
+		desc        string
+		namespace   string
+		previous    kudov1alpha1.ValueWithKind
+		current     kudov1alpha1.ValueWithKind
+		wantWidened bool
+	}{
+		{
+			desc:     "does not widen if the grant is allowed in more namespaces",
+			previous: roleGrant("app-admin", "ns-a", 0),
+			current:  roleGrant("app-admin", "ns-a", 0, "ns-b"),
+		},
+		{
+			desc:     "does not widen if the grant lasts less",
+			previous: roleGrant("app-admin", "ns-a", time.Hour),
+			current:  roleGrant("app-admin", "ns-a", 10*time.Minute),
+		},
+		{
+			desc:        "widens if the grant binds another role",
+			previous:    roleGrant("app-admin", "ns-a", 0),
+			current:     roleGrant("cluster-admin", "ns-a", 0),
+			wantWidened: true,
+		},
+		{
+			desc:        "widens if the grant now targets another namespace",
+			previous:    roleGrant("app-admin", "ns-a", 0),
+			current:     roleGrant("app-admin", "kube-system", 0),
+			wantWidened: true,
+		},
+		{
+			desc:      "does not widen if the escalation namespace is still targeted",
+			namespace: "ns-b",
+			previous:  roleGrant("app-admin", "ns-a", 0, "ns-b"),
+			current:   roleGrant("app-admin", "kube-system", 0, "ns-b"),
+		},
+		{
+			desc:        "widens if the grant lasts longer",
+			previous:    roleGrant("app-admin", "ns-a", 10*time.Minute),
+			current:     roleGrant("app-admin", "ns-a", time.Hour),
+			wantWidened: true,
+		},
+		{
+			desc:        "widens if the grant now lasts as long as the escalation",
+			previous:    roleGrant("app-admin", "ns-a", 10*time.Minute),
+			current:     roleGrant("app-admin", "ns-a", 0),
+			wantWidened: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				factory, _, cancel = buildTestFactory(t, nil)
+				esc                = &kudov1alpha1.Escalation{
+					Spec: kudov1alpha1.EscalationSpec{Namespace: testCase.namespace},
+				}
+			)
+
+			defer cancel()
+
+			granter, err := factory.Get(kudov1alpha1.GrantKindK8sRoleBinding)
+			require.NoError(t, err)
+
+			gotWidened, err := granter.Widens(ctx, esc, testCase.previous, testCase.current)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantWidened, gotWidened)
+		})
+	}
+}
+
+func TestK8sRoleBindingGranter_NarrowedResources(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		factory, k8s, cancel = buildTestFactory(t, []runtime.Object{&narrowableClusterRole})
+	)
+
+	defer cancel()
+
+	// The fake client does not handle generate name, give the generated role a name to follow it.
+	k8s.kubeClientSet.(*kubefake.Clientset).PrependReactor(
+		"create",
+		"roles",
+		func(action ktesting.Action) (bool, runtime.Object, error) {
+			role := action.(ktesting.CreateAction).GetObject().(*rbacv1.Role)
+			role.Name = role.GenerateName + "narrowed"
+			return false, nil, nil
+		},
+	)
+
+	granter, err := factory.Get(kudov1alpha1.GrantKindK8sRoleBinding)
+	require.NoError(t, err)
+
+	gotRef, err := granter.Create(ctx, &testEscalationWithResources, testNarrowableGrant)
+	require.NoError(t, err)
+
+	gotK8sRef, err := kudov1alpha1.DecodeValueWithKind[kudov1alpha1.K8sRoleBindingGrantRef](gotRef.Ref)
+	require.NoError(t, err)
+
+	assert.Equal(t, kudov1alpha1.GrantStatusCreated, gotRef.Status)
+	assert.Equal(
+		t,
+		kudov1alpha1.K8sRoleBindingGrantRef{
+			Namespace: "ns-a",
+			RoleName:  "kudo-grant-narrowed",
+		},
+		*gotK8sRef,
+	)
+
+	gotRole, err := k8s.kubeClientSet.RbacV1().Roles("ns-a").Get(ctx, "kudo-grant-narrowed", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]rbacv1.PolicyRule{
+			{
+				Verbs:         []string{"get", "delete"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"web-0"},
+			},
+			{
+				Verbs:         []string{"*"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"web-0"},
+			},
+		},
+		gotRole.Rules,
+	)
+	assert.Equal(t, []metav1.OwnerReference{testEscalationWithResources.AsOwnerRef()}, gotRole.OwnerReferences)
+
+	gotBinding, err := k8s.kubeClientSet.RbacV1().RoleBindings("ns-a").Get(ctx, "", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     "kudo-grant-narrowed",
+		},
+		gotBinding.RoleRef,
+	)
+
+	_, err = granter.Reclaim(ctx, gotRef)
+	require.NoError(t, err)
+
+	gotRoles, err := k8s.kubeClientSet.RbacV1().Roles("ns-a").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, gotRoles.Items)
+
+	gotBindings, err := k8s.kubeClientSet.RbacV1().RoleBindings("ns-a").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, gotBindings.Items)
+}
+
 type fakeK8s struct {
 	kubeClientSet        kubernetes.Interface
 	kubeInformersFactory kubeinformers.SharedInformerFactory
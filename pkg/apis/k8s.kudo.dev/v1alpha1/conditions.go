@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Escalation condition types, maintained by TransitionTo.
+const (
+	// ConditionApproved is true once the escalation challenges are completed.
+	ConditionApproved = "Approved"
+	// ConditionGranted is true while all the escalation grants are in place.
+	ConditionGranted = "Granted"
+	// ConditionExpired is true once the escalation has expired.
+	ConditionExpired = "Expired"
+	// ConditionReclaimFailed is true if the last attempt to reclaim grants has failed.
+	ConditionReclaimFailed = "ReclaimFailed"
+	// ConditionTampered is true if a resource managed by kudo has been modified by someone else.
+	ConditionTampered = "Tampered"
+)
+
+var conditionTypes = []string{
+	ConditionApproved,
+	ConditionGranted,
+	ConditionExpired,
+	ConditionReclaimFailed,
+	ConditionTampered,
+}
+
+var stateReasons = map[EscalationState]string{
+	StateUnknown:   "Unknown",
+	StatePending:   "Pending",
+	StateScheduled: "Scheduled",
+	StateDenied:    "Denied",
+	StateAccepted:  "Accepted",
+	StateExpired:   "Expired",
+	StateReleased:  "Released",
+	StateRevoked:   "Revoked",
+}
+
+// WithReclaimFailure reports that grants could not be reclaimed.
+func WithReclaimFailure(err error) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.setCondition(ConditionReclaimFailed, metav1.ConditionTrue, "ReclaimFailed", err.Error())
+	}
+}
+
+// WithTampering reports that a resource managed by kudo has been tampered with.
+func WithTampering(err error) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.setCondition(ConditionTampered, metav1.ConditionTrue, "Tampered", err.Error())
+	}
+}
+
+// IsConditionTrue returns true if the given condition is set and true.
+func (e *EscalationStatus) IsConditionTrue(conditionType string) bool {
+	condition := e.findCondition(conditionType)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// resetEventConditions sets the conditions reported by mutations to their default value.
+// A reclaim failure is only reported until the next transition, tampering is reported forever.
+func (e *EscalationStatus) resetEventConditions() {
+	e.setCondition(ConditionReclaimFailed, metav1.ConditionFalse, "NoFailure", "")
+
+	if !e.IsConditionTrue(ConditionTampered) {
+		e.setCondition(ConditionTampered, metav1.ConditionFalse, "NotTampered", "")
+	}
+}
+
+// setStateConditions derives the conditions describing the escalation progress from its state.
+func (e *EscalationStatus) setStateConditions() {
+	stateReason := stateReasons[e.State]
+
+	switch e.State {
+	case StateScheduled, StateAccepted, StateExpired:
+		e.setCondition(ConditionApproved, metav1.ConditionTrue, stateReasons[StateAccepted], e.StateDetails)
+	case StateDenied, StateReleased, StateRevoked:
+		// An escalation can end before or after its approval, keep track of it.
+		if !e.IsConditionTrue(ConditionApproved) {
+			e.setCondition(ConditionApproved, metav1.ConditionFalse, stateReason, e.StateDetails)
+		}
+	default:
+		e.setCondition(ConditionApproved, metav1.ConditionFalse, stateReason, e.StateDetails)
+	}
+
+	switch {
+	case e.State == StateAccepted && e.allGrantsActive():
+		e.setCondition(ConditionGranted, metav1.ConditionTrue, "Granted", e.StateDetails)
+	case e.State == StateAccepted:
+		e.setCondition(ConditionGranted, metav1.ConditionFalse, "GrantsPending", e.StateDetails)
+	default:
+		e.setCondition(ConditionGranted, metav1.ConditionFalse, stateReason, e.StateDetails)
+	}
+
+	if e.State == StateExpired {
+		e.setCondition(ConditionExpired, metav1.ConditionTrue, stateReason, e.StateDetails)
+	} else {
+		e.setCondition(ConditionExpired, metav1.ConditionFalse, stateReason, "")
+	}
+
+	sort.SliceStable(e.Conditions, func(i, j int) bool {
+		return conditionIndex(e.Conditions[i].Type) < conditionIndex(e.Conditions[j].Type)
+	})
+}
+
+// allGrantsActive returns true if all grants are created, or have been reclaimed because they've reached their own expiry.
+func (e *EscalationStatus) allGrantsActive() bool {
+	if len(e.GrantRefs) == 0 {
+		return false
+	}
+
+	for _, ref := range e.GrantRefs {
+		if ref.Status == GrantStatusCreated {
+			continue
+		}
+
+		if ref.Status == GrantStatusReclaimed && !ref.ExpiresAt.IsZero() {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// setCondition creates or updates a condition. Its transition time is cleared when its status changes,
+// it is set by the controller when writing the status.
+func (e *EscalationStatus) setCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	condition := e.findCondition(conditionType)
+	if condition == nil {
+		e.Conditions = append(e.Conditions, metav1.Condition{Type: conditionType})
+		condition = &e.Conditions[len(e.Conditions)-1]
+	}
+
+	if condition.Status != status {
+		condition.LastTransitionTime = metav1.Time{}
+	}
+
+	condition.Status = status
+	condition.Reason = reason
+	condition.Message = message
+}
+
+func (e *EscalationStatus) findCondition(conditionType string) *metav1.Condition {
+	for i := range e.Conditions {
+		if e.Conditions[i].Type == conditionType {
+			return &e.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func conditionIndex(conditionType string) int {
+	for i, candidate := range conditionTypes {
+		if candidate == conditionType {
+			return i
+		}
+	}
+
+	return len(conditionTypes)
+}
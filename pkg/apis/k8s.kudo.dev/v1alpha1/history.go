@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaxHistoryLength is the maximum amount of transitions kept in an escalation history, older transitions are dropped first.
+const MaxHistoryLength = 32
+
+// EscalationTransition records a change made to an escalation.
+type EscalationTransition struct {
+	From EscalationState `json:"from"`
+	To   EscalationState `json:"to"`
+	// At is set by the controller when writing the status.
+	At      metav1.Time     `json:"at"`
+	Actor   EscalationActor `json:"actor"`
+	Details string          `json:"details,omitempty"`
+}
+
+type ActorKind string
+
+const (
+	ActorKindController ActorKind = "Controller"
+	ActorKindRequestor  ActorKind = "Requestor"
+	ActorKindReviewer   ActorKind = "Reviewer"
+	ActorKindAdmin      ActorKind = "Admin"
+	// ActorKindDelegate is the user that created an escalation on behalf of its requestor.
+	ActorKindDelegate ActorKind = "Delegate"
+)
+
+// EscalationActor is who caused a transition.
+type EscalationActor struct {
+	Kind ActorKind `json:"kind"`
+	Name string    `json:"name,omitempty"`
+}
+
+func (a EscalationActor) String() string {
+	if a.Name == "" {
+		return string(a.Kind)
+	}
+
+	return string(a.Kind) + " " + a.Name
+}
+
+// ControllerActor is the actor of all the transitions decided by kudo itself.
+var ControllerActor = EscalationActor{Kind: ActorKindController}
+
+// WithActor records who caused the transition. Transitions that do not change the state are only
+// recorded in the history if they have an actor.
+func WithActor(actor EscalationActor) TransitionMutation {
+	return func(st *EscalationStatus) {
+		if n := len(st.History); n > 0 && st.History[n-1].At.IsZero() {
+			st.History[n-1].Actor = actor
+			return
+		}
+
+		st.History = append(st.History, EscalationTransition{From: st.State, To: st.State, Actor: actor})
+	}
+}
+
+// completeHistory fills the details of the transitions recorded since the status was written, and drops the oldest transitions
+// if the history is too long.
+func (e *EscalationStatus) completeHistory() {
+	for i := range e.History {
+		if e.History[i].At.IsZero() && e.History[i].Details == "" {
+			e.History[i].Details = e.StateDetails
+		}
+	}
+
+	if len(e.History) > MaxHistoryLength {
+		e.History = e.History[len(e.History)-MaxHistoryLength:]
+	}
+}
@@ -33,6 +33,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&EscalationPolicy{},
 		&EscalationPolicyList{},
+		&NamespacedEscalationPolicy{},
+		&NamespacedEscalationPolicyList{},
+		&EscalationPolicyTemplate{},
+		&EscalationPolicyTemplateList{},
 		&Escalation{},
 		&EscalationList{},
 	)
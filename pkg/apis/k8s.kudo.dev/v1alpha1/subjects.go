@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// ServiceAccountUsername returns the username the API server authenticates a service account with.
+func ServiceAccountUsername(namespace, name string) string {
+	return serviceAccountUsernamePrefix + namespace + ":" + name
+}
+
+// SplitServiceAccountUsername returns the namespace and the name of a service account from its username.
+// It returns false if the username doesn't belong to a service account.
+func SplitServiceAccountUsername(username string) (string, string, bool) {
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// RequestorSubject returns the RBAC subject of the escalation requestor.
+// Requestors authenticated as a service account get a ServiceAccount subject, any other requestor gets a User subject.
+func (e *EscalationSpec) RequestorSubject() rbacv1.Subject {
+	return usernameSubject(e.Requestor)
+}
+
+// Grantees returns the usernames of everyone the escalation grants permissions to, the requestor first.
+func (e *EscalationSpec) Grantees() []string {
+	return append([]string{e.Requestor}, e.Beneficiaries...)
+}
+
+// GranteeSubjects returns the RBAC subjects of everyone the escalation grants permissions to, the requestor first.
+func (e *EscalationSpec) GranteeSubjects() []rbacv1.Subject {
+	grantees := e.Grantees()
+	subjects := make([]rbacv1.Subject, len(grantees))
+
+	for i, username := range grantees {
+		subjects[i] = usernameSubject(username)
+	}
+
+	return subjects
+}
+
+func usernameSubject(username string) rbacv1.Subject {
+	if namespace, name, ok := SplitServiceAccountUsername(username); ok {
+		return rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+
+	return rbacv1.Subject{
+		Kind: rbacv1.UserKind,
+		Name: username,
+	}
+}
+
+// IsMatchable returns true if kudo knows how to match users against the subject: it is a user, a group or a service account,
+// or it has no kind and some extra attributes.
+func (s *PolicySubject) IsMatchable() bool {
+	switch s.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind, rbacv1.ServiceAccountKind:
+		return true
+	case "":
+		return len(s.Extra) > 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,119 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestEscalationSpec_RequestorSubject(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		requestor   string
+		wantSubject rbacv1.Subject
+	}{
+		{
+			desc:        "user requestor",
+			requestor:   "jean-testor",
+			wantSubject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "jean-testor"},
+		},
+		{
+			desc:        "service account requestor",
+			requestor:   "system:serviceaccount:ci:deployer",
+			wantSubject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ci", Name: "deployer"},
+		},
+		{
+			desc:        "malformed service account username",
+			requestor:   "system:serviceaccount:ci",
+			wantSubject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "system:serviceaccount:ci"},
+		},
+		{
+			desc:        "service account username with an empty name",
+			requestor:   "system:serviceaccount:ci:",
+			wantSubject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "system:serviceaccount:ci:"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			spec := v1alpha1.EscalationSpec{Requestor: testCase.requestor}
+
+			assert.Equal(t, testCase.wantSubject, spec.RequestorSubject())
+		})
+	}
+}
+
+func TestServiceAccountUsername(t *testing.T) {
+	username := v1alpha1.ServiceAccountUsername("ci", "deployer")
+
+	assert.Equal(t, "system:serviceaccount:ci:deployer", username)
+
+	namespace, name, ok := v1alpha1.SplitServiceAccountUsername(username)
+	assert.True(t, ok)
+	assert.Equal(t, "ci", namespace)
+	assert.Equal(t, "deployer", name)
+}
+
+func TestEscalationSpec_GranteeSubjects(t *testing.T) {
+	spec := v1alpha1.EscalationSpec{
+		Requestor:     "jean-testor",
+		Beneficiaries: []string{"jeanne-testeuse", "system:serviceaccount:ci:deployer"},
+	}
+
+	assert.Equal(t, []string{"jean-testor", "jeanne-testeuse", "system:serviceaccount:ci:deployer"}, spec.Grantees())
+	assert.Equal(
+		t,
+		[]rbacv1.Subject{
+			{Kind: rbacv1.UserKind, Name: "jean-testor"},
+			{Kind: rbacv1.UserKind, Name: "jeanne-testeuse"},
+			{Kind: rbacv1.ServiceAccountKind, Namespace: "ci", Name: "deployer"},
+		},
+		spec.GranteeSubjects(),
+	)
+}
+
+func TestPolicySubject_IsMatchable(t *testing.T) {
+	for _, testCase := range []struct {
+		desc    string
+		subject v1alpha1.PolicySubject
+		want    bool
+	}{
+		{
+			desc:    "user",
+			subject: v1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "jean-testor"}},
+			want:    true,
+		},
+		{
+			desc:    "group",
+			subject: v1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "devs"}},
+			want:    true,
+		},
+		{
+			desc:    "service account",
+			subject: v1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ci", Name: "deployer"}},
+			want:    true,
+		},
+		{
+			desc:    "extra only",
+			subject: v1alpha1.PolicySubject{Extra: map[string][]string{"team": {"sre"}}},
+			want:    true,
+		},
+		{
+			desc:    "no kind nor extra",
+			subject: v1alpha1.PolicySubject{Subject: rbacv1.Subject{Name: "jean-testor"}},
+			want:    false,
+		},
+		{
+			desc:    "unknown kind",
+			subject: v1alpha1.PolicySubject{Subject: rbacv1.Subject{Kind: "Robot", Name: "r2d2"}},
+			want:    false,
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.want, testCase.subject.IsMatchable())
+		})
+	}
+}
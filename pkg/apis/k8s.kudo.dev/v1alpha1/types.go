@@ -1,9 +1,12 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -14,31 +17,90 @@ import (
 const Version = "v1alpha1"
 
 const (
-	KindEscalation       = "Escalation"
-	KindEscalationPolicy = "EscalationPolicy"
+	KindEscalation                 = "Escalation"
+	KindEscalationPolicy           = "EscalationPolicy"
+	KindNamespacedEscalationPolicy = "NamespacedEscalationPolicy"
+	KindEscalationPolicyTemplate   = "EscalationPolicyTemplate"
 )
 
 const (
 	GrantKindK8sRoleBinding = "KubernetesRoleBinding"
+	GrantKindEKSAwsAuth     = "EKSAwsAuth"
 )
 
 // +genclient
-// +genclient:noStatus
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type EscalationPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec EscalationPolicySpec `json:"spec"`
+	Spec   EscalationPolicySpec   `json:"spec"`
+	Status EscalationPolicyStatus `json:"status,omitempty"`
 }
 
 type EscalationPolicySpec struct {
-	Subjects   []rbacv1.Subject      `json:"subjects"`
-	Challenges []EscalationChallenge `json:"challenges"`
-	Target     EscalationTarget      `json:"target"`
+	Subjects []PolicySubject `json:"subjects"`
+	// ExcludedSubjects are not allowed to use the policy, even if they match one of its subjects.
+	// Escalations granting permissions to beneficiaries are denied if some of the excluded subjects are not users.
+	ExcludedSubjects []PolicySubject       `json:"excludedSubjects,omitempty"`
+	Challenges       []EscalationChallenge `json:"challenges"`
+	Target           EscalationTarget      `json:"target"`
+	// Conditions are evaluated when an escalation is created, and again until it ends.
+	Conditions []PolicyCondition `json:"conditions,omitempty"`
+	// Delegation allows some subjects to create escalations on behalf of another requestor.
+	Delegation *EscalationDelegation `json:"delegation,omitempty"`
+	// TemplateRef is the escalation policy template the policy inherits from. The fields set by the policy override the template ones.
+	TemplateRef *PolicyTemplateRef `json:"templateRef,omitempty"`
+}
+
+// PolicyTemplateRef refers to the escalation policy template a policy inherits from.
+type PolicyTemplateRef struct {
+	Name string `json:"name"`
+	// Namespace replaces the default namespace and the allowed namespaces of the template KubernetesRoleBinding grants, if set.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// EscalationDelegation tells who can create escalations on behalf of another requestor, the beneficiary.
+// The beneficiary must still be part of the policy subjects.
+type EscalationDelegation struct {
+	Delegates []PolicySubject `json:"delegates"`
+	// RequireAcknowledgement makes delegated escalations wait for their beneficiary to acknowledge them.
+	// An acknowledgement is always required if the policy has subjects that aren't users, subjects with extra attributes, excluded subjects,
+	// or conditions.
+	RequireAcknowledgement bool `json:"requireAcknowledgement,omitempty"`
 }
 
+type ConditionAction string
+
+const (
+	// ConditionActionAllow allows an escalation. If a policy has allow conditions, one of them must be true.
+	ConditionActionAllow ConditionAction = "Allow"
+	// ConditionActionDeny denies an escalation.
+	ConditionActionDeny ConditionAction = "Deny"
+	// ConditionActionRequireChallenge makes an escalation wait for the approval of one of the policy challenges reviewers.
+	ConditionActionRequireChallenge ConditionAction = "RequireChallenge"
+)
+
+// PolicyCondition is a CEL expression evaluated over an escalation request, its action applies if it evaluates to true.
+type PolicyCondition struct {
+	Expression string          `json:"expression"`
+	Action     ConditionAction `json:"action"`
+	// Message is given to the requestor when the condition denies an escalation.
+	Message string `json:"message,omitempty"`
+}
+
+// PolicySubject is a RBAC subject, optionally restricted to users having some extra attributes.
+type PolicySubject struct {
+	rbacv1.Subject `json:",inline"`
+	// Extra restricts the subject to users having, for each key, one of the listed values in their extra attributes.
+	// A subject without kind matches any user having these attributes.
+	Extra map[string][]string `json:"extra,omitempty"`
+}
+
+// ChallengeKindPeerReview requires one of the challenge reviewers to approve the escalation.
+const ChallengeKindPeerReview = "PeerReview"
+
 type EscalationChallenge struct {
 	Kind      string           `json:"kind"`
 	Reviewers []rbacv1.Subject `json:"reviewers"`
@@ -48,12 +110,130 @@ type EscalationTarget struct {
 	DefaultDuration metav1.Duration `json:"defaultDuration"`
 	MaxDuration     metav1.Duration `json:"maxDuration"`
 	Grants          []ValueWithKind `json:"grants"`
+
+	// AtomicGrants makes kudo reclaim all the created grants as soon as one of them fails to be created.
+	// Otherwise, the created grants are kept in place while the failed ones are retried.
+	AtomicGrants bool `json:"atomicGrants,omitempty"`
+	// GrantFailurePolicy tells what to do with an atomic escalation once its grants have been reclaimed.
+	// Retry, the default, creates all the grants again on the next attempt, Deny denies the escalation.
+	// Only used when AtomicGrants is set.
+	GrantFailurePolicy GrantFailurePolicy `json:"grantFailurePolicy,omitempty"`
+	// OrderedGrants makes kudo create grants one after the other, in the order they are declared.
+	OrderedGrants bool `json:"orderedGrants,omitempty"`
+	// ExtensionsRequireApproval makes escalation extensions wait for the approval of one of the policy challenges reviewers.
+	ExtensionsRequireApproval bool `json:"extensionsRequireApproval,omitempty"`
+}
+
+// SelectGrants returns the grants matching the given selectors, in the order they are declared in the target.
+// A selector matches a grant by name, or by its index in the target. All grants are returned if there is no selector.
+func (t *EscalationTarget) SelectGrants(selectors []string) ([]ValueWithKind, error) {
+	if len(selectors) == 0 {
+		return t.Grants, nil
+	}
+
+	selected := make([]bool, len(t.Grants))
+
+	for _, selector := range selectors {
+		i, err := t.grantIndex(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		selected[i] = true
+	}
+
+	var grants []ValueWithKind
+
+	for i, grant := range t.Grants {
+		if selected[i] {
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+func (t *EscalationTarget) grantIndex(selector string) (int, error) {
+	for i, grant := range t.Grants {
+		commonSpec, err := DecodeValueWithKind[GrantCommonSpec](grant)
+		if err != nil {
+			return 0, err
+		}
+
+		if commonSpec.Name != "" && commonSpec.Name == selector {
+			return i, nil
+		}
+	}
+
+	i, err := strconv.Atoi(selector)
+	if err != nil || i < 0 || i >= len(t.Grants) {
+		return 0, fmt.Errorf("unknown grant %q", selector)
+	}
+
+	return i, nil
+}
+
+type GrantFailurePolicy string
+
+const (
+	GrantFailurePolicyUnknown GrantFailurePolicy = ""
+	GrantFailurePolicyRetry   GrantFailurePolicy = "Retry"
+	GrantFailurePolicyDeny    GrantFailurePolicy = "Deny"
+)
+
+// GrantCommonSpec holds the settings shared by all grant kinds.
+type GrantCommonSpec struct {
+	// Name identifies the grant in the policy, it allows escalations to select it.
+	Name string `json:"name,omitempty"`
+	// Duration is how long the grant lasts once the escalation is accepted.
+	// If not set, the grant lasts as long as the escalation.
+	Duration metav1.Duration `json:"duration,omitempty"`
 }
 
 type K8sRoleBindingGrant struct {
+	GrantCommonSpec `json:",inline"`
+
 	DefaultNamespace  string         `json:"defaultNamespace"`
 	AllowedNamespaces []string       `json:"allowedNamespaces"`
 	RoleRef           rbacv1.RoleRef `json:"roleRef"`
+	// NarrowableResources lists the resources escalations are allowed to restrict this grant to, by resource name.
+	NarrowableResources []NarrowableResource `json:"narrowableResources,omitempty"`
+}
+
+type NarrowableResource struct {
+	APIGroup string `json:"apiGroup"`
+	Resource string `json:"resource"`
+}
+
+const (
+	EKSAwsAuthMapUsers = "mapUsers"
+	EKSAwsAuthMapRoles = "mapRoles"
+)
+
+// EKSAwsAuthGrant maps an IAM user or role to the requestor in the EKS aws-auth ConfigMap.
+type EKSAwsAuthGrant struct {
+	GrantCommonSpec `json:",inline"`
+
+	// MapType is the aws-auth section to edit, either mapUsers or mapRoles.
+	MapType string   `json:"mapType"`
+	ARN     string   `json:"arn"`
+	Groups  []string `json:"groups"`
+}
+
+// EscalationPolicyStatus is maintained by the policy controller, it gives feedback to policy authors.
+type EscalationPolicyStatus struct {
+	// ObservedGeneration is the policy generation this status is based on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Valid is true if every grant of the policy is supported, and every role it binds exists.
+	Valid bool `json:"valid"`
+	// Problems lists the reasons why the policy is not valid.
+	Problems []string `json:"problems,omitempty"`
+	// ActiveEscalations is the amount of accepted escalations using this policy.
+	ActiveEscalations int `json:"activeEscalations"`
+	// PendingEscalations is the amount of pending or scheduled escalations using this policy.
+	PendingEscalations int `json:"pendingEscalations"`
+	// LastUsedAt is when the last escalation using this policy has been created.
+	LastUsedAt *metav1.Time `json:"lastUsedAt,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -64,6 +244,47 @@ type EscalationPolicyList struct {
 	Items []EscalationPolicy `json:"items"`
 }
 
+// EscalationPolicyTemplate carries the settings shared by several escalation policies.
+// A template can't inherit from another template.
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EscalationPolicyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EscalationPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EscalationPolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []EscalationPolicyTemplate `json:"items"`
+}
+
+// NamespacedEscalationPolicy is an escalation policy owned by a namespace.
+// Its grants are confined to its own namespace, and can only bind roles of this namespace or some allowed cluster roles.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespacedEscalationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EscalationPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespacedEscalationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NamespacedEscalationPolicy `json:"items"`
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -87,11 +308,98 @@ func (e *Escalation) AsOwnerRef() metav1.OwnerReference {
 }
 
 type EscalationSpec struct {
-	PolicyName string          `json:"policyName"`
-	Requestor  string          `json:"requestor"`
-	Reason     string          `json:"reason"`
-	Namespace  string          `json:"namespace"`
-	Duration   metav1.Duration `json:"duration"`
+	PolicyName string `json:"policyName"`
+	// PolicyNamespace is the namespace of the policy, if the escalation refers to a namespaced escalation policy.
+	PolicyNamespace string `json:"policyNamespace,omitempty"`
+	Requestor       string `json:"requestor"`
+	// RequestorInfo is the full identity of the requestor, set by the admission webhook at creation.
+	// The requestor eligibility is checked again against the policy subjects until the escalation ends.
+	RequestorInfo *authenticationv1.UserInfo `json:"requestorInfo,omitempty"`
+	Reason        string                     `json:"reason"`
+	Namespace     string                     `json:"namespace"`
+	Duration      metav1.Duration            `json:"duration"`
+	// StartAt delays the moment permissions are granted. The escalation starts as soon as it is accepted if empty.
+	StartAt *metav1.Time `json:"startAt,omitempty"`
+	// Grants selects a subset of the policy grants, by name or by index. All grants are selected if empty.
+	Grants []string `json:"grants,omitempty"`
+	// Resources restricts the escalation to specific objects, for grants that allow it.
+	Resources []EscalationResource `json:"resources,omitempty"`
+	// Extensions are requests for more time, made once the escalation is accepted.
+	Extensions []EscalationExtension `json:"extensions,omitempty"`
+	// Released is set by the requestor to end the escalation before it expires.
+	Released bool `json:"released,omitempty"`
+	// Revocation is set by an administrator to end the escalation before it expires.
+	Revocation *EscalationRevocation `json:"revocation,omitempty"`
+	// ApprovedBy is the reviewer that approved the escalation, when one of the policy conditions requires a challenge.
+	ApprovedBy string `json:"approvedBy,omitempty"`
+	// OnBehalfOf is set by a policy delegate to create the escalation for another user, the beneficiary.
+	// The beneficiary becomes the escalation requestor.
+	OnBehalfOf string `json:"onBehalfOf,omitempty"`
+	// Delegate is the full identity of the user that created the escalation on behalf of its requestor,
+	// set by the admission webhook at creation.
+	Delegate *authenticationv1.UserInfo `json:"delegate,omitempty"`
+	// Acknowledged is set by the requestor of a delegated escalation, to agree with it.
+	Acknowledged bool `json:"acknowledged,omitempty"`
+	// Beneficiaries are other users granted the escalation permissions along with the requestor, by username.
+	Beneficiaries []string `json:"beneficiaries,omitempty"`
+}
+
+// PolicyRef returns a printable reference to the escalation policy, prefixed by its namespace if it is namespaced.
+func (e *EscalationSpec) PolicyRef() string {
+	if e.PolicyNamespace == "" {
+		return e.PolicyName
+	}
+
+	return e.PolicyNamespace + "/" + e.PolicyName
+}
+
+// IsDelegated returns true if the escalation has been created by a delegate on behalf of its requestor.
+func (e *EscalationSpec) IsDelegated() bool {
+	return e.Delegate != nil
+}
+
+// EscalationRevocation records why and by whom an escalation has been revoked.
+type EscalationRevocation struct {
+	Reason string `json:"reason"`
+	// RevokedBy is set by the admission webhook to the user revoking the escalation.
+	RevokedBy string `json:"revokedBy,omitempty"`
+}
+
+// EscalationExtension is a request to push back the expiry of an accepted escalation.
+type EscalationExtension struct {
+	Duration  metav1.Duration `json:"duration"`
+	Reason    string          `json:"reason"`
+	Requestor string          `json:"requestor"`
+	// ApprovedBy is the reviewer that approved the extension, when the policy requires it.
+	ApprovedBy string `json:"approvedBy,omitempty"`
+}
+
+// EscalationResource is a specific object an escalation is restricted to.
+type EscalationResource struct {
+	APIGroup string `json:"apiGroup"`
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+}
+
+// PendingExtensions returns the extensions that are not applied yet.
+func (e *Escalation) PendingExtensions() []EscalationExtension {
+	if e.Status.AppliedExtensions >= len(e.Spec.Extensions) {
+		return nil
+	}
+
+	return e.Spec.Extensions[e.Status.AppliedExtensions:]
+}
+
+// MaxExpiresAt returns the time after which an accepted escalation can't be extended, given its policy.
+func (e *Escalation) MaxExpiresAt(policy *EscalationPolicy) time.Time {
+	maxExpiresAt := e.Status.AcceptedAt.Add(policy.Spec.Target.MaxDuration.Duration)
+
+	// Never shorten an escalation.
+	if maxExpiresAt.Before(e.Status.ExpiresAt.Time) {
+		return e.Status.ExpiresAt.Time
+	}
+
+	return maxExpiresAt
 }
 
 func (e *EscalationSpec) IsValid() bool {
@@ -105,11 +413,14 @@ func notBlank(v string) bool { return strings.TrimSpace(v) != "" }
 type EscalationState string
 
 const (
-	StateUnknown  EscalationState = ""
-	StatePending  EscalationState = "PENDING"
-	StateDenied   EscalationState = "DENIED"
-	StateAccepted EscalationState = "ACCEPTED"
-	StateExpired  EscalationState = "EXPIRED"
+	StateUnknown   EscalationState = ""
+	StatePending   EscalationState = "PENDING"
+	StateScheduled EscalationState = "SCHEDULED"
+	StateDenied    EscalationState = "DENIED"
+	StateAccepted  EscalationState = "ACCEPTED"
+	StateExpired   EscalationState = "EXPIRED"
+	StateReleased  EscalationState = "RELEASED"
+	StateRevoked   EscalationState = "REVOKED"
 )
 
 type EscalationStatus struct {
@@ -117,8 +428,38 @@ type EscalationStatus struct {
 	StateDetails  string               `json:"stateDetails"`
 	PolicyUID     types.UID            `json:"policyUid"`
 	PolicyVersion string               `json:"policyVersion"`
+	AcceptedAt    metav1.Time          `json:"acceptedAt"`
 	ExpiresAt     metav1.Time          `json:"expiresAt"`
 	GrantRefs     []EscalationGrantRef `json:"grantRefs"`
+	// AppliedExtensions is the amount of spec extensions already taken into account in ExpiresAt.
+	AppliedExtensions int `json:"appliedExtensions,omitempty"`
+	// FinishedAt is when the escalation has reached a terminal state.
+	FinishedAt metav1.Time `json:"finishedAt"`
+	// ArchivedAt is when the escalation has been archived once its retention is over, right before being deleted.
+	// It is set even without any archiver, so the deletion of the escalation isn't reported.
+	ArchivedAt metav1.Time `json:"archivedAt"`
+	// PolicySnapshot is a copy of the policy spec taken when the escalation became pending, it is never updated.
+	// Its target only holds the grants selected by the escalation, and grants are created from it.
+	PolicySnapshot *EscalationPolicySpec `json:"policySnapshot,omitempty"`
+	// CurrentGrants holds the definition of the escalation grants once a policy change has redefined some of them.
+	// When set, grants are created from it instead of the policy snapshot.
+	CurrentGrants []ValueWithKind `json:"currentGrants,omitempty"`
+	// ObservedGeneration is the escalation generation this status is based on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the escalation progress in a standard way, they're derived from the state on each transition.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// History lists the last transitions of the escalation, oldest first. It holds at most MaxHistoryLength entries.
+	History []EscalationTransition `json:"history,omitempty"`
+}
+
+// IsTerminal returns true if the escalation is over, and won't grant anything anymore.
+func (e *EscalationStatus) IsTerminal() bool {
+	switch e.State {
+	case StateDenied, StateExpired, StateReleased, StateRevoked:
+		return true
+	default:
+		return false
+	}
 }
 
 func (e *EscalationStatus) AllGrantsInStatus(wantStatus GrantStatus) bool {
@@ -156,12 +497,36 @@ func WithPolicyInfo(uid types.UID, version string) TransitionMutation {
 	}
 }
 
+func WithPolicySnapshot(spec *EscalationPolicySpec) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.PolicySnapshot = spec
+	}
+}
+
+func WithCurrentGrants(grants []ValueWithKind) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.CurrentGrants = grants
+	}
+}
+
+func WithAcceptedAt(t time.Time) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.AcceptedAt = metav1.Time{Time: t}
+	}
+}
+
 func WithExpiresAt(t time.Time) TransitionMutation {
 	return func(st *EscalationStatus) {
 		st.ExpiresAt = metav1.Time{Time: t}
 	}
 }
 
+func WithAppliedExtensions(count int) TransitionMutation {
+	return func(st *EscalationStatus) {
+		st.AppliedExtensions = count
+	}
+}
+
 func (e *EscalationStatus) TransitionTo(state EscalationState, mutations ...TransitionMutation) EscalationStatus {
 	newStatus := EscalationStatus{
 		State:         state,
@@ -169,16 +534,58 @@ func (e *EscalationStatus) TransitionTo(state EscalationState, mutations ...Tran
 		GrantRefs:     e.GrantRefs,
 		PolicyUID:     e.PolicyUID,
 		PolicyVersion: e.PolicyVersion,
+		AcceptedAt:    e.AcceptedAt,
 		ExpiresAt:     e.ExpiresAt,
+
+		AppliedExtensions: e.AppliedExtensions,
+		FinishedAt:        e.FinishedAt,
+		ArchivedAt:        e.ArchivedAt,
+		PolicySnapshot:    e.PolicySnapshot,
+		CurrentGrants:     e.CurrentGrants,
+
+		ObservedGeneration: e.ObservedGeneration,
+		Conditions:         append([]metav1.Condition(nil), e.Conditions...),
+		History:            append([]EscalationTransition(nil), e.History...),
+	}
+
+	if state != e.State {
+		newStatus.History = append(
+			newStatus.History,
+			EscalationTransition{From: e.State, To: state, Actor: ControllerActor},
+		)
 	}
 
+	newStatus.resetEventConditions()
+
 	for _, mut := range mutations {
 		mut(&newStatus)
 	}
 
+	newStatus.setStateConditions()
+	newStatus.completeHistory()
+
 	return newStatus
 }
 
+// MarkObserved records the escalation generation the status is based on, and the time of the transitions not recorded yet.
+func (e *EscalationStatus) MarkObserved(generation int64, now time.Time) {
+	e.ObservedGeneration = generation
+
+	for i := range e.Conditions {
+		e.Conditions[i].ObservedGeneration = generation
+
+		if e.Conditions[i].LastTransitionTime.IsZero() {
+			e.Conditions[i].LastTransitionTime = metav1.Time{Time: now}
+		}
+	}
+
+	for i := range e.History {
+		if e.History[i].At.IsZero() {
+			e.History[i].At = metav1.Time{Time: now}
+		}
+	}
+}
+
 type GrantStatus string
 
 const (
@@ -190,6 +597,13 @@ const (
 type EscalationGrantRef struct {
 	Status GrantStatus   `json:"status"`
 	Ref    ValueWithKind `json:"ref"`
+	// ExpiresAt is set when the grant expires before the escalation.
+	ExpiresAt metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// IsExpired returns true if the grant has its own expiry time, and if it is passed.
+func (r *EscalationGrantRef) IsExpired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt.Time)
 }
 
 type K8sRoleBindingGrantRef struct {
@@ -197,6 +611,16 @@ type K8sRoleBindingGrantRef struct {
 	Namespace       string    `json:"namespace"`
 	UID             types.UID `json:"uid"`
 	ResourceVersion string    `json:"resourceVersion"`
+	// RoleName is the name of the role generated by kudo when the escalation is restricted to specific objects.
+	RoleName string    `json:"roleName,omitempty"`
+	RoleUID  types.UID `json:"roleUid,omitempty"`
+}
+
+type EKSAwsAuthGrantRef struct {
+	MapType  string   `json:"mapType"`
+	ARN      string   `json:"arn"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,391 @@
+package v1alpha1_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+)
+
+func TestEscalationTarget_SelectGrants(t *testing.T) {
+	var (
+		viewGrant = v1alpha1.MustEncodeValueWithKind(
+			v1alpha1.GrantKindK8sRoleBinding,
+			v1alpha1.K8sRoleBindingGrant{
+				GrantCommonSpec: v1alpha1.GrantCommonSpec{Name: "view"},
+			},
+		)
+		editGrant = v1alpha1.MustEncodeValueWithKind(
+			v1alpha1.GrantKindK8sRoleBinding,
+			v1alpha1.K8sRoleBindingGrant{
+				GrantCommonSpec: v1alpha1.GrantCommonSpec{Name: "edit"},
+			},
+		)
+		unnamedGrant = v1alpha1.MustEncodeValueWithKind(
+			v1alpha1.GrantKindK8sRoleBinding,
+			v1alpha1.K8sRoleBindingGrant{},
+		)
+		target = v1alpha1.EscalationTarget{
+			Grants: []v1alpha1.ValueWithKind{viewGrant, editGrant, unnamedGrant},
+		}
+	)
+
+	testCases := []struct {
+		desc       string
+		selectors  []string
+		wantGrants []v1alpha1.ValueWithKind
+		wantErr    error
+	}{
+		{
+			desc:       "selects all grants if no selectors",
+			wantGrants: []v1alpha1.ValueWithKind{viewGrant, editGrant, unnamedGrant},
+		},
+		{
+			desc:       "selects grants by name",
+			selectors:  []string{"view"},
+			wantGrants: []v1alpha1.ValueWithKind{viewGrant},
+		},
+		{
+			desc:       "selects grants by index",
+			selectors:  []string{"2"},
+			wantGrants: []v1alpha1.ValueWithKind{unnamedGrant},
+		},
+		{
+			desc:       "keeps the target order and ignores duplicates",
+			selectors:  []string{"2", "view", "0"},
+			wantGrants: []v1alpha1.ValueWithKind{viewGrant, unnamedGrant},
+		},
+		{
+			desc:      "raises an error on unknown name",
+			selectors: []string{"admin"},
+			wantErr:   errors.New(`unknown grant "admin"`),
+		},
+		{
+			desc:      "raises an error on out of range index",
+			selectors: []string{"3"},
+			wantErr:   errors.New(`unknown grant "3"`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotGrants, err := target.SelectGrants(testCase.selectors)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.wantGrants, gotGrants)
+		})
+	}
+}
+
+func TestEscalationStatus_TransitionTo_Conditions(t *testing.T) {
+	var (
+		lastTransition = metav1.Date(2022, time.October, 10, 1, 30, 1, 0, time.UTC)
+		createdRef     = v1alpha1.EscalationGrantRef{Status: v1alpha1.GrantStatusCreated}
+		reclaimedRef   = v1alpha1.EscalationGrantRef{Status: v1alpha1.GrantStatusReclaimed}
+		expiredRef     = v1alpha1.EscalationGrantRef{Status: v1alpha1.GrantStatusReclaimed, ExpiresAt: lastTransition}
+		observed       = func(status v1alpha1.EscalationStatus) v1alpha1.EscalationStatus {
+			status.MarkObserved(1, lastTransition.Time)
+			return status
+		}
+		acceptedStatus = observed(
+			(&v1alpha1.EscalationStatus{}).TransitionTo(
+				v1alpha1.StateAccepted,
+				v1alpha1.WithNewGrantRefs([]v1alpha1.EscalationGrantRef{createdRef}),
+			),
+		)
+	)
+
+	testCases := []struct {
+		desc       string
+		status     v1alpha1.EscalationStatus
+		state      v1alpha1.EscalationState
+		mutations  []v1alpha1.TransitionMutation
+		wantStates map[string]string
+	}{
+		{
+			desc:  "pending escalation",
+			state: v1alpha1.StatePending,
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "False/Pending",
+				v1alpha1.ConditionGranted:       "False/Pending",
+				v1alpha1.ConditionExpired:       "False/Pending",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+		{
+			desc:      "accepted escalation with all grants created",
+			state:     v1alpha1.StateAccepted,
+			mutations: []v1alpha1.TransitionMutation{v1alpha1.WithNewGrantRefs([]v1alpha1.EscalationGrantRef{createdRef, expiredRef})},
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "True/Granted",
+				v1alpha1.ConditionExpired:       "False/Accepted",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+		{
+			desc:      "accepted escalation with grants not created yet",
+			state:     v1alpha1.StateAccepted,
+			mutations: []v1alpha1.TransitionMutation{v1alpha1.WithNewGrantRefs([]v1alpha1.EscalationGrantRef{createdRef, reclaimedRef})},
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "False/GrantsPending",
+				v1alpha1.ConditionExpired:       "False/Accepted",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+		{
+			desc:   "expired escalation failing to reclaim its grants",
+			status: acceptedStatus,
+			state:  v1alpha1.StateExpired,
+			mutations: []v1alpha1.TransitionMutation{
+				v1alpha1.WithReclaimFailure(errors.New("boom")),
+			},
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "False/Expired",
+				v1alpha1.ConditionExpired:       "True/Expired",
+				v1alpha1.ConditionReclaimFailed: "True/ReclaimFailed",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+		{
+			desc: "reclaim failure is cleared on next transition",
+			status: observed(
+				acceptedStatus.TransitionTo(
+					v1alpha1.StateExpired,
+					v1alpha1.WithReclaimFailure(errors.New("boom")),
+				),
+			),
+			state: v1alpha1.StateExpired,
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "False/Expired",
+				v1alpha1.ConditionExpired:       "True/Expired",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+		{
+			desc:   "denied escalation after tampering",
+			status: acceptedStatus,
+			state:  v1alpha1.StateDenied,
+			mutations: []v1alpha1.TransitionMutation{
+				v1alpha1.WithTampering(errors.New("tampered")),
+			},
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "False/Denied",
+				v1alpha1.ConditionExpired:       "False/Denied",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "True/Tampered",
+			},
+		},
+		{
+			desc: "tampering is reported forever",
+			status: observed(
+				acceptedStatus.TransitionTo(
+					v1alpha1.StateDenied,
+					v1alpha1.WithTampering(errors.New("tampered")),
+				),
+			),
+			state: v1alpha1.StateDenied,
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "True/Accepted",
+				v1alpha1.ConditionGranted:       "False/Denied",
+				v1alpha1.ConditionExpired:       "False/Denied",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "True/Tampered",
+			},
+		},
+		{
+			desc:  "escalation denied before its approval",
+			state: v1alpha1.StateDenied,
+			wantStates: map[string]string{
+				v1alpha1.ConditionApproved:      "False/Denied",
+				v1alpha1.ConditionGranted:       "False/Denied",
+				v1alpha1.ConditionExpired:       "False/Denied",
+				v1alpha1.ConditionReclaimFailed: "False/NoFailure",
+				v1alpha1.ConditionTampered:      "False/NotTampered",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			gotStatus := testCase.status.TransitionTo(testCase.state, testCase.mutations...)
+
+			require.Len(t, gotStatus.Conditions, len(testCase.wantStates))
+
+			for i, conditionType := range []string{
+				v1alpha1.ConditionApproved,
+				v1alpha1.ConditionGranted,
+				v1alpha1.ConditionExpired,
+				v1alpha1.ConditionReclaimFailed,
+				v1alpha1.ConditionTampered,
+			} {
+				gotCondition := gotStatus.Conditions[i]
+
+				assert.Equal(t, conditionType, gotCondition.Type)
+				assert.Equal(t, testCase.wantStates[conditionType], string(gotCondition.Status)+"/"+gotCondition.Reason)
+
+				// Transition time is kept as long as the condition status does not change.
+				if previous := findCondition(testCase.status.Conditions, conditionType); previous != nil && previous.Status == gotCondition.Status {
+					assert.Equal(t, previous.LastTransitionTime, gotCondition.LastTransitionTime)
+				} else {
+					assert.True(t, gotCondition.LastTransitionTime.IsZero())
+				}
+			}
+
+			// Transitions never alter the previous status.
+			if previous := findCondition(testCase.status.Conditions, v1alpha1.ConditionReclaimFailed); previous != nil {
+				assert.False(t, previous.LastTransitionTime.IsZero())
+			}
+		})
+	}
+}
+
+func TestEscalationStatus_MarkObserved(t *testing.T) {
+	var (
+		now            = time.Date(2022, time.October, 10, 1, 30, 1, 0, time.UTC)
+		lastTransition = metav1.NewTime(now.Add(-time.Hour))
+		status         = v1alpha1.EscalationStatus{
+			Conditions: []metav1.Condition{
+				{Type: v1alpha1.ConditionApproved, Status: metav1.ConditionTrue, LastTransitionTime: lastTransition},
+				{Type: v1alpha1.ConditionGranted, Status: metav1.ConditionTrue},
+			},
+		}
+	)
+
+	status.MarkObserved(4, now)
+
+	assert.Equal(t, int64(4), status.ObservedGeneration)
+	assert.Equal(
+		t,
+		[]metav1.Condition{
+			{Type: v1alpha1.ConditionApproved, Status: metav1.ConditionTrue, LastTransitionTime: lastTransition, ObservedGeneration: 4},
+			{Type: v1alpha1.ConditionGranted, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now), ObservedGeneration: 4},
+		},
+		status.Conditions,
+	)
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func TestEscalationStatus_TransitionTo_History(t *testing.T) {
+	var (
+		recordedAt = metav1.Date(2022, time.October, 10, 1, 30, 1, 0, time.UTC)
+		admin      = v1alpha1.EscalationActor{Kind: v1alpha1.ActorKindAdmin, Name: "admin"}
+		pending    = v1alpha1.EscalationTransition{
+			To:      v1alpha1.StatePending,
+			At:      recordedAt,
+			Actor:   v1alpha1.ControllerActor,
+			Details: "pending",
+		}
+		pendingStatus = v1alpha1.EscalationStatus{
+			State:        v1alpha1.StatePending,
+			StateDetails: "pending",
+			History:      []v1alpha1.EscalationTransition{pending},
+		}
+	)
+
+	testCases := []struct {
+		desc        string
+		status      v1alpha1.EscalationStatus
+		state       v1alpha1.EscalationState
+		mutations   []v1alpha1.TransitionMutation
+		wantHistory []v1alpha1.EscalationTransition
+	}{
+		{
+			desc:      "records state changes made by the controller",
+			status:    pendingStatus,
+			state:     v1alpha1.StateAccepted,
+			mutations: []v1alpha1.TransitionMutation{v1alpha1.WithDetails("accepted")},
+			wantHistory: []v1alpha1.EscalationTransition{
+				pending,
+				{From: v1alpha1.StatePending, To: v1alpha1.StateAccepted, Actor: v1alpha1.ControllerActor, Details: "accepted"},
+			},
+		},
+		{
+			desc:      "records state changes made by someone else",
+			status:    pendingStatus,
+			state:     v1alpha1.StateRevoked,
+			mutations: []v1alpha1.TransitionMutation{v1alpha1.WithDetails("revoked"), v1alpha1.WithActor(admin)},
+			wantHistory: []v1alpha1.EscalationTransition{
+				pending,
+				{From: v1alpha1.StatePending, To: v1alpha1.StateRevoked, Actor: admin, Details: "revoked"},
+			},
+		},
+		{
+			desc:      "records changes that keep the state if they have an actor",
+			status:    pendingStatus,
+			state:     v1alpha1.StatePending,
+			mutations: []v1alpha1.TransitionMutation{v1alpha1.WithDetails("extended"), v1alpha1.WithActor(admin)},
+			wantHistory: []v1alpha1.EscalationTransition{
+				pending,
+				{From: v1alpha1.StatePending, To: v1alpha1.StatePending, Actor: admin, Details: "extended"},
+			},
+		},
+		{
+			desc:        "does not record changes that keep the state without an actor",
+			status:      pendingStatus,
+			state:       v1alpha1.StatePending,
+			mutations:   []v1alpha1.TransitionMutation{v1alpha1.WithDetails("still pending")},
+			wantHistory: []v1alpha1.EscalationTransition{pending},
+		},
+		{
+			desc: "drops the oldest transitions",
+			status: func() v1alpha1.EscalationStatus {
+				status := pendingStatus
+
+				status.History = nil
+				for i := 0; i < v1alpha1.MaxHistoryLength; i++ {
+					status.History = append(status.History, pending)
+				}
+
+				status.History[0].Details = "oldest"
+
+				return status
+			}(),
+			state: v1alpha1.StateAccepted,
+			wantHistory: func() []v1alpha1.EscalationTransition {
+				var history []v1alpha1.EscalationTransition
+
+				for i := 1; i < v1alpha1.MaxHistoryLength; i++ {
+					history = append(history, pending)
+				}
+
+				return append(
+					history,
+					v1alpha1.EscalationTransition{From: v1alpha1.StatePending, To: v1alpha1.StateAccepted, Actor: v1alpha1.ControllerActor, Details: "pending"},
+				)
+			}(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			previousHistory := append([]v1alpha1.EscalationTransition(nil), testCase.status.History...)
+
+			gotStatus := testCase.status.TransitionTo(testCase.state, testCase.mutations...)
+
+			assert.Equal(t, testCase.wantHistory, gotStatus.History)
+			assert.Equal(t, previousHistory, testCase.status.History)
+		})
+	}
+}
@@ -31,6 +31,11 @@ func (a ValueWithKind) MarshalJSON() ([]byte, error) {
 		return nil, errors.New("unexpected marshal of an empty payload")
 	}
 
+	// Values without any attribute only carry their kind.
+	if string(a.payload) == "{}" {
+		return []byte(`{"kind":"` + a.Kind + `"}`), nil
+	}
+
 	// I'm going to regret this.
 	return append([]byte(`{"kind":"`+a.Kind+`",`), a.payload[1:]...), nil
 }
@@ -43,3 +43,13 @@ func TestValueWithKind_EncodeFailsOnArrays(t *testing.T) {
 	_, err := v1alpha1.EncodeValueWithKind("wrong", []int{1, 2, 3, 4})
 	require.Error(t, err)
 }
+
+func TestValueWithKind_EncodeEmptyValue(t *testing.T) {
+	encValue, err := v1alpha1.EncodeValueWithKind("something", struct{}{})
+	require.NoError(t, err)
+
+	jsonBytes, err := json.Marshal(encValue)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"kind":"something"}`, string(jsonBytes))
+}
@@ -6,16 +6,61 @@
 package v1alpha1
 
 import (
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EKSAwsAuthGrant) DeepCopyInto(out *EKSAwsAuthGrant) {
+	*out = *in
+	out.GrantCommonSpec = in.GrantCommonSpec
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSAwsAuthGrant.
+func (in *EKSAwsAuthGrant) DeepCopy() *EKSAwsAuthGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(EKSAwsAuthGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EKSAwsAuthGrantRef) DeepCopyInto(out *EKSAwsAuthGrantRef) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSAwsAuthGrantRef.
+func (in *EKSAwsAuthGrantRef) DeepCopy() *EKSAwsAuthGrantRef {
+	if in == nil {
+		return nil
+	}
+	out := new(EKSAwsAuthGrantRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Escalation) DeepCopyInto(out *Escalation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -38,6 +83,22 @@ func (in *Escalation) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationActor) DeepCopyInto(out *EscalationActor) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationActor.
+func (in *EscalationActor) DeepCopy() *EscalationActor {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationActor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalationChallenge) DeepCopyInto(out *EscalationChallenge) {
 	*out = *in
@@ -59,10 +120,51 @@ func (in *EscalationChallenge) DeepCopy() *EscalationChallenge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationDelegation) DeepCopyInto(out *EscalationDelegation) {
+	*out = *in
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]PolicySubject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationDelegation.
+func (in *EscalationDelegation) DeepCopy() *EscalationDelegation {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationDelegation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationExtension) DeepCopyInto(out *EscalationExtension) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationExtension.
+func (in *EscalationExtension) DeepCopy() *EscalationExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalationGrantRef) DeepCopyInto(out *EscalationGrantRef) {
 	*out = *in
 	in.Ref.DeepCopyInto(&out.Ref)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
 	return
 }
 
@@ -115,6 +217,7 @@ func (in *EscalationPolicy) DeepCopyInto(out *EscalationPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -174,8 +277,17 @@ func (in *EscalationPolicySpec) DeepCopyInto(out *EscalationPolicySpec) {
 	*out = *in
 	if in.Subjects != nil {
 		in, out := &in.Subjects, &out.Subjects
-		*out = make([]v1.Subject, len(*in))
-		copy(*out, *in)
+		*out = make([]PolicySubject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedSubjects != nil {
+		in, out := &in.ExcludedSubjects, &out.ExcludedSubjects
+		*out = make([]PolicySubject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Challenges != nil {
 		in, out := &in.Challenges, &out.Challenges
@@ -185,6 +297,21 @@ func (in *EscalationPolicySpec) DeepCopyInto(out *EscalationPolicySpec) {
 		}
 	}
 	in.Target.DeepCopyInto(&out.Target)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PolicyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delegation != nil {
+		in, out := &in.Delegation, &out.Delegation
+		*out = new(EscalationDelegation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(PolicyTemplateRef)
+		**out = **in
+	}
 	return
 }
 
@@ -198,10 +325,166 @@ func (in *EscalationPolicySpec) DeepCopy() *EscalationPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationPolicyStatus) DeepCopyInto(out *EscalationPolicyStatus) {
+	*out = *in
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUsedAt != nil {
+		in, out := &in.LastUsedAt, &out.LastUsedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationPolicyStatus.
+func (in *EscalationPolicyStatus) DeepCopy() *EscalationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationPolicyTemplate) DeepCopyInto(out *EscalationPolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationPolicyTemplate.
+func (in *EscalationPolicyTemplate) DeepCopy() *EscalationPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EscalationPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationPolicyTemplateList) DeepCopyInto(out *EscalationPolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EscalationPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationPolicyTemplateList.
+func (in *EscalationPolicyTemplateList) DeepCopy() *EscalationPolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationPolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EscalationPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationResource) DeepCopyInto(out *EscalationResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationResource.
+func (in *EscalationResource) DeepCopy() *EscalationResource {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationRevocation) DeepCopyInto(out *EscalationRevocation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationRevocation.
+func (in *EscalationRevocation) DeepCopy() *EscalationRevocation {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationRevocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalationSpec) DeepCopyInto(out *EscalationSpec) {
 	*out = *in
+	if in.RequestorInfo != nil {
+		in, out := &in.RequestorInfo, &out.RequestorInfo
+		*out = new(authenticationv1.UserInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Duration = in.Duration
+	if in.StartAt != nil {
+		in, out := &in.StartAt, &out.StartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]EscalationResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]EscalationExtension, len(*in))
+		copy(*out, *in)
+	}
+	if in.Revocation != nil {
+		in, out := &in.Revocation, &out.Revocation
+		*out = new(EscalationRevocation)
+		**out = **in
+	}
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(authenticationv1.UserInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Beneficiaries != nil {
+		in, out := &in.Beneficiaries, &out.Beneficiaries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -218,6 +501,7 @@ func (in *EscalationSpec) DeepCopy() *EscalationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalationStatus) DeepCopyInto(out *EscalationStatus) {
 	*out = *in
+	in.AcceptedAt.DeepCopyInto(&out.AcceptedAt)
 	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
 	if in.GrantRefs != nil {
 		in, out := &in.GrantRefs, &out.GrantRefs
@@ -226,6 +510,34 @@ func (in *EscalationStatus) DeepCopyInto(out *EscalationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	in.ArchivedAt.DeepCopyInto(&out.ArchivedAt)
+	if in.PolicySnapshot != nil {
+		in, out := &in.PolicySnapshot, &out.PolicySnapshot
+		*out = new(EscalationPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CurrentGrants != nil {
+		in, out := &in.CurrentGrants, &out.CurrentGrants
+		*out = make([]ValueWithKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]EscalationTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -264,15 +576,56 @@ func (in *EscalationTarget) DeepCopy() *EscalationTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationTransition) DeepCopyInto(out *EscalationTransition) {
+	*out = *in
+	in.At.DeepCopyInto(&out.At)
+	out.Actor = in.Actor
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationTransition.
+func (in *EscalationTransition) DeepCopy() *EscalationTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantCommonSpec) DeepCopyInto(out *GrantCommonSpec) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantCommonSpec.
+func (in *GrantCommonSpec) DeepCopy() *GrantCommonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantCommonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8sRoleBindingGrant) DeepCopyInto(out *K8sRoleBindingGrant) {
 	*out = *in
+	out.GrantCommonSpec = in.GrantCommonSpec
 	if in.AllowedNamespaces != nil {
 		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	out.RoleRef = in.RoleRef
+	if in.NarrowableResources != nil {
+		in, out := &in.NarrowableResources, &out.NarrowableResources
+		*out = make([]NarrowableResource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -302,6 +655,146 @@ func (in *K8sRoleBindingGrantRef) DeepCopy() *K8sRoleBindingGrantRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedEscalationPolicy) DeepCopyInto(out *NamespacedEscalationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedEscalationPolicy.
+func (in *NamespacedEscalationPolicy) DeepCopy() *NamespacedEscalationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedEscalationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedEscalationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedEscalationPolicyList) DeepCopyInto(out *NamespacedEscalationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedEscalationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedEscalationPolicyList.
+func (in *NamespacedEscalationPolicyList) DeepCopy() *NamespacedEscalationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedEscalationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedEscalationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NarrowableResource) DeepCopyInto(out *NarrowableResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NarrowableResource.
+func (in *NarrowableResource) DeepCopy() *NarrowableResource {
+	if in == nil {
+		return nil
+	}
+	out := new(NarrowableResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyCondition) DeepCopyInto(out *PolicyCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyCondition.
+func (in *PolicyCondition) DeepCopy() *PolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySubject) DeepCopyInto(out *PolicySubject) {
+	*out = *in
+	out.Subject = in.Subject
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySubject.
+func (in *PolicySubject) DeepCopy() *PolicySubject {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTemplateRef) DeepCopyInto(out *PolicyTemplateRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTemplateRef.
+func (in *PolicyTemplateRef) DeepCopy() *PolicyTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueWithKind) DeepCopyInto(out *ValueWithKind) {
 	*out = *in
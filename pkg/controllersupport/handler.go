@@ -36,12 +36,33 @@ type QueuedEventHandler[T any] struct {
 	name      string
 	workers   int
 
+	// Events about objects considered as priority are handled by dedicated workers,
+	// so they don't wait behind the regular events.
+	priorityQueue   workqueue.RateLimitingInterface
+	priorityWorkers int
+	isPriority      func(*T) bool
+
 	handler EventHandler[T]
 }
 
+type QueuedEventHandlerOpt[T any] func(h *QueuedEventHandler[T])
+
+// WithPriorityQueue makes the handler process events about objects matching isPriority
+// in a separate queue, with its own workers.
+func WithPriorityQueue[T any](isPriority func(*T) bool, workers int) QueuedEventHandlerOpt[T] {
+	return func(h *QueuedEventHandler[T]) {
+		h.priorityQueue = workqueue.NewNamedRateLimitingQueue(
+			workqueue.DefaultControllerRateLimiter(),
+			h.name+"-priority",
+		)
+		h.priorityWorkers = workers
+		h.isPriority = isPriority
+	}
+}
+
 // NewQueuedEventHandler returns a queued event handler
-func NewQueuedEventHandler[T any](handler EventHandler[T], name string, workers int) *QueuedEventHandler[T] {
-	return &QueuedEventHandler[T]{
+func NewQueuedEventHandler[T any](handler EventHandler[T], name string, workers int, opts ...QueuedEventHandlerOpt[T]) *QueuedEventHandler[T] {
+	h := QueuedEventHandler[T]{
 		workqueue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.DefaultControllerRateLimiter(),
 			name,
@@ -51,21 +72,27 @@ func NewQueuedEventHandler[T any](handler EventHandler[T], name string, workers
 		workers: workers,
 		name:    name,
 	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	return &h
 }
 
 // OnAdd enqueues an add event.
 func (h *QueuedEventHandler[T]) OnAdd(obj any) {
-	h.workqueue.Add(queueEvent{kind: kindAdd, object: obj})
+	h.queueFor(obj).Add(queueEvent{kind: kindAdd, object: obj})
 }
 
 // OnUpdate enqueues an update event.
 func (h *QueuedEventHandler[T]) OnUpdate(oldObj, newObj any) {
-	h.workqueue.Add(queueEvent{kind: kindUpdate, oldObj: oldObj, newObj: newObj})
+	h.queueFor(newObj).Add(queueEvent{kind: kindUpdate, oldObj: oldObj, newObj: newObj})
 }
 
 // OnDelete enqueues an update event.
 func (h *QueuedEventHandler[T]) OnDelete(obj any) {
-	h.workqueue.Add(queueEvent{kind: kindDelete, object: obj})
+	h.queueFor(obj).Add(queueEvent{kind: kindDelete, object: obj})
 }
 
 // Run starts workers and waits until completion.
@@ -76,7 +103,17 @@ func (h *QueuedEventHandler[T]) Run(ctx context.Context) {
 	klog.InfoS("Starting workers for handler", "name", h.name, "total", h.workers)
 
 	for i := 0; i < h.workers; i++ {
-		go wait.UntilWithContext(ctx, h.runWorker, time.Second)
+		go wait.UntilWithContext(ctx, h.runWorker(h.workqueue), time.Second)
+	}
+
+	if h.priorityQueue != nil {
+		defer h.priorityQueue.ShutDown()
+
+		klog.InfoS("Starting priority workers for handler", "name", h.name, "total", h.priorityWorkers)
+
+		for i := 0; i < h.priorityWorkers; i++ {
+			go wait.UntilWithContext(ctx, h.runWorker(h.priorityQueue), time.Second)
+		}
 	}
 
 	klog.InfoS("Started workers for handler", "name", h.name)
@@ -86,24 +123,39 @@ func (h *QueuedEventHandler[T]) Run(ctx context.Context) {
 	klog.InfoS("Shutting down workers for handler", "name", h.name)
 }
 
-func (h *QueuedEventHandler[T]) runWorker(ctx context.Context) {
-	for h.processItem(ctx) {
+func (h *QueuedEventHandler[T]) queueFor(obj any) workqueue.RateLimitingInterface {
+	if h.priorityQueue == nil {
+		return h.workqueue
+	}
+
+	typedObject, ok := obj.(*T)
+	if !ok || typedObject == nil || !h.isPriority(typedObject) {
+		return h.workqueue
+	}
+
+	return h.priorityQueue
+}
+
+func (h *QueuedEventHandler[T]) runWorker(queue workqueue.RateLimitingInterface) func(context.Context) {
+	return func(ctx context.Context) {
+		for h.processItem(ctx, queue) {
+		}
 	}
 }
 
-func (h *QueuedEventHandler[T]) processItem(ctx context.Context) bool {
-	obj, shutdown := h.workqueue.Get()
+func (h *QueuedEventHandler[T]) processItem(ctx context.Context, queue workqueue.RateLimitingInterface) bool {
+	obj, shutdown := queue.Get()
 
 	if shutdown {
 		return false
 	}
 
-	defer h.workqueue.Done(obj)
+	defer queue.Done(obj)
 
 	event, ok := obj.(queueEvent)
 
 	if !ok {
-		h.workqueue.Forget(obj)
+		queue.Forget(obj)
 		return true
 	}
 
@@ -116,7 +168,7 @@ func (h *QueuedEventHandler[T]) processItem(ctx context.Context) bool {
 	case kindAdd:
 		typedObject, ok := event.object.(*T)
 		if !ok {
-			h.workqueue.Forget(obj)
+			queue.Forget(obj)
 			return true
 		}
 
@@ -127,7 +179,7 @@ func (h *QueuedEventHandler[T]) processItem(ctx context.Context) bool {
 		typedNewObject, okNew := event.newObj.(*T)
 
 		if !okOld || !okNew {
-			h.workqueue.Forget(obj)
+			queue.Forget(obj)
 			return true
 		}
 
@@ -136,20 +188,20 @@ func (h *QueuedEventHandler[T]) processItem(ctx context.Context) bool {
 	case kindDelete:
 		typedObject, ok := event.object.(*T)
 		if !ok {
-			h.workqueue.Forget(obj)
+			queue.Forget(obj)
 			return true
 		}
 
 		insight, err = h.handler.OnDelete(ctx, typedObject)
 
 	default:
-		h.workqueue.Forget(obj)
+		queue.Forget(obj)
 		return true
 	}
 
 	if errors.Is(err, ErrTransientError) {
 		klog.ErrorS(err, "handler reported a transient error, requeuing...", "name", h.name)
-		h.workqueue.AddRateLimited(obj)
+		queue.AddRateLimited(obj)
 		return true
 	}
 
@@ -157,10 +209,10 @@ func (h *QueuedEventHandler[T]) processItem(ctx context.Context) bool {
 		klog.ErrorS(err, "handler reported an error", "name", h.name)
 	}
 
-	h.workqueue.Forget(obj)
+	queue.Forget(obj)
 
 	if insight.ResyncAfter > 0 {
-		h.workqueue.AddAfter(
+		h.queueFor(insight.Object).AddAfter(
 			queueEvent{
 				kind:   event.kind,
 				object: insight.Object,
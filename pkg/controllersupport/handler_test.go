@@ -71,6 +71,61 @@ func TestQueuedEventHandler_RequeuesAfter(t *testing.T) {
 	assert.True(t, handler.isComplete())
 }
 
+func TestQueuedEventHandler_PriorityQueue(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		unblock     = make(chan struct{})
+		handler     = blockingHandler{
+			unblock:         unblock,
+			priorityHandled: cancel,
+		}
+		eventHandler = controllersupport.NewQueuedEventHandler[int](
+			&handler,
+			"test",
+			1,
+			controllersupport.WithPriorityQueue(func(v *int) bool { return *v < 0 }, 1),
+		)
+	)
+
+	defer cancel()
+	defer close(unblock)
+
+	// This one blocks the only regular worker...
+	eventHandler.OnAdd(generics.Ptr(1))
+	eventHandler.OnAdd(generics.Ptr(2))
+	// ...but the priority one still goes through.
+	eventHandler.OnAdd(generics.Ptr(-1))
+
+	eventHandler.Run(ctx)
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+// blockingHandler blocks on every regular event and cancels when it receives a priority event.
+type blockingHandler struct {
+	unblock         chan struct{}
+	priorityHandled func()
+}
+
+func (h *blockingHandler) OnAdd(_ context.Context, v *int) (controllersupport.EventInsight[int], error) {
+	if *v < 0 {
+		h.priorityHandled()
+		return controllersupport.EventInsight[int]{}, nil
+	}
+
+	<-h.unblock
+
+	return controllersupport.EventInsight[int]{}, nil
+}
+
+func (h *blockingHandler) OnUpdate(context.Context, *int, *int) (controllersupport.EventInsight[int], error) {
+	return controllersupport.EventInsight[int]{}, nil
+}
+
+func (h *blockingHandler) OnDelete(context.Context, *int) (controllersupport.EventInsight[int], error) {
+	return controllersupport.EventInsight[int]{}, nil
+}
+
 type testHandler[T any] struct {
 	addReceived    int
 	updateReceived int
@@ -24,6 +24,7 @@ type EscalationPoliciesGetter interface {
 type EscalationPolicyInterface interface {
 	Create(ctx context.Context, escalationPolicy *v1alpha1.EscalationPolicy, opts v1.CreateOptions) (*v1alpha1.EscalationPolicy, error)
 	Update(ctx context.Context, escalationPolicy *v1alpha1.EscalationPolicy, opts v1.UpdateOptions) (*v1alpha1.EscalationPolicy, error)
+	UpdateStatus(ctx context.Context, escalationPolicy *v1alpha1.EscalationPolicy, opts v1.UpdateOptions) (*v1alpha1.EscalationPolicy, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.EscalationPolicy, error)
@@ -112,6 +113,21 @@ func (c *escalationPolicies) Update(ctx context.Context, escalationPolicy *v1alp
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *escalationPolicies) UpdateStatus(ctx context.Context, escalationPolicy *v1alpha1.EscalationPolicy, opts v1.UpdateOptions) (result *v1alpha1.EscalationPolicy, err error) {
+	result = &v1alpha1.EscalationPolicy{}
+	err = c.client.Put().
+		Resource("escalationpolicies").
+		Name(escalationPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(escalationPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the escalationPolicy and deletes it. Returns an error if one occurs.
 func (c *escalationPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
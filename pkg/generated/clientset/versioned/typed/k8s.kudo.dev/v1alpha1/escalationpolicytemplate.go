@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	scheme "github.com/jlevesy/kudo/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// EscalationPolicyTemplatesGetter has a method to return a EscalationPolicyTemplateInterface.
+// A group's client should implement this interface.
+type EscalationPolicyTemplatesGetter interface {
+	EscalationPolicyTemplates() EscalationPolicyTemplateInterface
+}
+
+// EscalationPolicyTemplateInterface has methods to work with EscalationPolicyTemplate resources.
+type EscalationPolicyTemplateInterface interface {
+	Create(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.CreateOptions) (*v1alpha1.EscalationPolicyTemplate, error)
+	Update(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.UpdateOptions) (*v1alpha1.EscalationPolicyTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.EscalationPolicyTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.EscalationPolicyTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.EscalationPolicyTemplate, err error)
+	EscalationPolicyTemplateExpansion
+}
+
+// escalationPolicyTemplates implements EscalationPolicyTemplateInterface
+type escalationPolicyTemplates struct {
+	client rest.Interface
+}
+
+// newEscalationPolicyTemplates returns a EscalationPolicyTemplates
+func newEscalationPolicyTemplates(c *K8sV1alpha1Client) *escalationPolicyTemplates {
+	return &escalationPolicyTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the escalationPolicyTemplate, and returns the corresponding escalationPolicyTemplate object, and an error if there is any.
+func (c *escalationPolicyTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	result = &v1alpha1.EscalationPolicyTemplate{}
+	err = c.client.Get().
+		Resource("escalationpolicytemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of EscalationPolicyTemplates that match those selectors.
+func (c *escalationPolicyTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.EscalationPolicyTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.EscalationPolicyTemplateList{}
+	err = c.client.Get().
+		Resource("escalationpolicytemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested escalationPolicyTemplates.
+func (c *escalationPolicyTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("escalationpolicytemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a escalationPolicyTemplate and creates it.  Returns the server's representation of the escalationPolicyTemplate, and an error, if there is any.
+func (c *escalationPolicyTemplates) Create(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.CreateOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	result = &v1alpha1.EscalationPolicyTemplate{}
+	err = c.client.Post().
+		Resource("escalationpolicytemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(escalationPolicyTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a escalationPolicyTemplate and updates it. Returns the server's representation of the escalationPolicyTemplate, and an error, if there is any.
+func (c *escalationPolicyTemplates) Update(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.UpdateOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	result = &v1alpha1.EscalationPolicyTemplate{}
+	err = c.client.Put().
+		Resource("escalationpolicytemplates").
+		Name(escalationPolicyTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(escalationPolicyTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the escalationPolicyTemplate and deletes it. Returns an error if one occurs.
+func (c *escalationPolicyTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("escalationpolicytemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *escalationPolicyTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("escalationpolicytemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched escalationPolicyTemplate.
+func (c *escalationPolicyTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	result = &v1alpha1.EscalationPolicyTemplate{}
+	err = c.client.Patch(pt).
+		Resource("escalationpolicytemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
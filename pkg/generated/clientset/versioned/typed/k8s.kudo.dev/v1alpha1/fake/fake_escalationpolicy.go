@@ -80,6 +80,17 @@ func (c *FakeEscalationPolicies) Update(ctx context.Context, escalationPolicy *v
 	return obj.(*v1alpha1.EscalationPolicy), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeEscalationPolicies) UpdateStatus(ctx context.Context, escalationPolicy *v1alpha1.EscalationPolicy, opts v1.UpdateOptions) (*v1alpha1.EscalationPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(escalationpoliciesResource, "status", escalationPolicy), &v1alpha1.EscalationPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EscalationPolicy), err
+}
+
 // Delete takes name of the escalationPolicy and deletes it. Returns an error if one occurs.
 func (c *FakeEscalationPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeEscalationPolicyTemplates implements EscalationPolicyTemplateInterface
+type FakeEscalationPolicyTemplates struct {
+	Fake *FakeK8sV1alpha1
+}
+
+var escalationpolicytemplatesResource = schema.GroupVersionResource{Group: "k8s.kudo.dev", Version: "v1alpha1", Resource: "escalationpolicytemplates"}
+
+var escalationpolicytemplatesKind = schema.GroupVersionKind{Group: "k8s.kudo.dev", Version: "v1alpha1", Kind: "EscalationPolicyTemplate"}
+
+// Get takes name of the escalationPolicyTemplate, and returns the corresponding escalationPolicyTemplate object, and an error if there is any.
+func (c *FakeEscalationPolicyTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(escalationpolicytemplatesResource, name), &v1alpha1.EscalationPolicyTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EscalationPolicyTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of EscalationPolicyTemplates that match those selectors.
+func (c *FakeEscalationPolicyTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.EscalationPolicyTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(escalationpolicytemplatesResource, escalationpolicytemplatesKind, opts), &v1alpha1.EscalationPolicyTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.EscalationPolicyTemplateList{ListMeta: obj.(*v1alpha1.EscalationPolicyTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.EscalationPolicyTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested escalationPolicyTemplates.
+func (c *FakeEscalationPolicyTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(escalationpolicytemplatesResource, opts))
+}
+
+// Create takes the representation of a escalationPolicyTemplate and creates it.  Returns the server's representation of the escalationPolicyTemplate, and an error, if there is any.
+func (c *FakeEscalationPolicyTemplates) Create(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.CreateOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(escalationpolicytemplatesResource, escalationPolicyTemplate), &v1alpha1.EscalationPolicyTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EscalationPolicyTemplate), err
+}
+
+// Update takes the representation of a escalationPolicyTemplate and updates it. Returns the server's representation of the escalationPolicyTemplate, and an error, if there is any.
+func (c *FakeEscalationPolicyTemplates) Update(ctx context.Context, escalationPolicyTemplate *v1alpha1.EscalationPolicyTemplate, opts v1.UpdateOptions) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(escalationpolicytemplatesResource, escalationPolicyTemplate), &v1alpha1.EscalationPolicyTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EscalationPolicyTemplate), err
+}
+
+// Delete takes name of the escalationPolicyTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeEscalationPolicyTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(escalationpolicytemplatesResource, name, opts), &v1alpha1.EscalationPolicyTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeEscalationPolicyTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(escalationpolicytemplatesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.EscalationPolicyTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched escalationPolicyTemplate.
+func (c *FakeEscalationPolicyTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.EscalationPolicyTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(escalationpolicytemplatesResource, name, pt, data, subresources...), &v1alpha1.EscalationPolicyTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EscalationPolicyTemplate), err
+}
@@ -20,6 +20,14 @@ func (c *FakeK8sV1alpha1) EscalationPolicies() v1alpha1.EscalationPolicyInterfac
 	return &FakeEscalationPolicies{c}
 }
 
+func (c *FakeK8sV1alpha1) EscalationPolicyTemplates() v1alpha1.EscalationPolicyTemplateInterface {
+	return &FakeEscalationPolicyTemplates{c}
+}
+
+func (c *FakeK8sV1alpha1) NamespacedEscalationPolicies(namespace string) v1alpha1.NamespacedEscalationPolicyInterface {
+	return &FakeNamespacedEscalationPolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeK8sV1alpha1) RESTClient() rest.Interface {
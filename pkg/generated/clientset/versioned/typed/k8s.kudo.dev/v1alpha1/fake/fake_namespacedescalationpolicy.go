@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNamespacedEscalationPolicies implements NamespacedEscalationPolicyInterface
+type FakeNamespacedEscalationPolicies struct {
+	Fake *FakeK8sV1alpha1
+	ns   string
+}
+
+var namespacedescalationpoliciesResource = schema.GroupVersionResource{Group: "k8s.kudo.dev", Version: "v1alpha1", Resource: "namespacedescalationpolicies"}
+
+var namespacedescalationpoliciesKind = schema.GroupVersionKind{Group: "k8s.kudo.dev", Version: "v1alpha1", Kind: "NamespacedEscalationPolicy"}
+
+// Get takes name of the namespacedEscalationPolicy, and returns the corresponding namespacedEscalationPolicy object, and an error if there is any.
+func (c *FakeNamespacedEscalationPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(namespacedescalationpoliciesResource, c.ns, name), &v1alpha1.NamespacedEscalationPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespacedEscalationPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of NamespacedEscalationPolicies that match those selectors.
+func (c *FakeNamespacedEscalationPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespacedEscalationPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(namespacedescalationpoliciesResource, namespacedescalationpoliciesKind, c.ns, opts), &v1alpha1.NamespacedEscalationPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.NamespacedEscalationPolicyList{ListMeta: obj.(*v1alpha1.NamespacedEscalationPolicyList).ListMeta}
+	for _, item := range obj.(*v1alpha1.NamespacedEscalationPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespacedEscalationPolicies.
+func (c *FakeNamespacedEscalationPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(namespacedescalationpoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a namespacedEscalationPolicy and creates it.  Returns the server's representation of the namespacedEscalationPolicy, and an error, if there is any.
+func (c *FakeNamespacedEscalationPolicies) Create(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.CreateOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(namespacedescalationpoliciesResource, c.ns, namespacedEscalationPolicy), &v1alpha1.NamespacedEscalationPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespacedEscalationPolicy), err
+}
+
+// Update takes the representation of a namespacedEscalationPolicy and updates it. Returns the server's representation of the namespacedEscalationPolicy, and an error, if there is any.
+func (c *FakeNamespacedEscalationPolicies) Update(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.UpdateOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(namespacedescalationpoliciesResource, c.ns, namespacedEscalationPolicy), &v1alpha1.NamespacedEscalationPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespacedEscalationPolicy), err
+}
+
+// Delete takes name of the namespacedEscalationPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeNamespacedEscalationPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(namespacedescalationpoliciesResource, c.ns, name, opts), &v1alpha1.NamespacedEscalationPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNamespacedEscalationPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(namespacedescalationpoliciesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.NamespacedEscalationPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched namespacedEscalationPolicy.
+func (c *FakeNamespacedEscalationPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(namespacedescalationpoliciesResource, c.ns, name, pt, data, subresources...), &v1alpha1.NamespacedEscalationPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespacedEscalationPolicy), err
+}
@@ -5,3 +5,7 @@ package v1alpha1
 type EscalationExpansion interface{}
 
 type EscalationPolicyExpansion interface{}
+
+type EscalationPolicyTemplateExpansion interface{}
+
+type NamespacedEscalationPolicyExpansion interface{}
@@ -14,6 +14,8 @@ type K8sV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	EscalationsGetter
 	EscalationPoliciesGetter
+	EscalationPolicyTemplatesGetter
+	NamespacedEscalationPoliciesGetter
 }
 
 // K8sV1alpha1Client is used to interact with features provided by the k8s.kudo.dev group.
@@ -29,6 +31,14 @@ func (c *K8sV1alpha1Client) EscalationPolicies() EscalationPolicyInterface {
 	return newEscalationPolicies(c)
 }
 
+func (c *K8sV1alpha1Client) EscalationPolicyTemplates() EscalationPolicyTemplateInterface {
+	return newEscalationPolicyTemplates(c)
+}
+
+func (c *K8sV1alpha1Client) NamespacedEscalationPolicies(namespace string) NamespacedEscalationPolicyInterface {
+	return newNamespacedEscalationPolicies(c, namespace)
+}
+
 // NewForConfig creates a new K8sV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
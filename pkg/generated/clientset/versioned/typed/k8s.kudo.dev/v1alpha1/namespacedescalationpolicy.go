@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	scheme "github.com/jlevesy/kudo/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NamespacedEscalationPoliciesGetter has a method to return a NamespacedEscalationPolicyInterface.
+// A group's client should implement this interface.
+type NamespacedEscalationPoliciesGetter interface {
+	NamespacedEscalationPolicies(namespace string) NamespacedEscalationPolicyInterface
+}
+
+// NamespacedEscalationPolicyInterface has methods to work with NamespacedEscalationPolicy resources.
+type NamespacedEscalationPolicyInterface interface {
+	Create(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.CreateOptions) (*v1alpha1.NamespacedEscalationPolicy, error)
+	Update(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.UpdateOptions) (*v1alpha1.NamespacedEscalationPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NamespacedEscalationPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NamespacedEscalationPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespacedEscalationPolicy, err error)
+	NamespacedEscalationPolicyExpansion
+}
+
+// namespacedEscalationPolicies implements NamespacedEscalationPolicyInterface
+type namespacedEscalationPolicies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newNamespacedEscalationPolicies returns a NamespacedEscalationPolicies
+func newNamespacedEscalationPolicies(c *K8sV1alpha1Client, namespace string) *namespacedEscalationPolicies {
+	return &namespacedEscalationPolicies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the namespacedEscalationPolicy, and returns the corresponding namespacedEscalationPolicy object, and an error if there is any.
+func (c *namespacedEscalationPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	result = &v1alpha1.NamespacedEscalationPolicy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NamespacedEscalationPolicies that match those selectors.
+func (c *namespacedEscalationPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespacedEscalationPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NamespacedEscalationPolicyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested namespacedEscalationPolicies.
+func (c *namespacedEscalationPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a namespacedEscalationPolicy and creates it.  Returns the server's representation of the namespacedEscalationPolicy, and an error, if there is any.
+func (c *namespacedEscalationPolicies) Create(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.CreateOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	result = &v1alpha1.NamespacedEscalationPolicy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespacedEscalationPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a namespacedEscalationPolicy and updates it. Returns the server's representation of the namespacedEscalationPolicy, and an error, if there is any.
+func (c *namespacedEscalationPolicies) Update(ctx context.Context, namespacedEscalationPolicy *v1alpha1.NamespacedEscalationPolicy, opts v1.UpdateOptions) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	result = &v1alpha1.NamespacedEscalationPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		Name(namespacedEscalationPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespacedEscalationPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the namespacedEscalationPolicy and deletes it. Returns an error if one occurs.
+func (c *namespacedEscalationPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *namespacedEscalationPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched namespacedEscalationPolicy.
+func (c *namespacedEscalationPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespacedEscalationPolicy, err error) {
+	result = &v1alpha1.NamespacedEscalationPolicy{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("namespacedescalationpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
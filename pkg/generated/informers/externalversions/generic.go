@@ -41,6 +41,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().Escalations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("escalationpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().EscalationPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("escalationpolicytemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().EscalationPolicyTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("namespacedescalationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().NamespacedEscalationPolicies().Informer()}, nil
 
 	}
 
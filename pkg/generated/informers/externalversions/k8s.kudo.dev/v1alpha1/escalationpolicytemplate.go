@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	k8skudodevv1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	versioned "github.com/jlevesy/kudo/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/jlevesy/kudo/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/jlevesy/kudo/pkg/generated/listers/k8s.kudo.dev/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// EscalationPolicyTemplateInformer provides access to a shared informer and lister for
+// EscalationPolicyTemplates.
+type EscalationPolicyTemplateInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.EscalationPolicyTemplateLister
+}
+
+type escalationPolicyTemplateInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewEscalationPolicyTemplateInformer constructs a new informer for EscalationPolicyTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewEscalationPolicyTemplateInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredEscalationPolicyTemplateInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredEscalationPolicyTemplateInformer constructs a new informer for EscalationPolicyTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredEscalationPolicyTemplateInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.K8sV1alpha1().EscalationPolicyTemplates().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.K8sV1alpha1().EscalationPolicyTemplates().Watch(context.TODO(), options)
+			},
+		},
+		&k8skudodevv1alpha1.EscalationPolicyTemplate{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *escalationPolicyTemplateInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredEscalationPolicyTemplateInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *escalationPolicyTemplateInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&k8skudodevv1alpha1.EscalationPolicyTemplate{}, f.defaultInformer)
+}
+
+func (f *escalationPolicyTemplateInformer) Lister() v1alpha1.EscalationPolicyTemplateLister {
+	return v1alpha1.NewEscalationPolicyTemplateLister(f.Informer().GetIndexer())
+}
@@ -12,6 +12,10 @@ type Interface interface {
 	Escalations() EscalationInformer
 	// EscalationPolicies returns a EscalationPolicyInformer.
 	EscalationPolicies() EscalationPolicyInformer
+	// EscalationPolicyTemplates returns a EscalationPolicyTemplateInformer.
+	EscalationPolicyTemplates() EscalationPolicyTemplateInformer
+	// NamespacedEscalationPolicies returns a NamespacedEscalationPolicyInformer.
+	NamespacedEscalationPolicies() NamespacedEscalationPolicyInformer
 }
 
 type version struct {
@@ -34,3 +38,13 @@ func (v *version) Escalations() EscalationInformer {
 func (v *version) EscalationPolicies() EscalationPolicyInformer {
 	return &escalationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// EscalationPolicyTemplates returns a EscalationPolicyTemplateInformer.
+func (v *version) EscalationPolicyTemplates() EscalationPolicyTemplateInformer {
+	return &escalationPolicyTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NamespacedEscalationPolicies returns a NamespacedEscalationPolicyInformer.
+func (v *version) NamespacedEscalationPolicies() NamespacedEscalationPolicyInformer {
+	return &namespacedEscalationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
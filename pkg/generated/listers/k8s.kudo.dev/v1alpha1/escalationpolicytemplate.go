@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EscalationPolicyTemplateLister helps list EscalationPolicyTemplates.
+// All objects returned here must be treated as read-only.
+type EscalationPolicyTemplateLister interface {
+	// List lists all EscalationPolicyTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.EscalationPolicyTemplate, err error)
+	// Get retrieves the EscalationPolicyTemplate from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.EscalationPolicyTemplate, error)
+	EscalationPolicyTemplateListerExpansion
+}
+
+// escalationPolicyTemplateLister implements the EscalationPolicyTemplateLister interface.
+type escalationPolicyTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewEscalationPolicyTemplateLister returns a new EscalationPolicyTemplateLister.
+func NewEscalationPolicyTemplateLister(indexer cache.Indexer) EscalationPolicyTemplateLister {
+	return &escalationPolicyTemplateLister{indexer: indexer}
+}
+
+// List lists all EscalationPolicyTemplates in the indexer.
+func (s *escalationPolicyTemplateLister) List(selector labels.Selector) (ret []*v1alpha1.EscalationPolicyTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.EscalationPolicyTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the EscalationPolicyTemplate from the index for a given name.
+func (s *escalationPolicyTemplateLister) Get(name string) (*v1alpha1.EscalationPolicyTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("escalationpolicytemplate"), name)
+	}
+	return obj.(*v1alpha1.EscalationPolicyTemplate), nil
+}
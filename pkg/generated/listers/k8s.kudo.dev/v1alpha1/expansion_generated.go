@@ -9,3 +9,15 @@ type EscalationListerExpansion interface{}
 // EscalationPolicyListerExpansion allows custom methods to be added to
 // EscalationPolicyLister.
 type EscalationPolicyListerExpansion interface{}
+
+// EscalationPolicyTemplateListerExpansion allows custom methods to be added to
+// EscalationPolicyTemplateLister.
+type EscalationPolicyTemplateListerExpansion interface{}
+
+// NamespacedEscalationPolicyListerExpansion allows custom methods to be added to
+// NamespacedEscalationPolicyLister.
+type NamespacedEscalationPolicyListerExpansion interface{}
+
+// NamespacedEscalationPolicyNamespaceListerExpansion allows custom methods to be added to
+// NamespacedEscalationPolicyNamespaceLister.
+type NamespacedEscalationPolicyNamespaceListerExpansion interface{}
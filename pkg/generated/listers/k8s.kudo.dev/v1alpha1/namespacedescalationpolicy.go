@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jlevesy/kudo/pkg/apis/k8s.kudo.dev/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespacedEscalationPolicyLister helps list NamespacedEscalationPolicies.
+// All objects returned here must be treated as read-only.
+type NamespacedEscalationPolicyLister interface {
+	// List lists all NamespacedEscalationPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespacedEscalationPolicy, err error)
+	// NamespacedEscalationPolicies returns an object that can list and get NamespacedEscalationPolicies.
+	NamespacedEscalationPolicies(namespace string) NamespacedEscalationPolicyNamespaceLister
+	NamespacedEscalationPolicyListerExpansion
+}
+
+// namespacedEscalationPolicyLister implements the NamespacedEscalationPolicyLister interface.
+type namespacedEscalationPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewNamespacedEscalationPolicyLister returns a new NamespacedEscalationPolicyLister.
+func NewNamespacedEscalationPolicyLister(indexer cache.Indexer) NamespacedEscalationPolicyLister {
+	return &namespacedEscalationPolicyLister{indexer: indexer}
+}
+
+// List lists all NamespacedEscalationPolicies in the indexer.
+func (s *namespacedEscalationPolicyLister) List(selector labels.Selector) (ret []*v1alpha1.NamespacedEscalationPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespacedEscalationPolicy))
+	})
+	return ret, err
+}
+
+// NamespacedEscalationPolicies returns an object that can list and get NamespacedEscalationPolicies.
+func (s *namespacedEscalationPolicyLister) NamespacedEscalationPolicies(namespace string) NamespacedEscalationPolicyNamespaceLister {
+	return namespacedEscalationPolicyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// NamespacedEscalationPolicyNamespaceLister helps list and get NamespacedEscalationPolicies.
+// All objects returned here must be treated as read-only.
+type NamespacedEscalationPolicyNamespaceLister interface {
+	// List lists all NamespacedEscalationPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespacedEscalationPolicy, err error)
+	// Get retrieves the NamespacedEscalationPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.NamespacedEscalationPolicy, error)
+	NamespacedEscalationPolicyNamespaceListerExpansion
+}
+
+// namespacedEscalationPolicyNamespaceLister implements the NamespacedEscalationPolicyNamespaceLister
+// interface.
+type namespacedEscalationPolicyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all NamespacedEscalationPolicies in the indexer for a given namespace.
+func (s namespacedEscalationPolicyNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.NamespacedEscalationPolicy, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespacedEscalationPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the NamespacedEscalationPolicy from the indexer for a given namespace and name.
+func (s namespacedEscalationPolicyNamespaceLister) Get(name string) (*v1alpha1.NamespacedEscalationPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("namespacedescalationpolicy"), name)
+	}
+	return obj.(*v1alpha1.NamespacedEscalationPolicy), nil
+}